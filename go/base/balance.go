@@ -0,0 +1,82 @@
+package base
+
+import (
+	"strings"
+)
+
+// 统一的账户类型, FetchBalance 通过参数 "type" 选择账户, 不传时使用各交易所的默认账户
+const (
+	AccountTypeSpot     = "spot"
+	AccountTypeMargin   = "margin"   // 全仓杠杆
+	AccountTypeIsolated = "isolated" // 逐仓杠杆
+	AccountTypeFutures  = "futures"  // 交割合约
+	AccountTypeSwap     = "swap"     // 永续合约
+	AccountTypeFunding  = "funding"  // 资金账户
+)
+
+// 各交易所原有的账户名称, 兼容以前的参数
+var accountTypeAliases = map[string]string{
+	"cash":            AccountTypeSpot,
+	"trade":           AccountTypeSpot,
+	"trade_hf":        AccountTypeSpot,
+	"exchange":        AccountTypeSpot,
+	"cross":           AccountTypeMargin,
+	"cross_margin":    AccountTypeMargin,
+	"isolated_margin": AccountTypeIsolated,
+	"future":          AccountTypeFutures,
+	"delivery":        AccountTypeFutures,
+	"perpetual":       AccountTypeSwap,
+	"contract":        AccountTypeSwap,
+	"main":            AccountTypeFunding,
+	"account":         AccountTypeFunding,
+	"fund":            AccountTypeFunding,
+	"wallet":          AccountTypeFunding,
+}
+
+// AccountType 取出 params 中的账户类型并转换为统一的名称, 不传时为 defaultType.
+// 返回的 params 为去掉 type 后的副本
+func (self *Exchange) AccountType(params map[string]interface{}, defaultType string) (string, map[string]interface{}) {
+	query := self.Extend(params).(map[string]interface{})
+	typ := strings.ToLower(self.SafeString(query, "type", defaultType))
+	delete(query, "type")
+	if alias, ok := accountTypeAliases[typ]; ok {
+		typ = alias
+	}
+	switch typ {
+	case AccountTypeSpot, AccountTypeMargin, AccountTypeIsolated, AccountTypeFutures, AccountTypeSwap, AccountTypeFunding:
+		return typ, query
+	}
+	self.RaiseException("BadRequest", self.Id+" unknown account type "+typ)
+	return "", nil
+}
+
+// UnsupportedAccountType 交易所不支持该账户类型
+func (self *Exchange) UnsupportedAccountType(typ string) {
+	self.RaiseException("NotSupported", self.Id+" fetchBalance does not support the "+typ+" account")
+}
+
+// FetchBalances 依次获取多个账户的余额, 结果以账户类型为 key. types 为空时获取 Options["accountTypes"] 中的所有账户.
+// 部分账户失败时返回成功的余额和第一个错误
+func (self *Exchange) FetchBalances(types []string, params map[string]interface{}) (map[string]*Account, error) {
+	if len(types) == 0 {
+		for _, typ := range self.SafeValue(self.Options, "accountTypes", []interface{}{}).([]interface{}) {
+			types = append(types, typ.(string))
+		}
+	}
+	var err error
+	result := map[string]*Account{}
+	for _, typ := range types {
+		request := self.Extend(params, map[string]interface{}{
+			"type": typ,
+		}).(map[string]interface{})
+		balance, e := self.Child.FetchBalance(request)
+		if e != nil {
+			if err == nil {
+				err = e
+			}
+			continue
+		}
+		result[typ] = balance
+	}
+	return result, err
+}
@@ -0,0 +1,156 @@
+package base
+
+import (
+	"sync"
+	"time"
+)
+
+// 公共接口响应缓存, 默认关闭. 开启后同一个公共 GET 请求在 TTL 内直接返回上次的响应,
+// 并发的相同请求只发出一次 HTTP 请求. 每个调用者得到响应的副本, 解析时可以修改.
+// 只缓存 Describe 中标记了 "cacheable": true 的 api, 单次调用可以传参数 "noCache": true 跳过缓存
+
+type cacheEntry struct {
+	response interface{}
+	expires  time.Time
+}
+
+// 正在进行的请求, 相同请求的调用者等待其结果
+type inflightRequest struct {
+	wg       sync.WaitGroup
+	response interface{}
+	panicVal interface{}
+}
+
+type responseCache struct {
+	sync.Mutex
+	defaultTTL time.Duration
+	ttls       map[string]time.Duration // 按 path 设置的 TTL
+	entries    map[string]*cacheEntry
+	inflight   map[string]*inflightRequest
+}
+
+func (self *Exchange) getCache() *responseCache {
+	self.cacheMutex.Lock()
+	defer self.cacheMutex.Unlock()
+	if self.cache == nil {
+		self.cache = &responseCache{
+			ttls:     map[string]time.Duration{},
+			entries:  map[string]*cacheEntry{},
+			inflight: map[string]*inflightRequest{},
+		}
+	}
+	return self.cache
+}
+
+// EnableCache 开启公共接口缓存, ttl 为所有公共接口默认的缓存时间, 为 0 时只合并并发的相同请求
+func (self *Exchange) EnableCache(ttl time.Duration) {
+	cache := self.getCache()
+	cache.Lock()
+	defer cache.Unlock()
+	cache.defaultTTL = ttl
+}
+
+// SetCacheTTL 设置单个接口的缓存时间, path 和 Describe 中 api 的路径一致, 如 "depth", "market/stats".
+// ttl 小于 0 表示该接口不缓存也不合并请求
+func (self *Exchange) SetCacheTTL(path string, ttl time.Duration) {
+	cache := self.getCache()
+	cache.Lock()
+	defer cache.Unlock()
+	cache.ttls[path] = ttl
+}
+
+// DisableCache 关闭缓存并清空已缓存的响应
+func (self *Exchange) DisableCache() {
+	self.cacheMutex.Lock()
+	defer self.cacheMutex.Unlock()
+	self.cache = nil
+}
+
+// 只缓存 cacheable 的 api 的 GET 请求, 返回是否使用缓存以及缓存时间
+func (self *Exchange) cacheTTL(path string, api string, method string) (time.Duration, bool) {
+	self.cacheMutex.Lock()
+	cache := self.cache
+	self.cacheMutex.Unlock()
+	if cache == nil || method != "GET" {
+		return 0, false
+	}
+	apiInfo := self.SafeValue(self.DescribeMap["api"], api, nil)
+	if cacheable, _ := self.SafeValue(apiInfo, "cacheable", false).(bool); !cacheable {
+		return 0, false
+	}
+	cache.Lock()
+	defer cache.Unlock()
+	if ttl, ok := cache.ttls[path]; ok {
+		return ttl, ttl >= 0
+	}
+	return cache.defaultTTL, true
+}
+
+// cachedRequest 返回未过期的缓存, 否则合并相同的并发请求后调用 fetch. 返回的都是副本
+func (self *Exchange) cachedRequest(key string, ttl time.Duration, fetch func() interface{}) interface{} {
+	cache := self.getCache()
+	cache.Lock()
+	if entry, ok := cache.entries[key]; ok && time.Now().Before(entry.expires) {
+		cache.Unlock()
+		return copyResponse(entry.response)
+	}
+	if call, ok := cache.inflight[key]; ok {
+		cache.Unlock()
+		call.wg.Wait()
+		if call.panicVal != nil {
+			panic(call.panicVal)
+		}
+		return copyResponse(call.response)
+	}
+	call := &inflightRequest{}
+	call.wg.Add(1)
+	cache.inflight[key] = call
+	cache.Unlock()
+
+	defer func() {
+		call.panicVal = recover()
+		cache.Lock()
+		delete(cache.inflight, key)
+		if call.panicVal == nil && ttl > 0 {
+			// 请求参数(如 since)不同时 key 也不同, 缓存较多时清理过期的响应
+			if len(cache.entries) >= 1000 {
+				now := time.Now()
+				for k, entry := range cache.entries {
+					if now.After(entry.expires) {
+						delete(cache.entries, k)
+					}
+				}
+			}
+			cache.entries[key] = &cacheEntry{
+				response: call.response,
+				expires:  time.Now().Add(ttl),
+			}
+		}
+		cache.Unlock()
+		call.wg.Done()
+		if call.panicVal != nil {
+			panic(call.panicVal)
+		}
+	}()
+	call.response = fetch()
+	return copyResponse(call.response)
+}
+
+// copyResponse 深拷贝解码后的 JSON, 其他类型的值不可变, 直接返回
+func copyResponse(response interface{}) interface{} {
+	switch value := response.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[k] = copyResponse(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = copyResponse(v)
+		}
+		return result
+	}
+	return response
+}
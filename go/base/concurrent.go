@@ -0,0 +1,159 @@
+package base
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// 并发请求默认的协程数, 可通过 ExchangeConfig.Concurrency 修改
+const defaultConcurrency = 4
+
+// OrderBookResult 批量获取深度中单个交易对的结果, Error 不为空表示该交易对失败
+type OrderBookResult struct {
+	Symbol    string     `json:"symbol"`
+	OrderBook *OrderBook `json:"orderBook"`
+	Error     error      `json:"error"`
+}
+
+// TickerResult 批量获取行情中单个交易对的结果, Error 不为空表示该交易对失败
+type TickerResult struct {
+	Symbol string  `json:"symbol"`
+	Ticker *Ticker `json:"ticker"`
+	Error  error   `json:"error"`
+}
+
+// OHLCVResult 批量获取 K 线中单个交易对的结果, Error 不为空表示该交易对失败
+type OHLCVResult struct {
+	Symbol string   `json:"symbol"`
+	OHLCV  []*OHLCV `json:"ohlcv"`
+	Error  error    `json:"error"`
+}
+
+// NOTE: 和 OrderResult 一样, Error 序列化为字符串
+func (self *OrderBookResult) MarshalJSON() ([]byte, error) {
+	type orderBookResult OrderBookResult
+	return json.Marshal(&struct {
+		*orderBookResult
+		Error string `json:"error"`
+	}{(*orderBookResult)(self), errorString(self.Error)})
+}
+
+func (self *TickerResult) MarshalJSON() ([]byte, error) {
+	type tickerResult TickerResult
+	return json.Marshal(&struct {
+		*tickerResult
+		Error string `json:"error"`
+	}{(*tickerResult)(self), errorString(self.Error)})
+}
+
+func (self *OHLCVResult) MarshalJSON() ([]byte, error) {
+	type ohlcvResult OHLCVResult
+	return json.Marshal(&struct {
+		*ohlcvResult
+		Error string `json:"error"`
+	}{(*ohlcvResult)(self), errorString(self.Error)})
+}
+
+// forEachSymbol 用 Concurrency 个协程并发执行 fn, 所有交易对执行完后返回.
+// NOTE: 请求仍然经过实例的限频, 未开启 EnableRateLimit 时也会按 RateLimit 间隔发出
+func (self *Exchange) forEachSymbol(symbols []string, fn func(i int, symbol string)) {
+	self.forEach(len(symbols), func(i int) {
+		fn(i, symbols[i])
+	})
+}
+
+// forEach 用 Concurrency 个协程并发执行 fn(0) 到 fn(n-1), CreateOrders 和 forEachSymbol 共用
+func (self *Exchange) forEach(n int, fn func(i int)) {
+	// 并发前先加载市场信息, 避免多个协程同时写 Markets
+	self.Child.LoadMarkets()
+	workers := self.Concurrency
+	if workers <= 0 {
+		workers = defaultConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if !self.EnableRateLimit {
+					self.Throttle()
+				}
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// 单个交易对的 panic 不影响其他交易对
+func (self *Exchange) recoverToError(err *error) {
+	if e := recover(); e != nil {
+		*err = self.PanicToError(e)
+	}
+}
+
+// FetchOrderBooks 并发获取多个交易对的深度, 结果和 symbols 顺序一致
+func (self *Exchange) FetchOrderBooks(symbols []string, limit int64, params map[string]interface{}) []*OrderBookResult {
+	results := make([]*OrderBookResult, len(symbols))
+	self.forEachSymbol(symbols, func(i int, symbol string) {
+		result := &OrderBookResult{Symbol: symbol}
+		results[i] = result
+		defer self.recoverToError(&result.Error)
+		result.OrderBook, result.Error = self.Child.FetchOrderBook(symbol, limit, params)
+	})
+	return results
+}
+
+// FetchTickerResults 并发调用 FetchTicker 获取多个交易对的行情, 结果和 symbols 顺序一致
+func (self *Exchange) FetchTickerResults(symbols []string, params map[string]interface{}) []*TickerResult {
+	results := make([]*TickerResult, len(symbols))
+	self.forEachSymbol(symbols, func(i int, symbol string) {
+		result := &TickerResult{Symbol: symbol}
+		results[i] = result
+		defer self.recoverToError(&result.Error)
+		result.Ticker, result.Error = self.Child.FetchTicker(symbol, params)
+	})
+	return results
+}
+
+// FetchOHLCVs 并发获取多个交易对的 K 线, 结果和 symbols 顺序一致
+func (self *Exchange) FetchOHLCVs(symbols []string, timeframe string, since int64, limit int64, params map[string]interface{}) []*OHLCVResult {
+	results := make([]*OHLCVResult, len(symbols))
+	self.forEachSymbol(symbols, func(i int, symbol string) {
+		result := &OHLCVResult{Symbol: symbol}
+		results[i] = result
+		defer self.recoverToError(&result.Error)
+		result.OHLCV, result.Error = self.Child.FetchOHLCV(symbol, timeframe, since, limit, params)
+	})
+	return results
+}
+
+// FetchTickers 没有批量行情接口的交易所用 FetchTicker 并发模拟, 必须指定 symbols.
+// 部分交易对失败时返回成功的行情和第一个错误
+func (self *Exchange) FetchTickers(symbols []string, params map[string]interface{}) ([]*Ticker, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("%s FetchTickers requires symbols", self.Id)
+	}
+	var err error
+	tickers := []*Ticker{}
+	for _, result := range self.FetchTickerResults(symbols, params) {
+		if result.Error != nil {
+			if err == nil {
+				err = result.Error
+			}
+			continue
+		}
+		tickers = append(tickers, result.Ticker)
+	}
+	return tickers, err
+}
@@ -0,0 +1,51 @@
+package base
+
+// CurrencyNetwork 币种在一条链上的充值提现信息
+type CurrencyNetwork struct {
+	Id            string      `json:"id"`      // 交易所的链名称
+	Network       string      `json:"network"` // 统一的链名称, 见 NetworkCode
+	Name          string      `json:"name"`
+	Active        bool        `json:"active"`
+	Deposit       bool        `json:"deposit"`
+	Withdraw      bool        `json:"withdraw"`
+	Fee           float64     `json:"fee"`      // 提现手续费
+	FeeKnown      bool        `json:"feeKnown"` // 交易所是否返回了手续费, 为 false 时 Fee 的 0 表示未知 (如 gateio 未配置 apiKey)
+	WithdrawMin   float64     `json:"withdrawMin"`
+	WithdrawMax   float64     `json:"withdrawMax"` // 交易所不返回时为 0
+	DepositMin    float64     `json:"depositMin"`
+	Confirmations int64       `json:"confirmations"`
+	Precision     int         `json:"precision"` // 提现数量的小数位数
+	Info          interface{} `json:"info"`
+}
+
+// CheapestNetwork 可以提现且手续费最低的链, 手续费未知的链不参与比较, 没有这样的链时返回 nil
+func (c *Currency) CheapestNetwork() *CurrencyNetwork {
+	var result *CurrencyNetwork
+	for _, network := range c.Networks {
+		if !network.Withdraw || !network.FeeKnown {
+			continue
+		}
+		if result == nil || network.Fee < result.Fee {
+			result = network
+		}
+	}
+	return result
+}
+
+// AddCurrencyNetwork 添加一条链, 并根据各条链更新币种的 Deposit, Withdraw, Active 和 Fee
+func (self *Exchange) AddCurrencyNetwork(currency *Currency, network *CurrencyNetwork) {
+	if currency.Networks == nil {
+		currency.Networks = map[string]*CurrencyNetwork{}
+	}
+	if network.Network == "" {
+		network.Network = self.NetworkCode(network.Id)
+	}
+	network.Active = network.Deposit && network.Withdraw
+	currency.Networks[network.Network] = network
+	currency.Deposit = currency.Deposit || network.Deposit
+	currency.Withdraw = currency.Withdraw || network.Withdraw
+	currency.Active = currency.Deposit && currency.Withdraw
+	if cheapest := currency.CheapestNetwork(); cheapest != nil {
+		currency.Fee = cheapest.Fee
+	}
+}
@@ -54,3 +54,19 @@ func TypedError(t string, msg string) error {
 	return fmt.Errorf("%w: %v", err, msg)
 }
 
+
+// EditOrderError 模拟改单时原订单已撤销但新订单下单失败, 调用者需要自行处理
+type EditOrderError struct {
+	Id            string // 已撤销的原订单
+	ClientOrderId string // 新订单使用的 clientOrderId
+	Canceled      *Order
+	Err           error
+}
+
+func (e *EditOrderError) Error() string {
+	return fmt.Sprintf("EditOrderError: cancel ok, replace failed: order %s canceled: %v", e.Id, e.Err)
+}
+
+func (e *EditOrderError) Unwrap() error {
+	return e.Err
+}
@@ -75,6 +75,8 @@ type Market struct {
 	Precision      Precision   `json:"precision"`
 	Limits         Limits      `json:"limits"`
 	Lot            float64     `json:"lot"`
+	ContractSize   float64     `json:"contractSize"` // 合约乘数, 每张合约对应的 base 数量, 反向合约为 quote 数量. 非合约或未知时为 0
+	Inverse        bool        `json:"inverse"`      // 反向合约, 以 base 结算
 	Info           interface{} `json:"info"`
 }
 
@@ -112,6 +114,7 @@ type ExchangeConfig struct {
 	EnableRateLimit bool          `json:"enableRateLimit"`
 	Test            bool          `json:"test"`
 	Verbose         bool          `json:"verbose"`
+	Concurrency     int           `json:"concurrency"` // FetchOrderBooks 等批量接口的并发数
 }
 
 // ExchangeInfo for the exchange
@@ -161,19 +164,6 @@ type Urls struct {
 	Fees StringSlice `json:"fees"`
 }
 
-/*
-// status = {
-//     'status': 'ok',
-//     'updated': None,
-//     'eta': None,
-//     'url': None,
-// }
-*/
-type ExchangeStatus struct {
-	Status  string // "ok", "maintenance"
-	Updated int64  // 更新时间戳?
-}
-
 // Exception takes the string and applies the error method
 type Exception map[string]error
 
@@ -330,10 +320,23 @@ type Balance struct {
 	RealPnl   float64 `json:"realPnl"`
 	UnrealPnl float64 `json:"unrealPnl"`
 	Total     float64 `json:"total"`
+	// 杠杆账户的 Free/Used/Total 为含借入资产的总额, 不扣除借款.
+	// 交易所不区分本金和利息时利息包含在 Borrowed 中, Interest 为 0
+	Borrowed  float64 `json:"borrowed"`
+	Interest  float64 `json:"interest"`
+	NetEquity float64 `json:"netEquity"` // 扣除借款和利息后的净资产
+	// 合约账户, 交易所不提供的字段为 0
+	WalletBalance     float64 `json:"walletBalance"` // 不含未实现盈亏
+	MarginBalance     float64 `json:"marginBalance"` // 含未实现盈亏
+	AvailableWithdraw float64 `json:"availableWithdraw"`
+	InitialMargin     float64 `json:"initialMargin"`
+	MaintenanceMargin float64 `json:"maintenanceMargin"`
+	CrossUnrealPnl    float64 `json:"crossUnrealPnl"` // 全仓仓位的未实现盈亏
 }
 
 // Account details
 type Account struct {
+	Type    string             `json:"type"` // 账户类型, 如 spot, margin, swap
 	Free    map[string]float64 `json:"free"`
 	Used    map[string]float64 `json:"used"`
 	Total   map[string]float64 `json:"total"`
@@ -349,16 +352,88 @@ type MarkPrice struct {
 	Info       interface{}
 }
 
+// FundingRate 永续合约当前周期的资金费率
+type FundingRate struct {
+	Symbol               string
+	FundingRate          float64 // 当前周期的资金费率, 在 FundingTimestamp 结算
+	FundingTimestamp     int64
+	NextFundingRate      float64 // 预测的下一周期资金费率, 交易所不提供时为 0
+	NextFundingTimestamp int64
+	MarkPrice            float64
+	IndexPrice           float64
+	Timestamp            int64
+	Info                 interface{}
+}
+
+// FundingRateHistory 已结算的历史资金费率
+type FundingRateHistory struct {
+	Symbol      string
+	FundingRate float64
+	Timestamp   int64 // 结算时间
+	Info        interface{}
+}
+
+// OpenInterest 持仓量, Amount 为合约张数(或币数), Value 为计价货币价值, 交易所不提供时为 0
+type OpenInterest struct {
+	Symbol    string
+	Amount    float64
+	Value     float64
+	Timestamp int64
+	Info      interface{}
+}
+
+// LeverageTier 分层杠杆, 仓位名义价值在 [MinNotional, MaxNotional) 之间时适用
+type LeverageTier struct {
+	Tier                  int64
+	Symbol                string
+	MinNotional           float64
+	MaxNotional           float64
+	MaintenanceMarginRate float64
+	MaxLeverage           float64
+	Info                  interface{}
+}
+
+// MarginModification 逐仓仓位追加或减少保证金的结果
+type MarginModification struct {
+	Symbol string
+	Type   string // add 或 reduce
+	Amount float64
+	Total  float64 // 调整后的仓位保证金, 交易所不返回时为 0
+	Info   interface{}
+}
+
 type Position struct {
-	Symbol     string
-	Side       string
-	Leverage   float64
-	Price      float64
-	Amount     float64
-	UsedAmount float64
-	UnrealPnl  float64
-	RealPnl    float64
-	Info       interface{}
+	Symbol            string
+	Side              string
+	Leverage          float64
+	Price             float64 // 开仓均价
+	Amount            float64 // 持仓数量, 合约交易所为张数
+	UsedAmount        float64
+	UnrealPnl         float64
+	RealPnl           float64
+	MarkPrice         float64
+	LiquidationPrice  float64 // 为 0 表示没有强平价格
+	Notional          float64 // 按标记价格计算的仓位价值
+	MarginMode        string  // cross 或 isolated
+	InitialMargin     float64
+	MaintenanceMargin float64
+	ContractSize      float64 // 每张合约对应的币数, 交易所不返回时为 0
+	Settle            string  // 结算币种, Notional 和盈亏以此计价, 反向合约为 base. 未知时为空
+	Timestamp         int64   // 最后更新时间
+	Datetime          string
+	Info              interface{}
+}
+
+// LiquidationDistance 标记价格距离强平价格的比例, 如 0.1 表示价格再不利变动 10% 会被强平.
+// 没有强平价格或标记价格时返回 0
+func (self *Position) LiquidationDistance() float64 {
+	if self.LiquidationPrice <= 0 || self.MarkPrice <= 0 {
+		return 0
+	}
+	if self.Side == "short" {
+		return (self.LiquidationPrice - self.MarkPrice) / self.MarkPrice
+	}
+	return (self.MarkPrice - self.LiquidationPrice) / self.MarkPrice
 }
 
 // Order structure
@@ -378,6 +453,7 @@ type Order struct {
 	Remaining     float64     `json:"remaining"`
 	Average       float64     `json:"average"`
 	Fee           float64     `json:"fee"`
+	StopPrice     float64     `json:"stopPrice"` // 条件单的触发价
 	Info          interface{} `json:"info"`
 }
 
@@ -431,6 +507,8 @@ func (o *Order) InitFromMap(m map[string]interface{}) (result *Order) {
 			o.Status = v.(string)
 		case "clientOrderId":
 			o.ClientOrderId = v.(string)
+		case "stopPrice":
+			o.StopPrice = v.(float64)
 		case "info":
 			o.Info = v
 		default:
@@ -441,6 +519,110 @@ func (o *Order) InitFromMap(m map[string]interface{}) (result *Order) {
 	return
 }
 
+// OrderResult 批量下单/撤单中单个订单的结果, Error 不为空表示该订单失败
+type OrderResult struct {
+	Id            string `json:"id"`
+	ClientOrderId string `json:"clientOrderId"`
+	Order         *Order `json:"order"`
+	Error         error  `json:"error"`
+}
+
+// OrderRequest 批量下单中的单个订单, 参数和 CreateOrder 一致
+type OrderRequest struct {
+	Symbol string                 `json:"symbol"`
+	Type   string                 `json:"type"`
+	Side   string                 `json:"side"`
+	Amount float64                `json:"amount"`
+	Price  float64                `json:"price"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// NOTE: error 类型序列化后为 {}, 所以转为字符串输出
+func (self *OrderResult) MarshalJSON() ([]byte, error) {
+	type orderResult OrderResult
+	return json.Marshal(&struct {
+		*orderResult
+		Error string `json:"error"`
+	}{(*orderResult)(self), errorString(self.Error)})
+}
+
+// errorString 批量结果中的错误序列化为字符串, 没有错误时为空字符串
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// TriggerOrder 统一的条件单参数, 由 ParseTriggerOrder 从 CreateOrder 的 params 中提取.
+// stopPrice/triggerPrice/stopLossPrice 为止损触发价, 买单在价格上涨到触发价时触发, 卖单在价格下跌到触发价时触发;
+// takeProfitPrice 为止盈触发价, 触发方向和止损相反; trailingPercent 为跟踪止损的回调比例, 单位为百分比, 1 表示 1%;
+// stopLoss/takeProfit 为开仓时附带的止损止盈, 值为 {"triggerPrice": x, "price": y} 或者直接为触发价,
+// 没有原生接口的交易所在开仓订单创建后立即下附带的只减仓条件单, 限价开仓单未成交时也是如此 (见 CreateAttachedOrders).
+// 触发后按 CreateOrder 的 type 下市价单或限价单
+type TriggerOrder struct {
+	StopPrice       float64
+	TakeProfitPrice float64
+	TrailingPercent float64
+	StopLoss        *AttachedOrder
+	TakeProfit      *AttachedOrder
+}
+
+// AttachedOrder 开仓时附带的止损或止盈单, Price 为 0 表示触发后下市价单
+type AttachedOrder struct {
+	TriggerPrice float64 `json:"triggerPrice"`
+	Price        float64 `json:"price"`
+}
+
+// TriggerPrice 返回触发价, 止损优先
+func (t *TriggerOrder) TriggerPrice() float64 {
+	if t.StopPrice > 0 {
+		return t.StopPrice
+	}
+	return t.TakeProfitPrice
+}
+
+// IsTakeProfit 是否为止盈单
+func (t *TriggerOrder) IsTakeProfit() bool {
+	return t.StopPrice <= 0 && t.TakeProfitPrice > 0
+}
+
+// IsAttached 是否只附带了止损止盈, 本身为普通订单
+func (t *TriggerOrder) IsAttached() bool {
+	return t.TriggerPrice() <= 0 && t.TrailingPercent <= 0
+}
+
+// Direction 返回触发方向, "up" 表示价格上涨到触发价时触发, "down" 表示价格下跌到触发价时触发
+func (t *TriggerOrder) Direction(side string) string {
+	up := side == "buy"
+	if t.IsTakeProfit() {
+		up = !up
+	}
+	if up {
+		return "up"
+	}
+	return "down"
+}
+
+// AttachedOrderError 开仓订单已成交或挂单, 但是附带的止损止盈下单失败. 已下的附带订单会被撤销,
+// 撤销失败的仍在 Remaining 中, 需要调用者处理
+type AttachedOrderError struct {
+	Order     *Order // 开仓订单
+	Remaining []*Order
+	Err       error
+}
+
+func (e *AttachedOrderError) Error() string {
+	if len(e.Remaining) > 0 {
+		return fmt.Sprintf("AttachedOrderError: order %s created, attached stop loss/take profit failed: %v, %d attached orders not canceled", e.Order.Id, e.Err, len(e.Remaining))
+	}
+	return fmt.Sprintf("AttachedOrderError: order %s created, attached stop loss/take profit failed: %v", e.Order.Id, e.Err)
+}
+
+func (e *AttachedOrderError) Unwrap() error {
+	return e.Err
+}
+
 // OrderBook struct
 type OrderBook struct {
 	Asks      [][2]float64
@@ -448,6 +630,9 @@ type OrderBook struct {
 	Timestamp int64
 	Datetime  string
 	Nonce     int64
+	// 价位的原始价格和数量字符串, 按价格索引, 由 UpdateRaw 维护, 用于计算校验和
+	rawAsks map[float64][2]string
+	rawBids map[float64][2]string
 }
 
 // BookEntry struct
@@ -517,16 +702,25 @@ type Ticker struct {
 
 // Currency struct
 type Currency struct {
-	Id        string `json:"id"`
-	Code      string `json:"code"`
-	NumericId string `json:"numericId"`
-	Precision int    `json:"precision"`
+	Id        string                      `json:"id"`
+	Code      string                      `json:"code"`
+	NumericId string                      `json:"numericId"`
+	Precision int                         `json:"precision"`
+	Name      string                      `json:"name"`
+	Active    bool                        `json:"active"`   // 可以充值且可以提现
+	Deposit   bool                        `json:"deposit"`  // 至少一条链可以充值
+	Withdraw  bool                        `json:"withdraw"` // 至少一条链可以提现
+	Fee       float64                     `json:"fee"`      // 可提现的链中最低的提现手续费
+	Networks  map[string]*CurrencyNetwork `json:"networks"` // key 为统一的链名称
+	Info      interface{}                 `json:"info"`
 }
 
 // DepositAddress struct
 type DepositAddress struct {
 	Currency string      `json:"currency"`
 	Address  string      `json:"address"`
+	Tag      string      `json:"tag"`     // memo 或 tag, 没有时为空
+	Network  string      `json:"network"` // 统一的链名称, 见 NetworkCode
 	Status   string      `json:"status"`
 	Info     interface{} `json:"info"`
 }
@@ -581,8 +775,11 @@ type ExchangeInterface interface {
 	FetchTicker(symbol string, params map[string]interface{}) (*Ticker, error)
 	FetchTickers(symbols []string, params map[string]interface{}) ([]*Ticker, error)
 	FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error)
+	FetchOrderBooks(symbols []string, limit int64, params map[string]interface{}) []*OrderBookResult
+	FetchTickerResults(symbols []string, params map[string]interface{}) []*TickerResult
+	FetchOHLCVs(symbols []string, timeframe string, since int64, limit int64, params map[string]interface{}) []*OHLCVResult
 	FetchOrderBook(symbol string, limit int64, params map[string]interface{}) (*OrderBook, error)
-	FetchStatus(params map[string]interface{}) (*ExchangeStatus, error) // 默认实现为返回 ok 状态
+	FetchStatus(params map[string]interface{}) (*ExchangeStatus, error) // 没有状态接口的交易所默认返回 ok 状态
 	// FetchL2OrderBook(symbol string, limit *int, params map[string]interface{}) (OrderBook, error)
 	FetchTrades(symbol string, since int64, limit int64, params map[string]interface{}) ([]*Trade, error)
 	FetchOrder(id string, symbol string, params map[string]interface{}) (*Order, error)
@@ -590,21 +787,77 @@ type ExchangeInterface interface {
 	FetchOpenOrders(symbol string, since int64, limit int64, params map[string]interface{}) ([]*Order, error)
 	// FetchClosedOrders(symbol *string, since *JSONTime, limit *int, params map[string]interface{}) ([]Order, error)
 	// FetchMyTrades(symbol *string, since *JSONTime, limit *int, params map[string]interface{}) ([]Trade, error)
+	// params 中的 type 为账户类型, 见 AccountTypeSpot 等
 	FetchBalance(params map[string]interface{}) (*Account, error)
+	// types 为空时获取所有支持的账户
+	FetchBalances(types []string, params map[string]interface{}) (map[string]*Account, error)
+	// symbol 为空时返回所有交易对的仓位 (需交易所支持)
 	FetchPositions(symbol string, params map[string]interface{}) ([]*Position, error)
 	FetchMarkPrice(symbol string, params map[string]interface{}) (*MarkPrice, error)
-	//FetchCurrencies() (map[string]*Currency, error)
+	FetchFundingRate(symbol string, params map[string]interface{}) (*FundingRate, error)
+	FetchFundingRateHistory(symbol string, since int64, limit int64, params map[string]interface{}) ([]*FundingRateHistory, error)
+	FetchOpenInterest(symbol string, params map[string]interface{}) (*OpenInterest, error)
+	FetchOpenInterestHistory(symbol string, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OpenInterest, error)
+	// 标记价格, 指数价格和溢价指数的 K 线, 成交量为 0
+	FetchMarkOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error)
+	FetchIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error)
+	FetchPremiumIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error)
+	// 合约账户设置, marginMode 为 cross 或 isolated, hedged 为 true 表示双向持仓
+	SetLeverage(symbol string, leverage float64, params map[string]interface{}) (interface{}, error)
+	SetMarginMode(symbol string, marginMode string, params map[string]interface{}) (interface{}, error)
+	SetPositionMode(hedged bool, params map[string]interface{}) (interface{}, error)
+	AddMargin(symbol string, amount float64, params map[string]interface{}) (*MarginModification, error)
+	ReduceMargin(symbol string, amount float64, params map[string]interface{}) (*MarginModification, error)
+	FetchLeverageTiers(symbols []string, params map[string]interface{}) (map[string][]*LeverageTier, error)
+	// 杠杆账户借币还币, 默认全仓, params 中给出 symbol 时为该交易对的逐仓杠杆账户
+	Borrow(code string, amount float64, params map[string]interface{}) (*MarginLoan, error)
+	Repay(code string, amount float64, params map[string]interface{}) (*MarginLoan, error)
+	// code 为空时返回所有有负债的币种
+	FetchBorrowInterest(code string, params map[string]interface{}) ([]*BorrowInterest, error)
+	FetchMaxBorrowable(code string, params map[string]interface{}) (*MaxBorrowable, error)
+	FetchMarginRisk(params map[string]interface{}) (*MarginRisk, error)
+	// 账户之间划转, fromAccount 和 toAccount 为统一的账户类型
+	Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (*Transfer, error)
+	FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) ([]*Transfer, error)
+	// 母子账户之间划转, 子账户 id 为空表示母账户, params 中的 fromAccount 和 toAccount 为账户类型, 默认 spot
+	SubAccountTransfer(code string, amount float64, fromSubAccount, toSubAccount string, params map[string]interface{}) (*Transfer, error)
+	// 充值和提现, network 为空时使用交易所的默认链
+	FetchDepositAddress(code string, network string, params map[string]interface{}) (*DepositAddress, error)
+	CreateDepositAddress(code string, network string, params map[string]interface{}) (*DepositAddress, error)
+	Withdraw(code string, amount float64, address string, tag string, network string, params map[string]interface{}) (*Transaction, error)
+	// code 为空时返回所有币种
+	FetchDeposits(code string, since int64, limit int64, params map[string]interface{}) ([]*Transaction, error)
+	FetchWithdrawals(code string, since int64, limit int64, params map[string]interface{}) ([]*Transaction, error)
+	// 账户流水按时间升序返回, code 为空时返回所有币种, 分页见 NewLedgerIterator
+	FetchLedger(code string, since int64, limit int64, params map[string]interface{}) ([]*LedgerEntry, error)
+	// 币种信息, 包括各条链的充值提现状态和提现手续费
+	FetchCurrencies(params map[string]interface{}) (map[string]*Currency, error)
 	FetchMarkets(params map[string]interface{}) ([]*Market, error)
 	FetchAccounts(params map[string]interface{}) []interface{}
+	// 账户的实际费率, 包括 VIP 等级和平台币抵扣
+	FetchTradingFee(symbol string, params map[string]interface{}) (*TradingFee, error)
+	FetchTradingFees(params map[string]interface{}) (map[string]*TradingFee, error)
+	LoadTradingFees(reload bool) (map[string]*TradingFee, error)
+	CalculateFee(symbol, otype, side string, amount, price float64, takerOrMaker string, params map[string]interface{}) (*Fee, error)
 
 	CreateOrder(symbol, otype, side string, amount float64, price float64, params map[string]interface{}) (*Order, error)
+	// 返回的结果和 orders 的顺序一致, 单个订单失败不影响其他订单
+	CreateOrders(orders []*OrderRequest, params map[string]interface{}) ([]*OrderResult, error)
+	// 没有原生改单接口的交易所先撤单再下单, 撤单成功但下单失败时返回 *EditOrderError
+	EditOrder(id string, symbol string, otype string, side string, amount float64, price float64, params map[string]interface{}) (*Order, error)
 	LimitBuy(symbol string, price, amount float64, params map[string]interface{}) (*Order, error)
 	LimitSell(symbol string, price, amount float64, params map[string]interface{}) (*Order, error)
 	CancelOrder(id string, symbol string, params map[string]interface{}) (interface{}, error)
+	// 没有原生接口的交易所默认逐个撤单, 每个订单的结果单独返回
+	CancelOrders(ids []string, symbol string, params map[string]interface{}) ([]*OrderResult, error)
+	// symbol 为空表示撤掉所有交易对的挂单 (需交易所支持).
+	// 撤销全部挂单的接口不返回订单明细时 (bybit, bitmax, kucoin 高频) 结果为 nil, 不表示没有撤销任何订单,
+	// 需要撤销的订单列表时先 FetchOpenOrders 或撤单后再查询
+	CancelAllOrders(symbol string, params map[string]interface{}) ([]*OrderResult, error)
 
 	// Describe() []byte
 	//GetMarkets() map[string]*Market
-	SetMarkets([]*Market, map[string]interface{}) map[string]*Market
+	SetMarkets([]*Market, map[string]*Currency) map[string]*Market
 	//GetMarketsById() map[string]Market
 	//SetMarketsById(map[string]Market)
 	//GetCurrencies() map[string]Currency
@@ -615,6 +868,7 @@ type ExchangeInterface interface {
 	//SetIds([]string)
 	// GetOrders() []Order
 	LoadMarkets() map[string]*Market
+	Market(symbol string) *Market
 	// LoadMarkets(reload bool, params map[string]interface{}) (map[string]*Market, error)
 	// GetMarket(symbol string) (Market, error)
 	// CreateLimitBuyOrder(symbol string, amount float64, price *float64, params map[string]interface{}) (Order, error)
@@ -630,7 +884,6 @@ type ExchangeInterface interface {
 	BaseUrl(key string) string
 	SetVerbose(verbose bool)
 
-	FetchCurrencies(params map[string]interface{}) map[string]interface{}
 	ApiFunc(function string, params interface{}, headers map[string]interface{}, body interface{}) (response map[string]interface{})
 	ApiFuncRaw(function string, params map[string]interface{}, headers map[string]interface{}, body interface{}) (response []byte)
 	SetHttpLib(lib string) // fasthttp, net/http
@@ -677,6 +930,12 @@ type Exchange struct {
 	Accounts       []interface{}
 	AccountsById   map[string]interface{}
 
+	// LoadTradingFees 的结果, 失败时记录错误, 不再自动重试
+	tradingFeesMutex  sync.Mutex
+	tradingFeesLoaded bool
+	tradingFees       map[string]*TradingFee
+	tradingFeesError  error
+
 	Child         ExchangeInterfaceInternal
 	ApiDecodeInfo map[string]*ApiDecode
 	//ApiUrls        map[string]string
@@ -686,6 +945,14 @@ type Exchange struct {
 	Hostname       string
 	requestTimeout time.Duration
 
+	// 限频用, 记录上次发出请求的时间
+	throttleMutex            sync.Mutex
+	lastRestRequestTimestamp int64
+
+	// 公共接口缓存, 为 nil 时不缓存
+	cacheMutex sync.Mutex
+	cache      *responseCache
+
 	DescribeJson gjson.Result
 }
 
@@ -810,20 +1077,75 @@ func (self *Exchange) FetchTicker(symbol string, params map[string]interface{})
 	return nil, errors.New("FetchTicker not supported yet")
 }
 
-func (self *Exchange) FetchTickers(symbols []string, params map[string]interface{}) ([]*Ticker, error) {
-	return nil, errors.New("FetchTickers not supported yet")
-}
-
 func (self *Exchange) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error) {
 	return nil, errors.New("FetchOHLCV not supported yet")
 }
 
+// FilterTickers 批量行情接口返回全部交易对时使用, symbols 不为空时只保留其中的交易对, 顺序和接口返回一致
+func (self *Exchange) FilterTickers(tickers []*Ticker, symbols []string) []*Ticker {
+	if len(symbols) == 0 {
+		return tickers
+	}
+	wanted := map[string]bool{}
+	for _, symbol := range symbols {
+		wanted[symbol] = true
+	}
+	result := []*Ticker{}
+	for _, ticker := range tickers {
+		if wanted[ticker.Symbol] {
+			result = append(result, ticker)
+		}
+	}
+	return result
+}
+
+// FilterOHLCVs klines 需为时间升序. 去掉 since 之前的 K 线, 超过 limit 时,
+// 指定了 since 则保留最早的 limit 根, 否则保留最新的 limit 根
+func (self *Exchange) FilterOHLCVs(klines []*OHLCV, since int64, limit int64) []*OHLCV {
+	result := []*OHLCV{}
+	for _, kline := range klines {
+		if kline.Timestamp >= since {
+			result = append(result, kline)
+		}
+	}
+	if limit > 0 && int64(len(result)) > limit {
+		if since > 0 {
+			result = result[:limit]
+		} else {
+			result = result[int64(len(result))-limit:]
+		}
+	}
+	return result
+}
+
+// ParseTimeframe 返回 K 线周期的秒数, 如 "15m" 返回 900, 1M 按 30 天计算
+func (self *Exchange) ParseTimeframe(timeframe string) int64 {
+	units := map[byte]int64{
+		's': 1,
+		'm': 60,
+		'h': 60 * 60,
+		'd': 24 * 60 * 60,
+		'w': 7 * 24 * 60 * 60,
+		'M': 30 * 24 * 60 * 60,
+		'y': 365 * 24 * 60 * 60,
+	}
+	if len(timeframe) < 2 {
+		self.RaiseException("NotSupported", "invalid timeframe "+timeframe)
+	}
+	unit, ok := units[timeframe[len(timeframe)-1]]
+	amount, err := strconv.ParseInt(timeframe[:len(timeframe)-1], 10, 64)
+	if !ok || err != nil || amount <= 0 {
+		self.RaiseException("NotSupported", "invalid timeframe "+timeframe)
+	}
+	return amount * unit
+}
+
 func (self *Exchange) FetchOrderBook(symbol string, limit int64, params map[string]interface{}) (*OrderBook, error) {
 	return nil, errors.New("FetchOrderBook not supported yet")
 }
 
 func (self *Exchange) FetchStatus(params map[string]interface{}) (*ExchangeStatus, error) {
-	return &ExchangeStatus{Status: "ok", Updated: self.Milliseconds()}, nil
+	return &ExchangeStatus{Status: StatusOk, Updated: self.Milliseconds()}, nil
 }
 
 func (self *Exchange) Sign(path string, api string, method string, params map[string]interface{}, headers interface{}, body interface{}) interface{} {
@@ -881,6 +1203,12 @@ func (self *Exchange) MarketFromMap(o interface{}) *Market {
 		if m["baseMultiplier"] != nil {
 			p.BaseMultiplier = m["baseMultiplier"].(float64)
 		}
+		if m["contractSize"] != nil {
+			p.ContractSize = m["contractSize"].(float64)
+		}
+		if m["inverse"] != nil {
+			p.Inverse = m["inverse"].(bool)
+		}
 		//p.Prediction
 		if m["precision"] != nil {
 			precisionMap := m["precision"].(map[string]interface{})
@@ -920,7 +1248,7 @@ func (self *Exchange) MarketFromMap(o interface{}) *Market {
 	return p
 }
 
-func (self *Exchange) SetMarkets(markets []*Market, currencies map[string]interface{}) map[string]*Market {
+func (self *Exchange) SetMarkets(markets []*Market, currencies map[string]*Currency) map[string]*Market {
 	symbols := make([]string, len(markets))
 	Ids := make([]string, len(markets))
 	marketsBySymbol := make(map[string]*Market, len(markets))
@@ -955,7 +1283,7 @@ func (self *Exchange) SetMarkets(markets []*Market, currencies map[string]interf
 		quoteCurrency := new(Currency)
 		if market.Quote != "" {
 			quoteCurrency.Id = market.QuoteId
-			if baseCurrency.Id == "" {
+			if quoteCurrency.Id == "" {
 				quoteCurrency.Id = market.Quote
 			}
 			quoteCurrency.NumericId = market.QuoteNumericId
@@ -978,8 +1306,12 @@ func (self *Exchange) SetMarkets(markets []*Market, currencies map[string]interf
 	}
 	sortedCurrencies := make(map[string]*Currency)
 	for code, currencies := range groupedCurrencies {
+		if code == "" {
+			continue
+		}
 		for _, currency := range currencies {
 			if sortedCurrencies[code] == nil {
+				sortedCurrencies[code] = currency
 				continue
 			}
 			if sortedCurrencies[code].Id == "" {
@@ -1009,13 +1341,17 @@ func (self *Exchange) SetMarkets(markets []*Market, currencies map[string]interf
 		}
 		self.Currencies = xCurrencies
 	} else {
+		// FetchCurrencies 的结果优先
+		for code, currency := range currencies {
+			sortedCurrencies[code] = currency
+		}
 		self.Currencies = sortedCurrencies
 	}
 	currenciesById := self.CurrenciesById
 	if len(currenciesById) == 0 {
 		currenciesById = make(map[string]*Currency, len(currencies))
 	}
-	for _, currency := range sortedCurrencies {
+	for _, currency := range self.Currencies {
 		currenciesById[currency.Id] = currency
 	}
 	self.CurrenciesById = currenciesById
@@ -1028,10 +1364,15 @@ func (self *Exchange) LoadMarkets() map[string]*Market {
 		return self.Markets
 	}
 
-	var currencies map[string]interface{}
+	var currencies map[string]*Currency
 	hasfetchCurrencies := self.DescribeMap["has"].(map[string]interface{})["fetchCurrencies"]
 	if hasfetchCurrencies != nil && hasfetchCurrencies.(bool) {
-		currencies = self.Child.FetchCurrencies(map[string]interface{}{})
+		// NOTE: 部分交易所的币种接口需要 API key, 失败时只使用交易对中的币种
+		var err error
+		currencies, err = self.Child.FetchCurrencies(map[string]interface{}{})
+		if err != nil {
+			log.Printf("%s FetchCurrencies failed, using currencies from markets: %s", self.Id, err)
+		}
 	}
 
 	markets, err := self.Child.FetchMarkets(nil)
@@ -1071,6 +1412,31 @@ func (self *Exchange) Request(
 	headers map[string]interface{},
 	body interface{},
 ) (response interface{}) {
+	noCache := false
+	if _, ok := params["noCache"]; ok {
+		noCache = self.ToBool(params["noCache"])
+		params = self.Omit(self.Extend(params).(map[string]interface{}), "noCache")
+	}
+	if ttl, ok := self.cacheTTL(path, api, method); ok && !noCache {
+		key := method + " " + api + " " + path + "?" + self.Urlencode(params)
+		return self.cachedRequest(key, ttl, func() interface{} {
+			return self.request(path, api, method, params, headers, body)
+		})
+	}
+	return self.request(path, api, method, params, headers, body)
+}
+
+func (self *Exchange) request(
+	path string,
+	api string,
+	method string,
+	params map[string]interface{},
+	headers map[string]interface{},
+	body interface{},
+) (response interface{}) {
+	if self.EnableRateLimit {
+		self.Throttle()
+	}
 	signInfo := self.Child.Sign(path, api, method, params, headers, body)
 
 	url := self.Member(signInfo, "url").(string)
@@ -1099,6 +1465,20 @@ func (self *Exchange) Request(
 	return
 }
 
+// Throttle 保证两次请求的间隔不小于 RateLimit 毫秒, 开启 EnableRateLimit 时每次请求前自动调用
+func (self *Exchange) Throttle() {
+	if self.RateLimit <= 0 {
+		return
+	}
+	self.throttleMutex.Lock()
+	defer self.throttleMutex.Unlock()
+	delay := self.lastRestRequestTimestamp + int64(self.RateLimit) - self.Milliseconds()
+	if delay > 0 {
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+	}
+	self.lastRestRequestTimestamp = self.Milliseconds()
+}
+
 func (self *Exchange) PrepareRequestHeaders(req *http.Request, headers map[string]interface{}) {
 	//req.Header.Set("Accept-Encoding", "gzip, deflate")
 
@@ -1341,6 +1721,15 @@ func (self *Exchange) Parse8601(x string) int64 {
 	return t.Unix()
 }
 
+// ParseDate 解析 "2006-01-02 15:04:05" 格式的 UTC 时间, 返回毫秒, 解析失败时返回 0
+func (self *Exchange) ParseDate(x string) int64 {
+	t, err := time.Parse("2006-01-02 15:04:05", x)
+	if err != nil {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
 func (self *Exchange) Iso8601Okex(milliseconds int64) string {
 	var seconds int64
 	seconds = milliseconds / 1000
@@ -1839,9 +2228,10 @@ func (self *Exchange) ParseBalance(balances map[string]interface{}) (pAccount *A
 	account.Used = make(map[string]float64)
 	account.Total = make(map[string]float64)
 	account.Info = balances["info"]
+	account.Type = self.SafeString(balances, "type", "")
 
 	account.Account = map[string]*Balance{}
-	for currency, balance := range self.Omit(balances, []string{"info", "free", "used", "total"}) {
+	for currency, balance := range self.Omit(balances, []string{"info", "type", "free", "used", "total"}) {
 		if balance, ok := balance.(map[string]interface{}); ok {
 			free := self.SafeFloat(balance, "free", 0)
 			used := self.SafeFloat(balance, "used", 0)
@@ -1852,11 +2242,20 @@ func (self *Exchange) ParseBalance(balances map[string]interface{}) (pAccount *A
 			account.Used[currency] = used
 			account.Total[currency] = total
 			account.Account[currency] = &Balance{
-				Free:      free,
-				Used:      used,
-				Total:     total,
-				RealPnl:   realPnl,
-				UnrealPnl: unrealPnl,
+				Free:              free,
+				Used:              used,
+				Total:             total,
+				RealPnl:           realPnl,
+				UnrealPnl:         unrealPnl,
+				Borrowed:          self.SafeFloat(balance, "borrowed", 0),
+				Interest:          self.SafeFloat(balance, "interest", 0),
+				NetEquity:         self.SafeFloat(balance, "netEquity", 0),
+				WalletBalance:     self.SafeFloat(balance, "walletBalance", 0),
+				MarginBalance:     self.SafeFloat(balance, "marginBalance", 0),
+				AvailableWithdraw: self.SafeFloat(balance, "availableWithdraw", 0),
+				InitialMargin:     self.SafeFloat(balance, "initialMargin", 0),
+				MaintenanceMargin: self.SafeFloat(balance, "maintenanceMargin", 0),
+				CrossUnrealPnl:    self.SafeFloat(balance, "crossUnrealPnl", 0),
 			}
 		}
 	}
@@ -1892,6 +2291,14 @@ func (self *Exchange) Account() map[string]interface{} {
 	}
 }
 
+// CurrencyId 和 SafeCurrencyCode 相反, 把统一的币种代码转换为交易所的币种 id, 没有加载币种时原样返回
+func (self *Exchange) CurrencyId(code string) string {
+	if currency, ok := self.Currencies[code]; ok && currency != nil && currency.Id != "" {
+		return currency.Id
+	}
+	return code
+}
+
 func (self *Exchange) SafeCurrencyCode(x interface{}) string {
 	code := ""
 
@@ -2074,6 +2481,131 @@ func (self *Exchange) FetchMarkPrice(symbol string, params map[string]interface{
 	return nil, fmt.Errorf("%s FetchMarkPrice not supported yet", self.Id)
 }
 
+func (self *Exchange) FetchFundingRate(symbol string, params map[string]interface{}) (*FundingRate, error) {
+	return nil, fmt.Errorf("%s FetchFundingRate not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchFundingRateHistory(symbol string, since int64, limit int64, params map[string]interface{}) ([]*FundingRateHistory, error) {
+	return nil, fmt.Errorf("%s FetchFundingRateHistory not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchOpenInterest(symbol string, params map[string]interface{}) (*OpenInterest, error) {
+	return nil, fmt.Errorf("%s FetchOpenInterest not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchOpenInterestHistory(symbol string, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OpenInterest, error) {
+	return nil, fmt.Errorf("%s FetchOpenInterestHistory not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchMarkOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error) {
+	return nil, fmt.Errorf("%s FetchMarkOHLCV not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error) {
+	return nil, fmt.Errorf("%s FetchIndexOHLCV not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchPremiumIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) ([]*OHLCV, error) {
+	return nil, fmt.Errorf("%s FetchPremiumIndexOHLCV not supported yet", self.Id)
+}
+
+func (self *Exchange) SetLeverage(symbol string, leverage float64, params map[string]interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("%s SetLeverage not supported yet", self.Id)
+}
+
+func (self *Exchange) SetMarginMode(symbol string, marginMode string, params map[string]interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("%s SetMarginMode not supported yet", self.Id)
+}
+
+func (self *Exchange) SetPositionMode(hedged bool, params map[string]interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("%s SetPositionMode not supported yet", self.Id)
+}
+
+func (self *Exchange) AddMargin(symbol string, amount float64, params map[string]interface{}) (*MarginModification, error) {
+	return nil, fmt.Errorf("%s AddMargin not supported yet", self.Id)
+}
+
+func (self *Exchange) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (*MarginModification, error) {
+	return nil, fmt.Errorf("%s ReduceMargin not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchLeverageTiers(symbols []string, params map[string]interface{}) (map[string][]*LeverageTier, error) {
+	return nil, fmt.Errorf("%s FetchLeverageTiers not supported yet", self.Id)
+}
+
+func (self *Exchange) Borrow(code string, amount float64, params map[string]interface{}) (*MarginLoan, error) {
+	return nil, fmt.Errorf("%s Borrow not supported yet", self.Id)
+}
+
+func (self *Exchange) Repay(code string, amount float64, params map[string]interface{}) (*MarginLoan, error) {
+	return nil, fmt.Errorf("%s Repay not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchBorrowInterest(code string, params map[string]interface{}) ([]*BorrowInterest, error) {
+	return nil, fmt.Errorf("%s FetchBorrowInterest not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchMaxBorrowable(code string, params map[string]interface{}) (*MaxBorrowable, error) {
+	return nil, fmt.Errorf("%s FetchMaxBorrowable not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchMarginRisk(params map[string]interface{}) (*MarginRisk, error) {
+	return nil, fmt.Errorf("%s FetchMarginRisk not supported yet", self.Id)
+}
+
+func (self *Exchange) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (*Transfer, error) {
+	return nil, fmt.Errorf("%s Transfer not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) ([]*Transfer, error) {
+	return nil, fmt.Errorf("%s FetchTransfers not supported yet", self.Id)
+}
+
+func (self *Exchange) SubAccountTransfer(code string, amount float64, fromSubAccount, toSubAccount string, params map[string]interface{}) (*Transfer, error) {
+	return nil, fmt.Errorf("%s SubAccountTransfer not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchDepositAddress(code string, network string, params map[string]interface{}) (*DepositAddress, error) {
+	return nil, fmt.Errorf("%s FetchDepositAddress not supported yet", self.Id)
+}
+
+func (self *Exchange) CreateDepositAddress(code string, network string, params map[string]interface{}) (*DepositAddress, error) {
+	return nil, fmt.Errorf("%s CreateDepositAddress not supported yet", self.Id)
+}
+
+func (self *Exchange) Withdraw(code string, amount float64, address string, tag string, network string, params map[string]interface{}) (*Transaction, error) {
+	return nil, fmt.Errorf("%s Withdraw not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchDeposits(code string, since int64, limit int64, params map[string]interface{}) ([]*Transaction, error) {
+	return nil, fmt.Errorf("%s FetchDeposits not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchWithdrawals(code string, since int64, limit int64, params map[string]interface{}) ([]*Transaction, error) {
+	return nil, fmt.Errorf("%s FetchWithdrawals not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchTradingFee(symbol string, params map[string]interface{}) (*TradingFee, error) {
+	return nil, fmt.Errorf("%s FetchTradingFee not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchTradingFees(params map[string]interface{}) (map[string]*TradingFee, error) {
+	return nil, fmt.Errorf("%s FetchTradingFees not supported yet", self.Id)
+}
+
+func (self *Exchange) FetchLedger(code string, since int64, limit int64, params map[string]interface{}) ([]*LedgerEntry, error) {
+	return nil, fmt.Errorf("%s FetchLedger not supported yet", self.Id)
+}
+
+// 检查 marginMode 参数, 返回小写的 cross 或 isolated
+func (self *Exchange) CheckMarginMode(marginMode string) string {
+	marginMode = strings.ToLower(marginMode)
+	if marginMode != "cross" && marginMode != "isolated" {
+		self.RaiseException("BadRequest", self.Id+" marginMode must be cross or isolated")
+	}
+	return marginMode
+}
+
 func (self *Exchange) FetchPositions(symbol string, params map[string]interface{}) ([]*Position, error) {
 	return nil, fmt.Errorf("%s FetchPositions not supported yet", self.Id)
 }
@@ -2086,6 +2618,301 @@ func (self *Exchange) CreateOrder(symbol string, otype string, side string, amou
 	return nil, fmt.Errorf("%s CreateOrder not supported yet", self.Id)
 }
 
+// 默认实现: 并发调用 CreateOrder, 并发数由 ExchangeConfig.Concurrency 控制
+func (self *Exchange) CreateOrders(orders []*OrderRequest, params map[string]interface{}) ([]*OrderResult, error) {
+	result := make([]*OrderResult, len(orders))
+	self.forEach(len(orders), func(i int) {
+		result[i] = &OrderResult{}
+		defer self.recoverToError(&result[i].Error)
+		result[i] = self.createOrderResult(orders[i], params)
+	})
+	return result, nil
+}
+
+func (self *Exchange) createOrderResult(order *OrderRequest, params map[string]interface{}) *OrderResult {
+	orderParams := self.Extend(params, order.Params).(map[string]interface{})
+	response, err := self.Child.CreateOrder(order.Symbol, order.Type, order.Side, order.Amount, order.Price, orderParams)
+	result := &OrderResult{
+		Order: response,
+		Error: err,
+	}
+	if response != nil {
+		result.Id = response.Id
+		result.ClientOrderId = response.ClientOrderId
+	}
+	return result
+}
+
+// CreateOrdersInBatches 原生批量下单的公共流程
+// build 构造单个订单的请求, 出错时只影响该订单; send 发送一批请求, 返回的结果必须和请求一一对应.
+// 最终结果和 orders 的顺序一致
+func (self *Exchange) CreateOrdersInBatches(
+	orders []*OrderRequest,
+	batchSize int,
+	build func(order *OrderRequest) interface{},
+	send func(requests []interface{}) []*OrderResult,
+) []*OrderResult {
+	result := make([]*OrderResult, len(orders))
+	indexes := []int{}
+	requests := []interface{}{}
+	for i, order := range orders {
+		var request interface{}
+		if err := self.catchPanic(func() { request = build(order) }); err != nil {
+			result[i] = &OrderResult{Error: err}
+			continue
+		}
+		indexes = append(indexes, i)
+		requests = append(requests, request)
+	}
+	if batchSize <= 0 {
+		batchSize = len(requests)
+	}
+	for start := 0; start < len(requests); start += batchSize {
+		end := start + batchSize
+		if end > len(requests) {
+			end = len(requests)
+		}
+		var batch []*OrderResult
+		err := self.catchPanic(func() { batch = send(requests[start:end]) })
+		for j, i := range indexes[start:end] {
+			if err != nil {
+				result[i] = &OrderResult{Error: err}
+			} else if j < len(batch) && batch[j] != nil {
+				result[i] = batch[j]
+			} else {
+				result[i] = &OrderResult{Error: TypedError("BadResponse", self.Id+" batch response does not match the requests")}
+			}
+		}
+	}
+	return result
+}
+
+func (self *Exchange) catchPanic(fn func()) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// ParseTriggerOrder 从 params 中提取统一的条件单参数 (参考 TriggerOrder), 返回的 params 为去掉这些参数后的副本.
+// 没有条件单参数时返回 nil
+func (self *Exchange) ParseTriggerOrder(params map[string]interface{}) (*TriggerOrder, map[string]interface{}) {
+	query := self.Extend(params).(map[string]interface{})
+	trigger := &TriggerOrder{
+		StopPrice:       self.SafeFloat(query, "stopPrice", self.SafeFloat(query, "triggerPrice", self.SafeFloat(query, "stopLossPrice"))),
+		TakeProfitPrice: self.SafeFloat(query, "takeProfitPrice"),
+		TrailingPercent: self.SafeFloat(query, "trailingPercent"),
+		StopLoss:        self.parseAttachedOrder(query["stopLoss"]),
+		TakeProfit:      self.parseAttachedOrder(query["takeProfit"]),
+	}
+	for _, key := range []string{"stopPrice", "triggerPrice", "stopLossPrice", "takeProfitPrice", "trailingPercent", "stopLoss", "takeProfit"} {
+		delete(query, key)
+	}
+	if trigger.TriggerPrice() <= 0 && trigger.TrailingPercent <= 0 && trigger.StopLoss == nil && trigger.TakeProfit == nil {
+		return nil, query
+	}
+	return trigger, query
+}
+
+func (self *Exchange) parseAttachedOrder(v interface{}) *AttachedOrder {
+	switch v.(type) {
+	case nil:
+		return nil
+	case *AttachedOrder:
+		return v.(*AttachedOrder)
+	case map[string]interface{}:
+		return &AttachedOrder{
+			TriggerPrice: self.SafeFloat(v, "triggerPrice"),
+			Price:        self.SafeFloat(v, "price"),
+		}
+	}
+	triggerPrice := self.SafeFloat(map[string]interface{}{"v": v}, "v")
+	if triggerPrice <= 0 {
+		return nil
+	}
+	return &AttachedOrder{TriggerPrice: triggerPrice}
+}
+
+// IsStopOrderRequest 查询或撤销条件单时使用, params 中 stop 或 trigger 为 true 表示操作条件单.
+// 返回的 params 为去掉这两个参数后的副本
+func (self *Exchange) IsStopOrderRequest(params map[string]interface{}) (bool, map[string]interface{}) {
+	query := self.Extend(params).(map[string]interface{})
+	stop := self.ToBool(query["stop"]) || self.ToBool(query["trigger"])
+	delete(query, "stop")
+	delete(query, "trigger")
+	return stop, query
+}
+
+// CreateAttachedOrders 开仓订单创建后再下附带的止损止盈单, 用于没有原生接口的交易所.
+// 止损止盈单为反方向的只减仓条件单, 开仓订单为未成交的限价单时也立即下单, 不等待成交.
+// 其中一个下单失败时撤销已下的附带订单, 返回 *AttachedOrderError
+func (self *Exchange) CreateAttachedOrders(order *Order, trigger *TriggerOrder, symbol string, side string, amount float64, params map[string]interface{}) (*Order, error) {
+	if trigger == nil {
+		return order, nil
+	}
+	closeSide := "sell"
+	if side == "sell" {
+		closeSide = "buy"
+	}
+	attached := []struct {
+		key    string
+		detail *AttachedOrder
+	}{{"stopLossPrice", trigger.StopLoss}, {"takeProfitPrice", trigger.TakeProfit}}
+	placed := []*Order{}
+	for _, one := range attached {
+		if one.detail == nil {
+			continue
+		}
+		attachedType := "market"
+		if one.detail.Price > 0 {
+			attachedType = "limit"
+		}
+		attachedParams := self.Extend(params, map[string]interface{}{
+			one.key:      one.detail.TriggerPrice,
+			"reduceOnly": true,
+		}).(map[string]interface{})
+		attachedOrder, err := self.Child.CreateOrder(symbol, attachedType, closeSide, amount, one.detail.Price, attachedParams)
+		if err != nil {
+			// NOTE: 不能只留下止损或止盈中的一个
+			remaining := []*Order{}
+			cancelParams := self.Extend(params, map[string]interface{}{"stop": true}).(map[string]interface{})
+			for _, o := range placed {
+				if _, e := self.Child.CancelOrder(o.Id, symbol, cancelParams); e != nil {
+					remaining = append(remaining, o)
+				}
+			}
+			return nil, &AttachedOrderError{
+				Order:     order,
+				Remaining: remaining,
+				Err:       err,
+			}
+		}
+		placed = append(placed, attachedOrder)
+	}
+	return order, nil
+}
+
+// 默认实现: 先撤单再下单, 新订单的 clientOrderId 由原订单派生 (参考 ReplaceClientOrderId).
+// params["cancelParams"] 用于撤单, 其余的参数用于下单
+func (self *Exchange) EditOrder(id string, symbol string, otype string, side string, amount float64, price float64, params map[string]interface{}) (*Order, error) {
+	createParams := self.Extend(params).(map[string]interface{})
+	cancelParams := self.Extend(self.SafeValue(createParams, "cancelParams", nil)).(map[string]interface{})
+	delete(createParams, "cancelParams")
+	response, err := self.Child.CancelOrder(id, symbol, cancelParams)
+	if err != nil {
+		return nil, err
+	}
+	canceled := self.CanceledOrder(id, symbol, response)
+	if canceled.ClientOrderId == "" {
+		// NOTE: CancelOrder 返回原始响应时没有原订单的 clientOrderId, 按撤单的参数或查询原订单补上, 查询失败时不派生
+		canceled.ClientOrderId = self.SafeString(cancelParams, "clientOrderId")
+		if canceled.ClientOrderId == "" {
+			if order, err := self.Child.FetchOrder(id, symbol, nil); err == nil && order != nil {
+				order.Info = response
+				canceled = order
+			}
+		}
+	}
+	clientOrderId := self.SafeString(createParams, "clientOrderId")
+	if clientOrderId == "" {
+		clientOrderId = self.ReplaceClientOrderId(canceled.ClientOrderId)
+		if clientOrderId != "" {
+			createParams["clientOrderId"] = clientOrderId
+		}
+	}
+	order, err := self.Child.CreateOrder(symbol, otype, side, amount, price, createParams)
+	if err != nil {
+		return nil, &EditOrderError{
+			Id:            id,
+			ClientOrderId: clientOrderId,
+			Canceled:      canceled,
+			Err:           err,
+		}
+	}
+	return order, nil
+}
+
+// GenerateClientOrderId 生成新的 clientOrderId: options.clientOrderIdPrefix 加上去掉 "-" 的 uuid,
+// 长度不超过 options.clientOrderIdMaxLength (默认 32)
+func (self *Exchange) GenerateClientOrderId() string {
+	prefix := self.SafeString(self.Options, "clientOrderIdPrefix", "")
+	maxLength := int(self.SafeInteger(self.Options, "clientOrderIdMaxLength", 32))
+	clientOrderId := prefix + strings.Replace(self.Uuid(), "-", "", -1)
+	if len(clientOrderId) > maxLength {
+		clientOrderId = clientOrderId[:maxLength]
+	}
+	return clientOrderId
+}
+
+// ParseClientOrderId 下单时使用, 依次从 params 的 clientOrderId 和 keys 中取 clientOrderId,
+// 没有时调用 GenerateClientOrderId 生成. 返回的 params 为去掉这些参数后的副本
+func (self *Exchange) ParseClientOrderId(params map[string]interface{}, keys ...string) (string, map[string]interface{}) {
+	query := self.Extend(params).(map[string]interface{})
+	clientOrderId := ""
+	for _, key := range append([]string{"clientOrderId"}, keys...) {
+		if clientOrderId == "" {
+			clientOrderId = self.SafeString(query, key, "")
+		}
+		delete(query, key)
+	}
+	if clientOrderId == "" {
+		clientOrderId = self.GenerateClientOrderId()
+	}
+	return clientOrderId, query
+}
+
+// PrefixedClientOrderId 同 ParseClientOrderId, 并保证 clientOrderId 以 options.clientOrderIdPrefix 开头,
+// 长度不超过 options.clientOrderIdMaxLength (默认 32). 用于要求固定前缀的交易所, 如 gateio 的 "t-"
+func (self *Exchange) PrefixedClientOrderId(params map[string]interface{}, keys ...string) (string, map[string]interface{}) {
+	clientOrderId, query := self.ParseClientOrderId(params, keys...)
+	prefix := self.SafeString(self.Options, "clientOrderIdPrefix", "")
+	if !strings.HasPrefix(clientOrderId, prefix) {
+		clientOrderId = prefix + clientOrderId
+	}
+	maxLength := int(self.SafeInteger(self.Options, "clientOrderIdMaxLength", 32))
+	if len(clientOrderId) > maxLength {
+		clientOrderId = clientOrderId[:maxLength]
+	}
+	return clientOrderId, query
+}
+
+// OrderIdParam 查询, 撤单和改单时使用, params 中有 clientOrderId 时代替 id. 返回的 params 为去掉 clientOrderId 后的副本
+func (self *Exchange) OrderIdParam(id string, params map[string]interface{}) (string, map[string]interface{}) {
+	query := self.Extend(params).(map[string]interface{})
+	if clientOrderId := self.SafeString(query, "clientOrderId", ""); clientOrderId != "" {
+		id = clientOrderId
+	}
+	delete(query, "clientOrderId")
+	return id, query
+}
+
+// ReplaceClientOrderId 改单后新订单的 clientOrderId, 在原 id 后追加 "<分隔符><n>" 以保留关联关系.
+// 分隔符由 options.clientOrderIdReplaceSeparator 指定, 默认为 "-r", 只允许字母和数字的交易所可以设置为 "r".
+// 长度不超过 options.clientOrderIdMaxLength (默认 32), 超出时截断原 id
+func (self *Exchange) ReplaceClientOrderId(clientOrderId string) string {
+	if clientOrderId == "" {
+		return ""
+	}
+	separator := self.SafeString(self.Options, "clientOrderIdReplaceSeparator", "-r")
+	prefix := clientOrderId
+	n := 1
+	if i := strings.LastIndex(clientOrderId, separator); i >= 0 {
+		if m, err := strconv.Atoi(clientOrderId[i+len(separator):]); err == nil && m > 0 {
+			prefix = clientOrderId[:i]
+			n = m + 1
+		}
+	}
+	suffix := fmt.Sprintf("%s%d", separator, n)
+	maxLength := int(self.SafeInteger(self.Options, "clientOrderIdMaxLength", 32))
+	if len(prefix)+len(suffix) > maxLength && maxLength > len(suffix) {
+		prefix = prefix[:maxLength-len(suffix)]
+	}
+	return prefix + suffix
+}
+
 func (self *Exchange) LimitBuy(symbol string, price, amount float64, params map[string]interface{}) (*Order, error) {
 	return self.Child.CreateOrder(symbol, "limit", "buy", amount, price, params)
 }
@@ -2094,14 +2921,81 @@ func (self *Exchange) LimitSell(symbol string, price, amount float64, params map
 	return self.Child.CreateOrder(symbol, "limit", "sell", amount, price, params)
 }
 
-func (self *Exchange) FetchCurrencies(params map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{}
+func (self *Exchange) FetchCurrencies(params map[string]interface{}) (map[string]*Currency, error) {
+	return nil, fmt.Errorf("%s FetchCurrencies not supported yet", self.Id)
 }
 
 func (self *Exchange) CancelOrder(id string, symbol string, params map[string]interface{}) (interface{}, error) {
 	return nil, fmt.Errorf("%s CancelOrder not supported yet", self.Id)
 }
 
+// 默认实现: 逐个调用 CancelOrder
+func (self *Exchange) CancelOrders(ids []string, symbol string, params map[string]interface{}) ([]*OrderResult, error) {
+	result := make([]*OrderResult, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, self.cancelOrderResult(id, "", symbol, params))
+	}
+	return result, nil
+}
+
+// 默认实现: 先 FetchOpenOrders 再逐个调用 CancelOrder
+func (self *Exchange) CancelAllOrders(symbol string, params map[string]interface{}) ([]*OrderResult, error) {
+	orders, err := self.Child.FetchOpenOrders(symbol, 0, 0, self.Extend(params).(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*OrderResult, 0, len(orders))
+	for _, order := range orders {
+		orderSymbol := order.Symbol
+		if orderSymbol == "" {
+			orderSymbol = symbol
+		}
+		result = append(result, self.cancelOrderResult(order.Id, order.ClientOrderId, orderSymbol, params))
+	}
+	return result, nil
+}
+
+// CanceledOrderResults 撤销全部挂单的接口不返回订单明细时, 把撤单前查询到的挂单作为撤销结果
+func (self *Exchange) CanceledOrderResults(orders []*Order) []*OrderResult {
+	result := make([]*OrderResult, 0, len(orders))
+	for _, order := range orders {
+		order.Status = "canceled"
+		result = append(result, &OrderResult{
+			Id:            order.Id,
+			ClientOrderId: order.ClientOrderId,
+			Order:         order,
+		})
+	}
+	return result
+}
+
+// NOTE: 各交易所的 CancelOrder 可能会修改 params, 所以每次都要复制一份
+func (self *Exchange) cancelOrderResult(id string, clientOrderId string, symbol string, params map[string]interface{}) *OrderResult {
+	response, err := self.Child.CancelOrder(id, symbol, self.Extend(params).(map[string]interface{}))
+	result := &OrderResult{
+		Id:            id,
+		ClientOrderId: clientOrderId,
+		Error:         err,
+	}
+	if err == nil {
+		result.Order = self.CanceledOrder(id, symbol, response)
+	}
+	return result
+}
+
+// 把 CancelOrder 的返回值统一转为 *Order, 各交易所的返回值类型不尽相同
+func (self *Exchange) CanceledOrder(id string, symbol string, response interface{}) *Order {
+	if order, ok := response.(*Order); ok && order != nil {
+		return order
+	}
+	return &Order{
+		Id:     id,
+		Symbol: symbol,
+		Status: "canceled",
+		Info:   response,
+	}
+}
+
 func (self *Exchange) FetchTrades(symbol string, since int64, limit int64, params map[string]interface{}) ([]*Trade, error) {
 	return nil, fmt.Errorf("%s FetchTrades not supported yet", self.Id)
 }
@@ -2224,6 +3118,17 @@ func (self *Exchange) ThrowExactlyMatchedException(exact interface{}, key interf
 	}
 }
 
+// 批量接口中单个订单失败时使用, 不抛出异常而是返回对应类型的错误
+func (self *Exchange) ExactlyMatchedError(exact interface{}, key string, message string) error {
+	errCls := "ExchangeError"
+	if strMap, ok := exact.(map[string]interface{}); ok {
+		if val, ok := strMap[key].(string); ok {
+			errCls = val
+		}
+	}
+	return TypedError(errCls, message)
+}
+
 func (self *Exchange) FindBroadlyMatchedKey(broad interface{}, key interface{}) string {
 	for k, _ := range broad.(map[string]interface{}) {
 		if strings.Contains(key.(string), k) {
@@ -2539,6 +3444,24 @@ func (self *Exchange) ParseNumber(v interface{}) float64 {
 	return def
 }
 
+// SymbolFromQuoteSuffix 不加载交易对时按 quote 后缀把 BTCUSDT 转换为 BTC/USDT, 没有匹配的后缀时原样返回.
+// 后缀由 Options["quoteSuffixes"] 指定, 默认为 USDT, BUSD 和 USDC
+func (self *Exchange) SymbolFromQuoteSuffix(marketId string) string {
+	quotes := []string{"USDT", "BUSD", "USDC"}
+	if list, ok := self.SafeValue(self.Options, "quoteSuffixes", nil).([]interface{}); ok {
+		quotes = []string{}
+		for _, quote := range list {
+			quotes = append(quotes, fmt.Sprintf("%v", quote))
+		}
+	}
+	for _, quote := range quotes {
+		if strings.HasSuffix(marketId, quote) && len(marketId) > len(quote) {
+			return strings.TrimSuffix(marketId, quote) + "/" + quote
+		}
+	}
+	return marketId
+}
+
 func (self *Exchange) SafeSymbol(marketId string, market interface{}, delimiter string) (symbol string) {
 	if marketId != "" {
 		if self.ToBool(self.InMap(marketId, self.MarketsById)) {
@@ -0,0 +1,124 @@
+package base
+
+// TradingFee 账户在交易对上的实际费率, 已按 VIP 等级计算, 负数为返佣
+type TradingFee struct {
+	Symbol string
+	Maker  float64
+	Taker  float64
+	Tier   string // VIP 等级, 交易所不返回时为空
+	// 开启平台币(如 BNB, KCS)抵扣手续费时为抵扣的币种, 实际费率为 Maker/Taker 乘以 Discount
+	DiscountCurrency string
+	Discount         float64
+	Info             interface{}
+}
+
+// EffectiveRate 实际生效的费率, 已计入平台币抵扣
+func (self *TradingFee) EffectiveRate(takerOrMaker string) float64 {
+	rate := self.Taker
+	if takerOrMaker == "maker" {
+		rate = self.Maker
+	}
+	if self.DiscountCurrency != "" && self.Discount > 0 {
+		rate *= self.Discount
+	}
+	return rate
+}
+
+// Fee 预估的手续费
+type Fee struct {
+	Type     string // taker 或 maker
+	Currency string
+	Rate     float64
+	Cost     float64 // 平台币抵扣时实际以平台币支付, Cost 为 Currency 计价的等值数量
+}
+
+// LoadTradingFees 获取并缓存所有交易对的费率, 供 CalculateFee 使用. 同时更新 Market 的 Taker 和 Maker.
+// 加载失败时缓存错误, reload 为 true 时重新获取
+func (self *Exchange) LoadTradingFees(reload bool) (map[string]*TradingFee, error) {
+	self.tradingFeesMutex.Lock()
+	defer self.tradingFeesMutex.Unlock()
+	if self.tradingFeesLoaded && !reload {
+		return self.tradingFees, self.tradingFeesError
+	}
+	self.tradingFeesLoaded = true
+	fees, err := self.Child.FetchTradingFees(nil)
+	self.tradingFeesError = err
+	if err != nil {
+		return nil, err
+	}
+	for symbol, fee := range fees {
+		if market, ok := self.Markets[symbol]; ok {
+			market.Taker = fee.Taker
+			market.Maker = fee.Maker
+		}
+	}
+	self.tradingFees = fees
+	return fees, nil
+}
+
+// CalculateFee 预估手续费. 费率依次取 LoadTradingFees 的结果, Market 的 Taker/Maker 和 describe 中 fees 的默认费率,
+// 配置了 apiKey 时第一次调用会加载账户的实际费率, 加载失败时不再重试. 现货买入的手续费以 base 计价, 反向合约以 base 计价, 其他以 quote 计价
+func (self *Exchange) CalculateFee(symbol, otype, side string, amount, price float64, takerOrMaker string, params map[string]interface{}) (result *Fee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	// NOTE: 不加载交易对的交易所 Market 只根据 symbol 生成
+	self.Child.LoadMarkets()
+	market, ok := self.Markets[symbol]
+	if !ok {
+		market = self.Child.Market(symbol)
+	}
+	return self.CalculateMarketFee(market, otype, side, amount, price, takerOrMaker), nil
+}
+
+// CalculateMarketFee 按 market 预估手续费, 合约的 amount 为张数, 按 ContractSize 换算.
+// 用于 Market 中没有合约信息, 需要另外获取的交易所
+func (self *Exchange) CalculateMarketFee(market *Market, otype, side string, amount, price float64, takerOrMaker string) *Fee {
+	if takerOrMaker != "maker" {
+		takerOrMaker = "taker"
+	}
+	if otype == "market" {
+		takerOrMaker = "taker"
+	}
+	// NOTE: 没有 apiKey 或交易所不支持时加载失败, 使用默认费率. 失败只尝试一次
+	self.tradingFeesMutex.Lock()
+	tradingFees, loaded := self.tradingFees, self.tradingFeesLoaded
+	self.tradingFeesMutex.Unlock()
+	if !loaded && self.ApiKey != "" {
+		tradingFees, _ = self.Child.LoadTradingFees(false)
+	}
+	fees := self.SafeValue(self.DescribeMap, "fees", nil)
+	rate := self.SafeFloat(self.SafeValue(fees, "trading", nil), takerOrMaker, 0)
+	if market.Taker != 0 || market.Maker != 0 {
+		rate = market.Taker
+		if takerOrMaker == "maker" {
+			rate = market.Maker
+		}
+	}
+	if fee, ok := tradingFees[market.Symbol]; ok {
+		rate = fee.EffectiveRate(takerOrMaker)
+	}
+	contractSize := market.ContractSize
+	if contractSize <= 0 {
+		contractSize = 1
+	}
+	result := &Fee{
+		Type:     takerOrMaker,
+		Currency: market.Quote,
+		Rate:     rate,
+		Cost:     amount * contractSize * price * rate,
+	}
+	if market.Inverse {
+		result.Currency = market.Base
+		result.Cost = 0
+		if price > 0 {
+			result.Cost = amount * contractSize / price * rate
+		}
+	} else if market.Spot && side == "buy" {
+		result.Currency = market.Base
+		result.Cost = amount * rate
+	}
+	return result
+}
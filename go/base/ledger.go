@@ -0,0 +1,62 @@
+package base
+
+import (
+	"sort"
+)
+
+// 统一的流水类型, 交易所的类型由 Options["ledgerTypes"] 转换, 未知类型原样返回
+const (
+	LedgerTypeTrade       = "trade"
+	LedgerTypeFee         = "fee"
+	LedgerTypeFunding     = "funding"
+	LedgerTypeTransfer    = "transfer"
+	LedgerTypeRebate      = "rebate"
+	LedgerTypeDeposit     = "deposit"
+	LedgerTypeWithdrawal  = "withdrawal"
+	LedgerTypeRealizedPnl = "pnl"
+	LedgerTypeInterest    = "interest"
+	LedgerTypeLiquidation = "liquidation"
+)
+
+// LedgerEntry 账户流水, 每条记录对应一次余额变动
+type LedgerEntry struct {
+	Id          string
+	Direction   string // in, out
+	Account     string // 统一的账户类型, 交易所不返回时为空
+	ReferenceId string // 关联的订单, 成交或划转 id
+	Type        string
+	Currency    string
+	Symbol      string  // 资金费等和交易对相关的流水, 否则为空
+	Amount      float64 // 总是正数, 方向见 Direction
+	Before      float64 // 交易所不返回余额时 Before 和 After 都为 0
+	After       float64
+	Fee         float64
+	Timestamp   int64
+	Info        interface{}
+}
+
+// ParseLedgerType 按 Options["ledgerTypes"] 转换交易所的流水类型
+func (self *Exchange) ParseLedgerType(typ string) string {
+	ledgerTypes := self.SafeValue(self.Options, "ledgerTypes", map[string]interface{}{})
+	return self.SafeString(ledgerTypes, typ, typ)
+}
+
+// SortLedger 按时间升序排列
+func (self *Exchange) SortLedger(entries []*LedgerEntry) []*LedgerEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp < entries[j].Timestamp
+	})
+	return entries
+}
+
+// LimitLedger 排序后只保留 limit 条, 有 since 时保留最早的, 否则保留最新的. 用于交易所按时间倒序分页的情况
+func (self *Exchange) LimitLedger(entries []*LedgerEntry, since int64, limit int64) []*LedgerEntry {
+	entries = self.SortLedger(entries)
+	if limit <= 0 || int64(len(entries)) <= limit {
+		return entries
+	}
+	if since > 0 {
+		return entries[:limit]
+	}
+	return entries[int64(len(entries))-limit:]
+}
@@ -0,0 +1,59 @@
+package base
+
+// 杠杆账户借币还币. 默认操作全仓杠杆账户, params 中给出 symbol 时操作该交易对的逐仓杠杆账户
+
+// MarginLoan 借币或还币的结果
+type MarginLoan struct {
+	Id        string // 交易所的借币或还币记录 id, 不返回时为空
+	Currency  string
+	Amount    float64
+	Symbol    string // 逐仓杠杆的交易对, 全仓为空
+	Timestamp int64
+	Info      interface{}
+}
+
+// BorrowInterest 未还的借币本金和利息
+type BorrowInterest struct {
+	Currency     string
+	Symbol       string  // 逐仓杠杆的交易对, 全仓为空
+	Borrowed     float64 // 未还本金
+	Interest     float64 // 未还利息
+	InterestRate float64 // 日利率, 交易所不返回时为 0
+	Timestamp    int64
+	Info         interface{}
+}
+
+// MaxBorrowable 当前最多还能借的数量
+type MaxBorrowable struct {
+	Currency string
+	Symbol   string // 逐仓杠杆的交易对, 全仓为空
+	Amount   float64
+	Info     interface{}
+}
+
+// MarginRisk 杠杆账户的风险, MarginLevel 为总资产 / 总负债(含利息), 越低风险越高
+type MarginRisk struct {
+	Symbol           string  // 逐仓杠杆的交易对, 全仓为空
+	MarginLevel      float64 // 没有负债时为 0
+	LiquidationLevel float64 // MarginLevel 低于该值时强平, 交易所不提供时为 0
+	TotalAsset       float64 // 交易所不返回时为 0
+	TotalDebt        float64
+	Currency         string // TotalAsset 和 TotalDebt 的计价货币
+	Info             interface{}
+}
+
+// MarginSymbol 取出 params 中逐仓杠杆的交易对, 为空表示全仓. 返回的 params 为去掉 symbol 后的副本
+func (self *Exchange) MarginSymbol(params map[string]interface{}) (string, map[string]interface{}) {
+	query := self.Extend(params).(map[string]interface{})
+	symbol := self.SafeString(query, "symbol", "")
+	delete(query, "symbol")
+	return symbol, query
+}
+
+// DebtRatioToMarginLevel 债务率(负债 / 资产)转换为 MarginLevel, 用于以债务率表示风险的交易所, 如 kucoin
+func (self *Exchange) DebtRatioToMarginLevel(debtRatio float64) float64 {
+	if debtRatio <= 0 {
+		return 0
+	}
+	return 1 / debtRatio
+}
@@ -0,0 +1,182 @@
+package base
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// RoundTimeframe 返回 timestamp 所在 K 线的开始时间(毫秒, UTC).
+// 周线从周一开始, 月线和年线按自然月和自然年对齐, 其余按周期整除对齐
+func (self *Exchange) RoundTimeframe(timeframe string, timestamp int64) int64 {
+	duration := self.ParseTimeframe(timeframe) * 1000
+	unit := timeframe[len(timeframe)-1]
+	t := time.Unix(0, timestamp*int64(time.Millisecond)).UTC()
+	switch unit {
+	case 'w':
+		// 1970-01-01 是周四, 偏移到周一再整除
+		offset := int64(4 * 24 * 3600 * 1000)
+		return (timestamp+offset)/duration*duration - offset
+	case 'M':
+		amount, _ := strconv.Atoi(timeframe[:len(timeframe)-1])
+		month := (int(t.Month())-1)/amount*amount + 1
+		return time.Date(t.Year(), time.Month(month), 1, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+	case 'y':
+		amount, _ := strconv.Atoi(timeframe[:len(timeframe)-1])
+		year := t.Year() / amount * amount
+		return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+	}
+	return timestamp - timestamp%duration
+}
+
+// 下一根 K 线的开始时间, start 需已对齐
+func (self *Exchange) nextTimeframe(timeframe string, start int64) int64 {
+	unit := timeframe[len(timeframe)-1]
+	if unit != 'M' && unit != 'y' {
+		return start + self.ParseTimeframe(timeframe)*1000
+	}
+	amount, _ := strconv.Atoi(timeframe[:len(timeframe)-1])
+	t := time.Unix(0, start*int64(time.Millisecond)).UTC()
+	if unit == 'M' {
+		t = t.AddDate(0, amount, 0)
+	} else {
+		t = t.AddDate(amount, 0, 0)
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// BuildOHLCV 用成交记录生成任意周期的 K 线, 如 "10s", "2m". 没有成交的周期不生成 K 线
+func (self *Exchange) BuildOHLCV(trades []*Trade, timeframe string, since int64, limit int64) []*OHLCV {
+	sorted := make([]*Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+	result := []*OHLCV{}
+	var kline *OHLCV
+	for _, trade := range sorted {
+		if trade.Timestamp < since {
+			continue
+		}
+		start := self.RoundTimeframe(timeframe, trade.Timestamp)
+		if kline == nil || kline.Timestamp != start {
+			if limit > 0 && int64(len(result)) >= limit {
+				break
+			}
+			kline = &OHLCV{
+				Timestamp: start,
+				Open:      trade.Price,
+				High:      trade.Price,
+				Low:       trade.Price,
+				Close:     trade.Price,
+			}
+			result = append(result, kline)
+		}
+		if trade.Price > kline.High {
+			kline.High = trade.Price
+		}
+		if trade.Price < kline.Low {
+			kline.Low = trade.Price
+		}
+		kline.Close = trade.Price
+		kline.Volume += trade.Amount
+	}
+	return result
+}
+
+// ResampleOHLCV 把小周期的 K 线合并为大周期, 如 1m 合并为 2m, 1h 合并为 1d.
+// 开盘价取周期内第一根, 收盘价取最后一根. 首尾不完整的周期也会返回, 调用者可按需丢弃
+func (self *Exchange) ResampleOHLCV(klines []*OHLCV, timeframe string) []*OHLCV {
+	sorted := make([]*OHLCV, len(klines))
+	copy(sorted, klines)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+	result := []*OHLCV{}
+	var current *OHLCV
+	for _, kline := range sorted {
+		start := self.RoundTimeframe(timeframe, kline.Timestamp)
+		if current == nil || current.Timestamp != start {
+			current = &OHLCV{
+				Timestamp: start,
+				Open:      kline.Open,
+				High:      kline.High,
+				Low:       kline.Low,
+			}
+			result = append(result, current)
+		}
+		if kline.High > current.High {
+			current.High = kline.High
+		}
+		if kline.Low < current.Low {
+			current.Low = kline.Low
+		}
+		current.Close = kline.Close
+		current.Volume += kline.Volume
+	}
+	return result
+}
+
+// FillOHLCVGaps 用前一根 K 线的收盘价补齐缺失的 K 线, 成交量为 0. klines 需为时间升序.
+// to 大于 0 时补齐到 to 之前(不含) 的最后一根
+func (self *Exchange) FillOHLCVGaps(klines []*OHLCV, timeframe string, to int64) []*OHLCV {
+	result := []*OHLCV{}
+	for _, kline := range klines {
+		if len(result) > 0 {
+			prev := result[len(result)-1]
+			for next := self.nextTimeframe(timeframe, prev.Timestamp); next < kline.Timestamp; next = self.nextTimeframe(timeframe, next) {
+				prev = self.flatOHLCV(next, prev.Close)
+				result = append(result, prev)
+			}
+		}
+		result = append(result, kline)
+	}
+	if to > 0 && len(result) > 0 {
+		prev := result[len(result)-1]
+		for next := self.nextTimeframe(timeframe, prev.Timestamp); next < to; next = self.nextTimeframe(timeframe, next) {
+			prev = self.flatOHLCV(next, prev.Close)
+			result = append(result, prev)
+		}
+	}
+	return result
+}
+
+func (self *Exchange) flatOHLCV(timestamp int64, price float64) *OHLCV {
+	return &OHLCV{
+		Timestamp: timestamp,
+		Open:      price,
+		High:      price,
+		Low:       price,
+		Close:     price,
+	}
+}
+
+// FetchOHLCVFromTrades 交易所不支持的周期(如 10s)或没有 K 线接口时, 用成交记录模拟 K 线.
+// 成交记录通过 TradesRange 分页获取, 只适用于能按时间查询历史成交的交易所
+func (self *Exchange) FetchOHLCVFromTrades(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if since <= 0 {
+		duration := self.ParseTimeframe(timeframe) * 1000
+		count := limit
+		if count <= 0 {
+			count = defaultFetchOHLCVLimit
+		}
+		since = self.RoundTimeframe(timeframe, self.Milliseconds()) - (count-1)*duration
+	}
+	to := int64(0)
+	if limit > 0 {
+		to = self.RoundTimeframe(timeframe, since)
+		for i := int64(0); i < limit; i++ {
+			to = self.nextTimeframe(timeframe, to)
+		}
+	}
+	trades, err := self.TradesRange(symbol, since, to, params)
+	if err != nil {
+		return nil, err
+	}
+	return self.BuildOHLCV(trades, timeframe, since, limit), nil
+}
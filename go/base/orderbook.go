@@ -0,0 +1,386 @@
+package base
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NOTE: OrderBook 中 Bids 按价格降序, Asks 按价格升序, FetchOrderBook 返回的快照满足这个约定,
+// 可以直接作为增量更新的初始状态, Nonce 为快照的更新 id
+
+// OrderBookDelta 增量深度, amount 为 0 表示删除该价位
+type OrderBookDelta struct {
+	FirstUpdateId int64 // 本次更新的第一个 id, 如 binance/gateio 的 U, 为 0 时和 LastUpdateId 相同
+	LastUpdateId  int64 // 本次更新的最后一个 id, 如 binance/gateio 的 u, bybit 的 u, kucoin 的 sequenceEnd
+	PrevUpdateId  int64 // 上一次更新的最后一个 id, 如 binance 合约的 pu, 为 0 时不检查
+	Bids          [][2]float64
+	Asks          [][2]float64
+	// 交易所原始的价格和数量字符串, 不为空时代替 Bids/Asks, 需要校验和时使用 (如 okx)
+	RawBids   [][2]string
+	RawAsks   [][2]string
+	Timestamp int64
+}
+
+// ApplyDelta 按更新 id 应用增量深度.
+// 已经包含在当前深度中的更新会被忽略, 更新 id 不连续时返回 InvalidNonce 错误, 需要重新获取快照
+func (self *OrderBook) ApplyDelta(delta *OrderBookDelta) error {
+	first := delta.FirstUpdateId
+	if first == 0 {
+		first = delta.LastUpdateId
+	}
+	if delta.LastUpdateId <= self.Nonce {
+		return nil
+	}
+	if delta.PrevUpdateId > 0 {
+		if delta.PrevUpdateId != self.Nonce {
+			return TypedError("InvalidNonce", fmt.Sprintf("orderbook nonce %d, delta prev update id %d", self.Nonce, delta.PrevUpdateId))
+		}
+	} else if first > self.Nonce+1 {
+		return TypedError("InvalidNonce", fmt.Sprintf("orderbook nonce %d, delta first update id %d", self.Nonce, first))
+	}
+	if delta.RawBids != nil || delta.RawAsks != nil {
+		if err := self.UpdateRaw(delta.RawBids, delta.RawAsks); err != nil {
+			return err
+		}
+	} else {
+		self.Update(delta.Bids, delta.Asks)
+	}
+	self.Nonce = delta.LastUpdateId
+	if delta.Timestamp > 0 {
+		self.Timestamp = delta.Timestamp
+	}
+	return nil
+}
+
+// Update 更新价位, 不检查更新 id. 更新的价位丢弃原始字符串, CRC32 改为按浮点数格式化
+func (self *OrderBook) Update(bids [][2]float64, asks [][2]float64) {
+	for _, level := range bids {
+		self.Bids = updateLevel(self.Bids, level, true)
+		delete(self.rawBids, level[0])
+	}
+	for _, level := range asks {
+		self.Asks = updateLevel(self.Asks, level, false)
+		delete(self.rawAsks, level[0])
+	}
+}
+
+// UpdateRaw 按原始字符串更新价位并保留原始字符串, 之后 CRC32 使用原始字符串计算.
+// 快照也可以在空的 OrderBook 上调用 UpdateRaw 得到
+func (self *OrderBook) UpdateRaw(bids [][2]string, asks [][2]string) error {
+	parsedBids, err := parseRawLevels(bids)
+	if err != nil {
+		return err
+	}
+	parsedAsks, err := parseRawLevels(asks)
+	if err != nil {
+		return err
+	}
+	if self.rawBids == nil {
+		self.rawBids = map[float64][2]string{}
+	}
+	if self.rawAsks == nil {
+		self.rawAsks = map[float64][2]string{}
+	}
+	self.Update(parsedBids, parsedAsks)
+	updateRaw(self.rawBids, bids, parsedBids)
+	updateRaw(self.rawAsks, asks, parsedAsks)
+	return nil
+}
+
+func parseRawLevels(levels [][2]string) ([][2]float64, error) {
+	result := make([][2]float64, 0, len(levels))
+	for _, level := range levels {
+		price, err := strconv.ParseFloat(level[0], 64)
+		if err != nil {
+			return nil, TypedError("BadResponse", fmt.Sprintf("invalid orderbook price %q", level[0]))
+		}
+		size, err := strconv.ParseFloat(level[1], 64)
+		if err != nil {
+			return nil, TypedError("BadResponse", fmt.Sprintf("invalid orderbook size %q", level[1]))
+		}
+		result = append(result, [2]float64{price, size})
+	}
+	return result, nil
+}
+
+// Update 已经删除了这些价位的原始字符串, 这里只加回仍然存在的价位
+func updateRaw(raw map[float64][2]string, levels [][2]string, parsed [][2]float64) {
+	for i, level := range parsed {
+		if level[1] != 0 {
+			raw[level[0]] = levels[i]
+		}
+	}
+}
+
+func updateLevel(levels [][2]float64, level [2]float64, descending bool) [][2]float64 {
+	i := sort.Search(len(levels), func(i int) bool {
+		if descending {
+			return levels[i][0] <= level[0]
+		}
+		return levels[i][0] >= level[0]
+	})
+	found := i < len(levels) && levels[i][0] == level[0]
+	if level[1] == 0 {
+		if found {
+			levels = append(levels[:i], levels[i+1:]...)
+		}
+		return levels
+	}
+	if found {
+		levels[i][1] = level[1]
+		return levels
+	}
+	levels = append(levels, [2]float64{})
+	copy(levels[i+1:], levels[i:])
+	levels[i] = level
+	return levels
+}
+
+// Truncate 只保留买卖各 depth 档
+func (self *OrderBook) Truncate(depth int) {
+	if len(self.Bids) > depth {
+		for _, level := range self.Bids[depth:] {
+			delete(self.rawBids, level[0])
+		}
+		self.Bids = self.Bids[:depth]
+	}
+	if len(self.Asks) > depth {
+		for _, level := range self.Asks[depth:] {
+			delete(self.rawAsks, level[0])
+		}
+		self.Asks = self.Asks[:depth]
+	}
+}
+
+// BestBid 返回买一的价格和数量, 没有买单时 ok 为 false
+func (self *OrderBook) BestBid() (level [2]float64, ok bool) {
+	if len(self.Bids) == 0 {
+		return level, false
+	}
+	return self.Bids[0], true
+}
+
+// BestAsk 返回卖一的价格和数量, 没有卖单时 ok 为 false
+func (self *OrderBook) BestAsk() (level [2]float64, ok bool) {
+	if len(self.Asks) == 0 {
+		return level, false
+	}
+	return self.Asks[0], true
+}
+
+// Mid 买一卖一的中间价, 任一边为空时返回 0
+func (self *OrderBook) Mid() float64 {
+	bid, ok1 := self.BestBid()
+	ask, ok2 := self.BestAsk()
+	if !ok1 || !ok2 {
+		return 0
+	}
+	return (bid[0] + ask[0]) / 2
+}
+
+// Spread 卖一减买一, 任一边为空时返回 0
+func (self *OrderBook) Spread() float64 {
+	bid, ok1 := self.BestBid()
+	ask, ok2 := self.BestAsk()
+	if !ok1 || !ok2 {
+		return 0
+	}
+	return ask[0] - bid[0]
+}
+
+// 返回对应一边的价位, 以及是否按价格降序. side 无效时返回 BadRequest 错误
+func (self *OrderBook) levels(side string) ([][2]float64, bool, error) {
+	switch side {
+	case "bids", "bid", "sell":
+		return self.Bids, true, nil
+	case "asks", "ask", "buy":
+		return self.Asks, false, nil
+	}
+	return nil, false, TypedError("BadRequest", "invalid orderbook side "+side)
+}
+
+// DepthToPrice 累计到 price (含) 为止的数量和金额. side 为 "bids" 或 "asks",
+// 也可以用订单方向 "sell"/"buy" 表示吃掉的一边
+func (self *OrderBook) DepthToPrice(side string, price float64) (amount float64, cost float64, err error) {
+	levels, descending, err := self.levels(side)
+	if err != nil {
+		return
+	}
+	for _, level := range levels {
+		if (descending && level[0] < price) || (!descending && level[0] > price) {
+			break
+		}
+		amount += level[1]
+		cost += level[0] * level[1]
+	}
+	return
+}
+
+// DepthToAmount 累计数量达到 amount 时的价格和金额, 深度不足时 filled 小于 amount
+func (self *OrderBook) DepthToAmount(side string, amount float64) (price float64, cost float64, filled float64, err error) {
+	levels, _, err := self.levels(side)
+	if err != nil {
+		return
+	}
+	for _, level := range levels {
+		if filled >= amount {
+			break
+		}
+		size := level[1]
+		if filled+size > amount {
+			size = amount - filled
+		}
+		price = level[0]
+		cost += level[0] * size
+		filled += size
+	}
+	return
+}
+
+// CRC32 按 okx 的规则计算前 depth 档(okx 为 25 档)的校验和:
+// 买卖交替拼接 "bidPrice:bidSize:askPrice:askSize:..." 后计算 crc32, 结果为有符号 32 位整数.
+// NOTE: okx 的校验和使用原始字符串(保留末尾的 0), 所以需要用 UpdateRaw 或 OrderBookDelta.RawBids/RawAsks 更新,
+// 没有原始字符串的价位按最短形式格式化
+func (self *OrderBook) CRC32(depth int) int32 {
+	parts := []string{}
+	for i := 0; i < depth; i++ {
+		if i < len(self.Bids) {
+			parts = append(parts, formatLevel(self.rawBids, self.Bids[i])...)
+		}
+		if i < len(self.Asks) {
+			parts = append(parts, formatLevel(self.rawAsks, self.Asks[i])...)
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(strings.Join(parts, ":"))))
+}
+
+func formatLevel(raw map[float64][2]string, level [2]float64) []string {
+	if rawLevel, ok := raw[level[0]]; ok {
+		return rawLevel[:]
+	}
+	return []string{
+		strconv.FormatFloat(level[0], 'f', -1, 64),
+		strconv.FormatFloat(level[1], 'f', -1, 64),
+	}
+}
+
+// VerifyCRC32 校验和不一致时返回 ChecksumError 错误, 需要重新订阅或获取快照
+func (self *OrderBook) VerifyCRC32(checksum int64, depth int) error {
+	if actual := self.CRC32(depth); int64(actual) != checksum {
+		return TypedError("ChecksumError", fmt.Sprintf("orderbook checksum %d, expected %d", actual, checksum))
+	}
+	return nil
+}
+
+// FillEstimate 按当前可见深度估算市价单的成交情况
+type FillEstimate struct {
+	Side       string  // 订单方向, buy 吃卖单, sell 吃买单
+	Amount     float64 // 可成交的数量
+	Cost       float64 // 可成交的金额
+	Price      float64 // 成交均价(VWAP)
+	WorstPrice float64 // 成交到的最差价格
+	Mid        float64 // 下单时的中间价
+	Slippage   float64 // 成交均价相对中间价的滑点, 单位 bps, 正数表示比中间价差
+	Fillable   bool    // 可见深度是否足够完全成交
+	Remaining  float64 // 未能成交的部分, 按数量估算时为数量, 按金额估算时为金额
+}
+
+func (self *OrderBook) newFillEstimate(side string) *FillEstimate {
+	return &FillEstimate{
+		Side: side,
+		Mid:  self.Mid(),
+	}
+}
+
+func (self *FillEstimate) finish() *FillEstimate {
+	if self.Amount > 0 {
+		self.Price = self.Cost / self.Amount
+	}
+	if self.Mid > 0 && self.Price > 0 {
+		self.Slippage = (self.Price - self.Mid) / self.Mid * 10000
+		if self.Side == "sell" {
+			self.Slippage = -self.Slippage
+		}
+	}
+	return self
+}
+
+// FillByAmount 估算成交 amount 个 base 币的市价单, side 为订单方向 buy/sell
+func (self *OrderBook) FillByAmount(side string, amount float64) (*FillEstimate, error) {
+	result := self.newFillEstimate(side)
+	price, cost, filled, err := self.DepthToAmount(side, amount)
+	if err != nil {
+		return nil, err
+	}
+	result.Amount = filled
+	result.Cost = cost
+	result.WorstPrice = price
+	result.Remaining = amount - filled
+	// 数量为 0 (如按精度截断后) 时不可成交
+	result.Fillable = filled > 0 && result.Remaining <= 0
+	return result.finish(), nil
+}
+
+// FillByCost 估算花费(或得到) cost 个 quote 币的市价单, side 为订单方向 buy/sell
+func (self *OrderBook) FillByCost(side string, cost float64) (*FillEstimate, error) {
+	result := self.newFillEstimate(side)
+	levels, _, err := self.levels(side)
+	if err != nil {
+		return nil, err
+	}
+	for _, level := range levels {
+		if result.Cost >= cost {
+			break
+		}
+		size := level[1]
+		if result.Cost+level[0]*size > cost {
+			size = (cost - result.Cost) / level[0]
+		}
+		result.WorstPrice = level[0]
+		result.Amount += size
+		result.Cost += level[0] * size
+	}
+	result.Remaining = cost - result.Cost
+	// 浮点误差
+	result.Fillable = result.Amount > 0 && result.Remaining <= cost*1e-12
+	if result.Fillable {
+		result.Remaining = 0
+	}
+	return result.finish(), nil
+}
+
+// EstimateFill 按市场的数量精度截断 amount 后估算市价单成交, market 为 nil 或没有设置数量精度时不处理精度
+func (self *Exchange) EstimateFill(orderBook *OrderBook, market *Market, side string, amount float64) (*FillEstimate, error) {
+	return orderBook.FillByAmount(side, self.truncateAmount(market, amount))
+}
+
+// EstimateFillCost 按金额估算市价单成交, 可成交数量按市场的数量精度截断后重新计算
+func (self *Exchange) EstimateFillCost(orderBook *OrderBook, market *Market, side string, cost float64) (*FillEstimate, error) {
+	result, err := orderBook.FillByCost(side, cost)
+	if err != nil || market == nil {
+		return result, err
+	}
+	amount := self.truncateAmount(market, result.Amount)
+	estimate, err := orderBook.FillByAmount(side, amount)
+	if err != nil {
+		return nil, err
+	}
+	estimate.Fillable = result.Fillable && estimate.Amount > 0
+	estimate.Remaining = cost - estimate.Cost
+	return estimate, nil
+}
+
+// NOTE: Precision.Amount 为 0 时视为未设置 (和 SetMarkets 一致), 不截断
+func (self *Exchange) truncateAmount(market *Market, amount float64) float64 {
+	if market == nil || market.Precision.Amount == 0 {
+		return amount
+	}
+	ret, err := DecimalToPrecision(amount, Truncate, market.Precision.Amount, DecimalPlaces, NoPadding)
+	if err != nil {
+		return amount
+	}
+	return ToFloat(ret)
+}
@@ -0,0 +1,331 @@
+package base
+
+// 每页数量由 options 中的 fetchOHLCVLimit, fetchTradesLimit 和 fetchLedgerLimit 指定, 应为交易所单次请求的上限
+const (
+	defaultFetchOHLCVLimit  = 500
+	defaultFetchTradesLimit = 500
+	defaultFetchLedgerLimit = 100
+)
+
+// OHLCVIterator 从 since 开始按页向后获取 K 线, 直到 to 或当前时间
+//
+//	it := ex.NewOHLCVIterator("BTC/USDT", "1m", since, 0, nil)
+//	for !it.Done() {
+//		klines, err := it.Next()
+//		...
+//	}
+type OHLCVIterator struct {
+	Symbol    string
+	Timeframe string
+	Since     int64 // 下一页的起始时间, 毫秒
+	To        int64 // 结束时间(不含), 为 0 时到当前时间为止
+	Limit     int64 // 每页数量
+	Params    map[string]interface{}
+
+	ex   *Exchange
+	last int64 // 已返回的最后一根 K 线的时间, 用于去重
+	done bool
+}
+
+func (self *Exchange) NewOHLCVIterator(symbol, timeframe string, since, to int64, params map[string]interface{}) *OHLCVIterator {
+	return &OHLCVIterator{
+		Symbol:    symbol,
+		Timeframe: timeframe,
+		Since:     since,
+		To:        to,
+		Limit:     self.SafeInteger(self.Options, "fetchOHLCVLimit", defaultFetchOHLCVLimit),
+		Params:    params,
+		ex:        self,
+		last:      -1,
+	}
+}
+
+func (self *OHLCVIterator) Done() bool {
+	return self.done
+}
+
+// Next 返回下一页的 K 线, 结束时 Done() 返回 true. 某个时间段没有 K 线时可能返回空列表
+func (self *OHLCVIterator) Next() (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.ex.PanicToError(e)
+		}
+	}()
+	klines = []*OHLCV{}
+	if self.done {
+		return klines, nil
+	}
+	duration := self.ex.ParseTimeframe(self.Timeframe) * 1000
+	end := self.ex.Milliseconds()
+	if self.To > 0 && self.To < end {
+		end = self.To
+	}
+	if self.Since >= end {
+		self.done = true
+		return klines, nil
+	}
+	if !self.ex.EnableRateLimit {
+		self.ex.Throttle()
+	}
+	page, err := self.ex.Child.FetchOHLCV(self.Symbol, self.Timeframe, self.Since, self.Limit, self.Params)
+	if err != nil {
+		return nil, err
+	}
+	for _, kline := range page {
+		// NOTE: 相邻两页可能有重叠的 K 线
+		if kline.Timestamp <= self.last || kline.Timestamp < self.Since {
+			continue
+		}
+		if kline.Timestamp >= end {
+			break
+		}
+		klines = append(klines, kline)
+		self.last = kline.Timestamp
+	}
+	if len(klines) > 0 {
+		self.Since = self.last + duration
+	} else {
+		// 这一段时间没有 K 线(如停牌), 跳过一页对应的时间
+		self.Since += self.Limit * duration
+	}
+	// 最后一根是当前未完成的 K 线
+	if self.Since >= end {
+		self.done = true
+	}
+	return klines, nil
+}
+
+// OHLCVRange 获取 [since, to) 之间的所有 K 线, to 为 0 时到当前时间为止
+func (self *Exchange) OHLCVRange(symbol, timeframe string, since, to int64, params map[string]interface{}) ([]*OHLCV, error) {
+	result := []*OHLCV{}
+	it := self.NewOHLCVIterator(symbol, timeframe, since, to, params)
+	for !it.Done() {
+		klines, err := it.Next()
+		if err != nil {
+			return result, err
+		}
+		result = append(result, klines...)
+	}
+	return result, nil
+}
+
+// TradesIterator 从 since 开始按页向后获取成交记录, 直到 to 或没有更多成交.
+// FetchTrades 忽略 since 只返回最新成交的交易所(Options["fetchTradesSince"] 为 false)不支持分页, Next 返回 NotSupported
+type TradesIterator struct {
+	Symbol string
+	Since  int64 // 下一页的起始时间, 毫秒
+	To     int64 // 结束时间(不含), 为 0 时到当前时间为止
+	Limit  int64 // 每页数量
+	Params map[string]interface{}
+
+	ex   *Exchange
+	seen map[string]bool // 时间为 Since 的已返回成交, 用于去重
+	done bool
+}
+
+func (self *Exchange) NewTradesIterator(symbol string, since, to int64, params map[string]interface{}) *TradesIterator {
+	return &TradesIterator{
+		Symbol: symbol,
+		Since:  since,
+		To:     to,
+		Limit:  self.SafeInteger(self.Options, "fetchTradesLimit", defaultFetchTradesLimit),
+		Params: params,
+		ex:     self,
+		seen:   map[string]bool{},
+	}
+}
+
+func (self *TradesIterator) Done() bool {
+	return self.done
+}
+
+// Next 返回下一页的成交记录, 结束时 Done() 返回 true
+func (self *TradesIterator) Next() (trades []*Trade, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.ex.PanicToError(e)
+		}
+	}()
+	trades = []*Trade{}
+	if self.done {
+		return trades, nil
+	}
+	if !self.ex.ToBool(self.ex.SafeValue(self.ex.Options, "fetchTradesSince", true)) {
+		self.ex.RaiseException("NotSupported", self.ex.Id+" fetchTrades does not support since, trades can not be paginated")
+	}
+	end := self.ex.Milliseconds()
+	if self.To > 0 && self.To < end {
+		end = self.To
+	}
+	if self.Since >= end {
+		self.done = true
+		return trades, nil
+	}
+	if !self.ex.EnableRateLimit {
+		self.ex.Throttle()
+	}
+	page, err := self.ex.Child.FetchTrades(self.Symbol, self.Since, self.Limit, self.Params)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 下一页从最后一笔成交的时间开始, 同一毫秒内的成交可能分在两页, 按 id 去重
+	since := self.Since
+	for _, trade := range page {
+		if trade.Timestamp < self.Since || (trade.Timestamp == self.Since && self.seen[trade.Id]) {
+			continue
+		}
+		if trade.Timestamp >= end {
+			self.done = true
+			break
+		}
+		if trade.Timestamp > since {
+			since = trade.Timestamp
+			self.seen = map[string]bool{}
+		}
+		self.seen[trade.Id] = true
+		trades = append(trades, trade)
+	}
+	if len(trades) > 0 {
+		self.Since = since
+		return trades, nil
+	}
+	// 没有新的成交. 如果整页都是同一毫秒的重复成交, 跳过这一毫秒, 否则已经到了最新的成交
+	if len(page) > 0 && page[len(page)-1].Timestamp == self.Since {
+		self.Since++
+		self.seen = map[string]bool{}
+	} else {
+		self.done = true
+	}
+	return trades, nil
+}
+
+// TradesRange 获取 [since, to) 之间的所有成交记录, to 为 0 时到当前时间为止
+func (self *Exchange) TradesRange(symbol string, since, to int64, params map[string]interface{}) ([]*Trade, error) {
+	result := []*Trade{}
+	it := self.NewTradesIterator(symbol, since, to, params)
+	for !it.Done() {
+		trades, err := it.Next()
+		if err != nil {
+			return result, err
+		}
+		result = append(result, trades...)
+	}
+	return result, nil
+}
+
+// LedgerIterator 从 since 开始按页向后获取账户流水, 直到 to 或没有更多流水.
+// 交易所限制单次查询的时间范围时, 由 Options["fetchLedgerWindow"] 给出范围(毫秒), 没有流水的时间段按范围跳过
+type LedgerIterator struct {
+	Code   string
+	Since  int64 // 下一页的起始时间, 毫秒
+	To     int64 // 结束时间(不含), 为 0 时到当前时间为止
+	Limit  int64 // 每页数量
+	Window int64 // 单次查询的时间范围, 为 0 时不限制
+	Params map[string]interface{}
+
+	ex   *Exchange
+	seen map[string]bool // 时间为 Since 的已返回流水, 用于去重
+	done bool
+}
+
+func (self *Exchange) NewLedgerIterator(code string, since, to int64, params map[string]interface{}) *LedgerIterator {
+	return &LedgerIterator{
+		Code:   code,
+		Since:  since,
+		To:     to,
+		Limit:  self.SafeInteger(self.Options, "fetchLedgerLimit", defaultFetchLedgerLimit),
+		Window: self.SafeInteger(self.Options, "fetchLedgerWindow", 0),
+		Params: params,
+		ex:     self,
+		seen:   map[string]bool{},
+	}
+}
+
+func (self *LedgerIterator) Done() bool {
+	return self.done
+}
+
+// Next 返回下一页的流水, 结束时 Done() 返回 true. 有时间范围限制或在这里过滤币种时可能返回空列表
+func (self *LedgerIterator) Next() (entries []*LedgerEntry, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.ex.PanicToError(e)
+		}
+	}()
+	entries = []*LedgerEntry{}
+	if self.done {
+		return entries, nil
+	}
+	end := self.ex.Milliseconds()
+	if self.To > 0 && self.To < end {
+		end = self.To
+	}
+	if self.Since >= end {
+		self.done = true
+		return entries, nil
+	}
+	if !self.ex.EnableRateLimit {
+		self.ex.Throttle()
+	}
+	// NOTE: 交易所不支持按币种查询时 (Options["fetchLedgerByCode"] 为 false), 按未过滤的页分页, 在这里过滤币种,
+	// 否则没有该币种流水的页会被当作没有更多流水
+	code := self.Code
+	if !self.ex.ToBool(self.ex.SafeValue(self.ex.Options, "fetchLedgerByCode", true)) {
+		code = ""
+	}
+	page, err := self.ex.Child.FetchLedger(code, self.Since, self.Limit, self.Params)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 同 TradesIterator, 下一页从最后一条流水的时间开始, 按 id 去重
+	since := self.Since
+	progressed := false
+	for _, entry := range page {
+		if entry.Timestamp < self.Since || (entry.Timestamp == self.Since && self.seen[entry.Id]) {
+			continue
+		}
+		if entry.Timestamp >= end {
+			self.done = true
+			break
+		}
+		if entry.Timestamp > since {
+			since = entry.Timestamp
+			self.seen = map[string]bool{}
+		}
+		self.seen[entry.Id] = true
+		progressed = true
+		if self.Code == "" || entry.Currency == self.Code {
+			entries = append(entries, entry)
+		}
+	}
+	if progressed {
+		self.Since = since
+		return entries, nil
+	}
+	if len(page) > 0 && page[len(page)-1].Timestamp == self.Since {
+		self.Since++
+		self.seen = map[string]bool{}
+	} else if self.Window > 0 {
+		self.Since += self.Window
+	} else {
+		self.done = true
+	}
+	if self.Since >= end {
+		self.done = true
+	}
+	return entries, nil
+}
+
+// LedgerRange 获取 [since, to) 之间的所有流水, to 为 0 时到当前时间为止
+func (self *Exchange) LedgerRange(code string, since, to int64, params map[string]interface{}) ([]*LedgerEntry, error) {
+	result := []*LedgerEntry{}
+	it := self.NewLedgerIterator(code, since, to, params)
+	for !it.Done() {
+		entries, err := it.Next()
+		if err != nil {
+			return result, err
+		}
+		result = append(result, entries...)
+	}
+	return result, nil
+}
@@ -0,0 +1,349 @@
+package base
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 币种估值的状态
+const (
+	ValuationOk       = "ok"
+	ValuationIlliquid = "illiquid" // 转换路径上有交易对没有买一卖一或价差过大, 按中间价或最新价估值
+	ValuationUnknown  = "unknown"  // 找不到转换路径, Value 为 0
+)
+
+// PortfolioVenue 组合中的一个交易所账户
+type PortfolioVenue struct {
+	Name     string
+	Exchange ExchangeInterface
+	// FetchBalances 的账户类型, 为空时只获取默认账户
+	AccountTypes []string
+	// 为 true 时同时获取仓位, 用于合约交易所. 合约账户的 Total 已包含未实现盈亏, 仓位只统计敞口, 不重复计入价值
+	Positions bool
+}
+
+// Portfolio 汇总多个交易所的余额, 以 Quote 计价. 价格取各交易所 FetchTickers 的中间价, 按 Venues 的顺序优先
+//
+//	p := NewPortfolio("USD")
+//	p.AddVenue("binance", binance)
+//	p.AddVenue("futures", futuresBinance, AccountTypeSwap).Positions = true
+//	p.FixedRates["USDT/USD"] = 1
+//	p.FixedRates["XBT/BTC"] = 1 // futures_kucoin 的反向合约以 XBT 结算
+//	p.Paths["ASD"] = []string{"USDT", "USD"}
+//	valuation, err := p.Value()
+type Portfolio struct {
+	Quote  string
+	Venues []*PortfolioVenue
+	// 币种到 Quote 的转换路径(不含币种自身), 如 "ASD": {"USDT", "USD"} 表示 ASD→USDT→USD, 最后不是 Quote 时自动补上
+	Paths map[string][]string
+	// 没有配置 Paths 的币种依次尝试直接转换和经过 Bridges 中的币种转换
+	Bridges []string
+	// 交易所没有的交易对的固定价格, 如 "USDT/USD": 1
+	FixedRates map[string]float64
+	// 买一卖一价差超过中间价的比例时视为流动性不足, 为 0 时不检查
+	MaxSpread float64
+}
+
+// PortfolioAsset 一个币种的数量和估值
+type PortfolioAsset struct {
+	Currency string
+	Amount   float64 // 扣除借款和利息后的数量
+	Price    float64 // 以 Quote 计价的单价
+	Value    float64
+	Path     []string // 实际使用的转换路径, 包括币种自身和 Quote
+	Status   string   // ValuationOk, ValuationIlliquid 或 ValuationUnknown
+}
+
+// VenueValuation 一个交易所账户的估值
+type VenueValuation struct {
+	Name      string
+	Value     float64
+	Assets    map[string]*PortfolioAsset
+	Positions []*Position
+	Exposure  float64 // 仓位名义价值绝对值之和, 按仓位的结算币种换算为 Quote
+	UnrealPnl float64 // 仓位未实现盈亏之和, 按仓位的结算币种换算为 Quote, 已包含在 Value 中
+	Error     error   // 获取余额或仓位失败, 此时 Value 不完整
+}
+
+// PortfolioValuation 组合的估值结果. Unknown 和 Illiquid 为有余额但无法估值或流动性不足的币种
+type PortfolioValuation struct {
+	Quote     string
+	Timestamp int64
+	Total     float64
+	Venues    map[string]*VenueValuation
+	Assets    map[string]*PortfolioAsset // 所有交易所合计
+	Unknown   []string
+	Illiquid  []string
+}
+
+func NewPortfolio(quote string) *Portfolio {
+	return &Portfolio{
+		Quote:      quote,
+		Venues:     []*PortfolioVenue{},
+		Paths:      map[string][]string{},
+		Bridges:    []string{"USDT", "USDC", "BTC", "ETH"},
+		FixedRates: map[string]float64{},
+	}
+}
+
+// AddVenue 添加一个交易所账户, name 在组合中唯一. 返回的 PortfolioVenue 可以继续设置 Positions
+func (self *Portfolio) AddVenue(name string, ex ExchangeInterface, accountTypes ...string) *PortfolioVenue {
+	venue := &PortfolioVenue{
+		Name:         name,
+		Exchange:     ex,
+		AccountTypes: accountTypes,
+	}
+	self.Venues = append(self.Venues, venue)
+	return venue
+}
+
+// Value 获取所有交易所的余额和行情并估值. 部分交易所失败时返回其他交易所的估值和第一个错误, 失败的交易所见 VenueValuation.Error
+func (self *Portfolio) Value() (*PortfolioValuation, error) {
+	var err error
+	result := &PortfolioValuation{
+		Quote:    self.Quote,
+		Venues:   map[string]*VenueValuation{},
+		Assets:   map[string]*PortfolioAsset{},
+		Unknown:  []string{},
+		Illiquid: []string{},
+	}
+	prices := self.fetchPrices()
+	for _, venue := range self.Venues {
+		valuation := self.valueVenue(venue, prices)
+		result.Venues[venue.Name] = valuation
+		if valuation.Error != nil && err == nil {
+			err = fmt.Errorf("%s: %s", venue.Name, valuation.Error)
+		}
+		result.Total += valuation.Value
+		for code, asset := range valuation.Assets {
+			total, ok := result.Assets[code]
+			if !ok {
+				total = &PortfolioAsset{
+					Currency: code,
+					Price:    asset.Price,
+					Path:     asset.Path,
+					Status:   asset.Status,
+				}
+				result.Assets[code] = total
+			}
+			total.Amount += asset.Amount
+			total.Value += asset.Value
+		}
+	}
+	for code, asset := range result.Assets {
+		switch asset.Status {
+		case ValuationUnknown:
+			result.Unknown = append(result.Unknown, code)
+		case ValuationIlliquid:
+			result.Illiquid = append(result.Illiquid, code)
+		}
+	}
+	sort.Strings(result.Unknown)
+	sort.Strings(result.Illiquid)
+	result.Timestamp = time.Now().UnixNano() / 1000000
+	return result, err
+}
+
+// Price 以 Quote 计价的单价, 返回实际使用的转换路径和状态
+func (self *Portfolio) Price(code string) (float64, []string, string) {
+	return self.price(code, self.fetchPrices())
+}
+
+func (self *Portfolio) valueVenue(venue *PortfolioVenue, prices map[string]*Ticker) *VenueValuation {
+	result := &VenueValuation{
+		Name:   venue.Name,
+		Assets: map[string]*PortfolioAsset{},
+	}
+	var accounts map[string]*Account
+	if len(venue.AccountTypes) == 0 {
+		account, err := venue.Exchange.FetchBalance(nil)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		accounts = map[string]*Account{"": account}
+	} else {
+		accounts, result.Error = venue.Exchange.FetchBalances(venue.AccountTypes, nil)
+	}
+	for _, account := range accounts {
+		for code, total := range account.Total {
+			// 杠杆账户的 Total 为含借入资产的总额, 扣除借款和利息得到净额
+			amount := total
+			if balance, ok := account.Account[code]; ok && balance != nil {
+				amount -= balance.Borrowed + balance.Interest
+			}
+			if amount == 0 {
+				continue
+			}
+			asset, ok := result.Assets[code]
+			if !ok {
+				asset = &PortfolioAsset{Currency: code}
+				asset.Price, asset.Path, asset.Status = self.price(code, prices)
+				result.Assets[code] = asset
+			}
+			asset.Amount += amount
+		}
+	}
+	for _, asset := range result.Assets {
+		asset.Value = asset.Amount * asset.Price
+		result.Value += asset.Value
+	}
+	if !venue.Positions {
+		return result
+	}
+	positions, err := venue.Exchange.FetchPositions("", nil)
+	if err != nil {
+		if result.Error == nil {
+			result.Error = err
+		}
+		return result
+	}
+	result.Positions = positions
+	for _, position := range positions {
+		// NOTE: 反向合约的仓位价值和盈亏以 base 计价, 所以按结算币种估值, 未知时按交易对的 quote
+		settle := position.Settle
+		if settle == "" {
+			settle = symbolSettle(position.Symbol)
+		}
+		price, _, _ := self.price(settle, prices)
+		result.Exposure += math.Abs(position.Notional) * price
+		result.UnrealPnl += position.UnrealPnl * price
+	}
+	return result
+}
+
+// fetchPrices 按 Venues 的顺序获取行情, 同一交易对取第一个交易所的. 获取失败的交易所跳过
+func (self *Portfolio) fetchPrices() map[string]*Ticker {
+	result := map[string]*Ticker{}
+	for _, venue := range self.Venues {
+		tickers, err := venue.Exchange.FetchTickers(nil, nil)
+		if err != nil {
+			continue
+		}
+		for _, ticker := range tickers {
+			if _, ok := result[ticker.Symbol]; !ok {
+				result[ticker.Symbol] = ticker
+			}
+		}
+	}
+	return result
+}
+
+// price 依次尝试 Paths 中配置的路径, 直接转换和经过 Bridges 转换, 优先使用流动性正常的路径.
+// Bridges 中的币种按同样的规则转换为 Quote, 如 ILL→BTC→USDT→USD
+func (self *Portfolio) price(code string, prices map[string]*Ticker) (float64, []string, string) {
+	return self.bridgePrice(code, prices, map[string]bool{})
+}
+
+func (self *Portfolio) bridgePrice(code string, prices map[string]*Ticker, visited map[string]bool) (float64, []string, string) {
+	if code == self.Quote {
+		return 1, []string{code}, ValuationOk
+	}
+	if hops, ok := self.Paths[code]; ok {
+		path := append([]string{code}, hops...)
+		if path[len(path)-1] != self.Quote {
+			path = append(path, self.Quote)
+		}
+		price, status := self.pathPrice(path, prices)
+		if status == ValuationUnknown {
+			return 0, nil, status
+		}
+		return price, path, status
+	}
+	visited[code] = true
+	defer delete(visited, code)
+	var illiquid []string
+	var illiquidPrice float64
+	for _, bridge := range append([]string{self.Quote}, self.Bridges...) {
+		if visited[bridge] {
+			continue
+		}
+		rate, status := self.rate(code, bridge, prices)
+		if status == ValuationUnknown {
+			continue
+		}
+		price, path, s := self.bridgePrice(bridge, prices, visited)
+		if s == ValuationUnknown {
+			continue
+		}
+		if s == ValuationIlliquid {
+			status = ValuationIlliquid
+		}
+		path = append([]string{code}, path...)
+		if status == ValuationOk {
+			return rate * price, path, status
+		}
+		if illiquid == nil {
+			illiquid = path
+			illiquidPrice = rate * price
+		}
+	}
+	if illiquid != nil {
+		return illiquidPrice, illiquid, ValuationIlliquid
+	}
+	return 0, nil, ValuationUnknown
+}
+
+func (self *Portfolio) pathPrice(path []string, prices map[string]*Ticker) (float64, string) {
+	result := 1.0
+	status := ValuationOk
+	for i := 0; i+1 < len(path); i++ {
+		rate, s := self.rate(path[i], path[i+1], prices)
+		if s == ValuationUnknown {
+			return 0, ValuationUnknown
+		}
+		if s == ValuationIlliquid {
+			status = ValuationIlliquid
+		}
+		result *= rate
+	}
+	return result, status
+}
+
+// rate 1 个 from 等于多少 to, 依次取固定价格, from/to 和 to/from 的行情
+func (self *Portfolio) rate(from, to string, prices map[string]*Ticker) (float64, string) {
+	if from == to {
+		return 1, ValuationOk
+	}
+	if rate, ok := self.FixedRates[from+"/"+to]; ok && rate > 0 {
+		return rate, ValuationOk
+	}
+	if rate, ok := self.FixedRates[to+"/"+from]; ok && rate > 0 {
+		return 1 / rate, ValuationOk
+	}
+	if ticker, ok := prices[from+"/"+to]; ok {
+		if price, status := self.midPrice(ticker); price > 0 {
+			return price, status
+		}
+	}
+	if ticker, ok := prices[to+"/"+from]; ok {
+		if price, status := self.midPrice(ticker); price > 0 {
+			return 1 / price, status
+		}
+	}
+	return 0, ValuationUnknown
+}
+
+// midPrice 买一卖一的中间价, 没有买一或卖一时用最新价并视为流动性不足
+func (self *Portfolio) midPrice(ticker *Ticker) (float64, string) {
+	if ticker.Bid <= 0 || ticker.Ask <= 0 {
+		return ticker.Last, ValuationIlliquid
+	}
+	mid := (ticker.Bid + ticker.Ask) / 2
+	if self.MaxSpread > 0 && (ticker.Ask-ticker.Bid)/mid > self.MaxSpread {
+		return mid, ValuationIlliquid
+	}
+	return mid, ValuationOk
+}
+
+// symbolSettle 仓位没有结算币种时从交易对推断, symbol 为 BASE/QUOTE 时为 QUOTE, BASE/QUOTE:SETTLE 时为 SETTLE
+func symbolSettle(symbol string) string {
+	quote := symbol[strings.Index(symbol, "/")+1:]
+	if i := strings.Index(quote, ":"); i >= 0 {
+		return quote[i+1:]
+	}
+	return quote
+}
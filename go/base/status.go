@@ -0,0 +1,93 @@
+package base
+
+const (
+	StatusOk          = "ok"
+	StatusMaintenance = "maintenance"
+)
+
+// ExchangeStatus 交易所的系统状态
+type ExchangeStatus struct {
+	Status  string // "ok", "maintenance"
+	Updated int64  // 更新时间戳
+	Eta     int64  // 维护中时预计恢复的时间戳, 未知时为 0
+	Url     string // 维护公告链接
+	Message string
+	// 正在进行和计划中的维护, 按开始时间升序
+	Maintenances []*Maintenance
+	// 暂停交易的交易对和暂停充值或提现的币种, 只在 Options["fetchStatusDetails"] 为 true 且交易所提供时填充, 否则为 nil
+	Markets    map[string]*Suspension
+	Currencies map[string]*Suspension
+	Info       interface{}
+}
+
+// Maintenance 一次系统维护, 时间为毫秒
+type Maintenance struct {
+	Title string
+	Start int64
+	End   int64 // 未知时为 0
+	Url   string
+	Info  interface{}
+}
+
+// Suspension 交易对或币种的暂停情况, 为 true 时表示已暂停
+type Suspension struct {
+	Trading  bool
+	Deposit  bool
+	Withdraw bool
+}
+
+// InMaintenance 在 now 时是否处于维护中, 包括 before 毫秒内即将开始的维护. 调度器可据此在维护开始前暂停
+func (self *ExchangeStatus) InMaintenance(now, before int64) bool {
+	if self.Status == StatusMaintenance {
+		return true
+	}
+	m := self.NextMaintenance(now)
+	return m != nil && m.Start <= now+before
+}
+
+// NextMaintenance 在 now 时正在进行或最近一次计划中的维护, 没有时返回 nil
+func (self *ExchangeStatus) NextMaintenance(now int64) *Maintenance {
+	var result *Maintenance
+	for _, m := range self.Maintenances {
+		if m.End > 0 && m.End <= now {
+			continue
+		}
+		if result == nil || m.Start < result.Start {
+			result = m
+		}
+	}
+	return result
+}
+
+// FetchSuspensions 获取交易对和币种列表, 根据 Market.Active 和 Currency 的 Deposit/Withdraw 填充暂停的交易对和币种.
+// withCurrencies 为 false 时不获取币种(如接口需要 apiKey 而未配置).
+// 需要额外请求交易对和币种列表, 轮询状态时开销较大, 所以只在 Options["fetchStatusDetails"] 为 true 时获取, 默认不获取
+func (self *Exchange) FetchSuspensions(status *ExchangeStatus, withCurrencies bool) error {
+	if !self.ToBool(self.SafeValue(self.Options, "fetchStatusDetails", false)) {
+		return nil
+	}
+	markets, err := self.Child.FetchMarkets(nil)
+	if err != nil {
+		return err
+	}
+	status.Markets = map[string]*Suspension{}
+	for _, market := range markets {
+		if !market.Active {
+			status.Markets[market.Symbol] = &Suspension{Trading: true}
+		}
+	}
+	if !withCurrencies {
+		return nil
+	}
+	currencies, err := self.Child.FetchCurrencies(nil)
+	if err != nil {
+		return err
+	}
+	status.Currencies = map[string]*Suspension{}
+	for code, currency := range currencies {
+		if !currency.Deposit || !currency.Withdraw {
+			status.Currencies[code] = &Suspension{Deposit: !currency.Deposit, Withdraw: !currency.Withdraw}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package base
+
+// 充值和提现. Network 为统一的链名称 (如 TRC20), 由 Options["networks"] 和交易所的链名称互相转换.
+// 参数中的 network 可以是统一的名称或交易所的名称, 返回的结果中总是统一的名称
+
+// Transaction 充值或提现记录
+type Transaction struct {
+	Id        string // 交易所的记录 id
+	Txid      string // 链上交易 hash, 未上链或内部转账时为空
+	Type      string // deposit, withdrawal
+	Currency  string
+	Network   string // 统一的链名称, 见 NetworkCode
+	Amount    float64
+	Fee       float64 // 交易所不返回时为 0
+	Address   string
+	Tag       string // memo 或 tag, 没有时为空
+	Status    string // pending, ok, failed, canceled
+	Timestamp int64
+	Updated   int64 // 最后更新时间, 交易所不返回时为 0
+	Info      interface{}
+}
+
+// NetworkId 统一的链名称转换为交易所的链名称, Options["networks"] 中没有时原样返回
+func (self *Exchange) NetworkId(network string) string {
+	networks := self.SafeValue(self.Options, "networks", map[string]interface{}{})
+	return self.SafeString(networks, network, network)
+}
+
+// NetworkCode 交易所的链名称转换为统一的名称, Options["networks"] 中没有时原样返回
+func (self *Exchange) NetworkCode(networkId string) string {
+	networks := self.SafeValue(self.Options, "networks", map[string]interface{}{}).(map[string]interface{})
+	for code, id := range networks {
+		if id == networkId {
+			return code
+		}
+	}
+	return networkId
+}
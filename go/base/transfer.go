@@ -0,0 +1,86 @@
+package base
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 账户之间划转, FromAccount 和 ToAccount 使用统一的账户类型, 见 AccountTypeSpot 等
+
+// Transfer 划转记录
+type Transfer struct {
+	Id             string
+	Currency       string
+	Amount         float64
+	FromAccount    string // 交易所不返回时为空
+	ToAccount      string
+	FromSubAccount string // 子账户划转时为转出的子账户, 母账户为空
+	ToSubAccount   string
+	Status         string // ok, pending, failed
+	Timestamp      int64
+	Info           interface{}
+}
+
+// 划转状态统一为 ok, pending, failed, 未知状态原样返回
+var transferStatuses = map[string]string{
+	"success":    "ok",
+	"successful": "ok",
+	"succeeded":  "ok",
+	"confirmed":  "ok",
+	"finished":   "ok",
+	"done":       "ok",
+	"pending":    "pending",
+	"processing": "pending",
+	"applying":   "pending",
+	"failed":     "failed",
+	"failure":    "failed",
+	"fail":       "failed",
+	"canceled":   "failed",
+}
+
+// ParseTransferStatus 统一划转状态
+func (self *Exchange) ParseTransferStatus(status string) string {
+	if result, ok := transferStatuses[strings.ToLower(status)]; ok {
+		return result
+	}
+	return status
+}
+
+// TransferAccountId 按 Options["accountsByType"] 把统一的账户类型转换为交易所的账户名称, 不支持时抛出 NotSupported
+func (self *Exchange) TransferAccountId(account string) string {
+	typ, _ := self.AccountType(map[string]interface{}{"type": account}, "")
+	accountsByType := self.SafeValue(self.Options, "accountsByType", map[string]interface{}{})
+	if id := self.SafeString(accountsByType, typ, ""); id != "" {
+		return id
+	}
+	self.RaiseException("NotSupported", fmt.Sprintf("%s transfer does not support the %s account", self.Id, typ))
+	return ""
+}
+
+// 多个统一的账户类型对应同一个交易所账户时, TransferAccountType 按这个顺序选择
+var transferAccountTypeOrder = []string{AccountTypeSpot, AccountTypeSwap, AccountTypeFutures, AccountTypeFunding, AccountTypeMargin, AccountTypeIsolated}
+
+// TransferAccountType 把交易所的账户名称转换为统一的账户类型, 找不到时为空.
+// 多个账户类型对应同一个账户名称时按 transferAccountTypeOrder 选择, 其他类型按名称排序
+func (self *Exchange) TransferAccountType(id string) string {
+	accountsByType := self.SafeValue(self.Options, "accountsByType", map[string]interface{}{}).(map[string]interface{})
+	types := []string{}
+	for typ, accountId := range accountsByType {
+		if s, ok := accountId.(string); ok && strings.EqualFold(s, id) {
+			types = append(types, typ)
+		}
+	}
+	for _, typ := range transferAccountTypeOrder {
+		for _, t := range types {
+			if t == typ {
+				return typ
+			}
+		}
+	}
+	if len(types) == 0 {
+		return ""
+	}
+	sort.Strings(types)
+	return types[0]
+}
@@ -45,6 +45,7 @@ func (self *Binance) Describe() []byte {
         "fetchBidsAsks": true,
         "fetchTickers": true,
         "fetchTime": true,
+        "fetchStatus": true,
         "fetchOHLCV": true,
         "fetchMyTrades": true,
         "fetchOrder": true,
@@ -58,7 +59,12 @@ func (self *Binance) Describe() []byte {
         "fetchTransactions": false,
         "fetchTradingFee": true,
         "fetchTradingFees": true,
-        "cancelAllOrders": true
+        "cancelAllOrders": true,
+        "cancelOrders": true,
+        "createOrders": true,
+        "editOrder": true,
+        "createStopOrder": true,
+        "createTrailingStopOrder": true
     },
     "timeframes": {
         "1m": "1m",
@@ -127,8 +133,14 @@ func (self *Binance) Describe() []byte {
                 "margin/myTrades",
                 "margin/maxBorrowable",
                 "margin/maxTransferable",
+                "margin/isolated/account",
+                "asset/transfer",
                 "futures/transfer",
                 "capital/config/getall",
+                "system/status",
+                "asset/tradeFee",
+                "account/info",
+                "bnbBurn",
                 "capital/deposit/address",
                 "capital/deposit/hisrec",
                 "capital/deposit/subAddress",
@@ -141,6 +153,7 @@ func (self *Binance) Describe() []byte {
                 "sub-account/margin/accountSummary",
                 "sub-account/status",
                 "sub-account/transfer/subUserHistory",
+                "sub-account/universalTransfer",
                 "lending/daily/product/list",
                 "lending/daily/userLeftQuota",
                 "lending/daily/userRedemptionQuota",
@@ -161,6 +174,8 @@ func (self *Binance) Describe() []byte {
             ],
             "post": [
                 "asset/dust",
+                "asset/get-funding-asset",
+                "asset/transfer",
                 "account/disableFastWithdrawSwitch",
                 "account/enableFastWithdrawSwitch",
                 "capital/withdraw/apply",
@@ -171,6 +186,7 @@ func (self *Binance) Describe() []byte {
                 "sub-account/margin/enable",
                 "sub-account/margin/enable",
                 "sub-account/futures/enable",
+                "sub-account/universalTransfer",
                 "userDataStream",
                 "futures/transfer",
                 "lending/customizedFixed/purchase",
@@ -182,6 +198,7 @@ func (self *Binance) Describe() []byte {
             ],
             "delete": [
                 "margin/order",
+                "margin/openOrders",
                 "userDataStream"
             ]
         },
@@ -206,6 +223,7 @@ func (self *Binance) Describe() []byte {
             ]
         },
         "fapiPublic": {
+            "cacheable": true,
             "get": [
                 "ping",
                 "time",
@@ -251,6 +269,7 @@ func (self *Binance) Describe() []byte {
                 "countdownCancelAll"
             ],
             "put": [
+                "order",
                 "listenKey"
             ],
             "delete": [
@@ -267,6 +286,7 @@ func (self *Binance) Describe() []byte {
             ]
         },
         "public": {
+            "cacheable": true,
             "get": [
                 "ping",
                 "time",
@@ -304,7 +324,8 @@ func (self *Binance) Describe() []byte {
             "post": [
                 "order/oco",
                 "order",
-                "order/test"
+                "order/test",
+                "order/cancelReplace"
             ],
             "delete": [
                 "openOrders",
@@ -328,8 +349,11 @@ func (self *Binance) Describe() []byte {
     "options": {
         "fetchTradesMethod": "publicGetAggTrades",
         "fetchTickersMethod": "publicGetTicker24hr",
+        "fetchOHLCVLimit": 1000,
+        "fetchTradesLimit": 1000,
         "defaultTimeInForce": "GTC",
         "defaultType": "spot",
+        "accountTypes": ["spot", "margin", "isolated", "swap", "funding"],
         "hasAlreadyAuthenticatedSuccessfully": false,
         "warnOnFetchOpenOrdersWithoutSymbol": true,
         "recvWindow": 5000,
@@ -340,7 +364,32 @@ func (self *Binance) Describe() []byte {
             "market": "FULL",
             "limit": "RESULT"
         },
-        "quoteOrderQty": true
+        "quoteOrderQty": true,
+        "cancelOrdersBatchSize": 10,
+        "createOrdersBatchSize": 5,
+        "liquidationLevel": 1.1,
+        "bnbDiscount": 0.75,
+        "accountsByType": {
+            "spot": "MAIN",
+            "margin": "MARGIN",
+            "isolated": "ISOLATEDMARGIN",
+            "futures": "CMFUTURE",
+            "swap": "UMFUTURE",
+            "funding": "FUNDING"
+        },
+        "networks": {
+            "ERC20": "ETH",
+            "TRC20": "TRX",
+            "BEP20": "BSC",
+            "BEP2": "BNB"
+        },
+        "subAccountsByType": {
+            "spot": "SPOT",
+            "margin": "MARGIN",
+            "isolated": "ISOLATED_MARGIN",
+            "futures": "COIN_FUTURE",
+            "swap": "USDT_FUTURE"
+        }
     },
     "exceptions": {
         "API key does not exist": "AuthenticationError",
@@ -482,30 +531,52 @@ func (self *Binance) FetchBalance(params map[string]interface{}) (balanceResult
 	}()
 	self.LoadMarkets()
 	defaultType := self.SafeString2(self.Options, "fetchBalance", "defaultType", "spot")
-	typ := self.SafeString(params, "type", defaultType)
-	method := "privateGetAccount"
-	if self.ToBool(typ == "future") {
-		method = "fapiPrivateGetAccount"
-	} else if self.ToBool(typ == "margin") {
-		method = "sapiGetMarginAccount"
-	}
-	query := self.Omit(params, "type")
-	response := self.ApiFunc(method, query, nil, nil)
+	typ, query := self.AccountType(params, defaultType)
 	result := map[string]interface{}{
-		"info": response,
+		"type": typ,
 	}
-	if self.ToBool(typ == "spot" || typ == "margin") {
+	switch typ {
+	case AccountTypeSpot, AccountTypeMargin:
+		method := "privateGetAccount"
+		if typ == AccountTypeMargin {
+			method = "sapiGetMarginAccount"
+		}
+		response := self.ApiFunc(method, query, nil, nil)
+		result["info"] = response
 		balances := self.SafeValue2(response, "balances", "userAssets", []interface{}{})
 		for i := 0; i < self.Length(balances); i++ {
 			balance := self.Member(balances, i)
 			currencyId := self.SafeString(balance, "asset", "")
 			code := self.SafeCurrencyCode(currencyId)
 			account := self.Account()
-			self.SetValue(account, "free", self.SafeFloat(balance, "free", 0))
-			self.SetValue(account, "used", self.SafeFloat(balance, "locked", 0))
+			free := self.SafeFloat(balance, "free", 0)
+			used := self.SafeFloat(balance, "locked", 0)
+			self.SetValue(account, "free", free)
+			self.SetValue(account, "used", used)
+			self.SetValue(account, "total", free+used)
 			self.SetValue(result, code, account)
 		}
-	} else {
+	case AccountTypeIsolated:
+		// 多个交易对的同一币种合并
+		response := self.ApiFunc("sapiGetMarginIsolatedAccount", query, nil, nil)
+		result["info"] = response
+		pairs := self.SafeValue(response, "assets", []interface{}{})
+		for i := 0; i < self.Length(pairs); i++ {
+			pair := self.Member(pairs, i)
+			for _, key := range []string{"baseAsset", "quoteAsset"} {
+				balance := self.SafeValue(pair, key, map[string]interface{}{})
+				code := self.SafeCurrencyCode(self.SafeString(balance, "asset", ""))
+				account := self.SafeValue(result, code, self.Account()).(map[string]interface{})
+				account["free"] = self.SafeFloat(account, "free", 0) + self.SafeFloat(balance, "free", 0)
+				account["used"] = self.SafeFloat(account, "used", 0) + self.SafeFloat(balance, "locked", 0)
+				account["total"] = self.SafeFloat(account, "total", 0) + self.SafeFloat(balance, "totalAsset", 0)
+				result[code] = account
+			}
+		}
+	case AccountTypeFutures, AccountTypeSwap:
+		// U 本位合约的交割和永续共用一个账户
+		response := self.ApiFunc("fapiPrivateGetAccount", query, nil, nil)
+		result["info"] = response
 		balances := self.SafeValue(response, "assets", []interface{}{})
 		for i := 0; i < self.Length(balances); i++ {
 			balance := self.Member(balances, i)
@@ -516,10 +587,579 @@ func (self *Binance) FetchBalance(params map[string]interface{}) (balanceResult
 			self.SetValue(account, "total", self.SafeFloat(balance, "marginBalance", 0))
 			self.SetValue(result, code, account)
 		}
+	case AccountTypeFunding:
+		response := self.ApiFuncReturnList("sapiPostAssetGetFundingAsset", query, nil, nil)
+		result["info"] = response
+		for _, balance := range response {
+			code := self.SafeCurrencyCode(self.SafeString(balance, "asset", ""))
+			account := self.Account()
+			account["free"] = self.SafeFloat(balance, "free", 0)
+			account["used"] = self.SafeFloat(balance, "locked", 0) + self.SafeFloat(balance, "freeze", 0) + self.SafeFloat(balance, "withdrawing", 0)
+			account["total"] = account["free"].(float64) + account["used"].(float64)
+			result[code] = account
+		}
+	default:
+		self.UnsupportedAccountType(typ)
 	}
 	return self.ParseBalance(result), nil
 }
 
+// 逐仓杠杆的请求参数, 全仓时为空
+func (self *Binance) isolatedMarginRequest(symbol string) map[string]interface{} {
+	if symbol == "" {
+		return map[string]interface{}{}
+	}
+	self.LoadMarkets()
+	return map[string]interface{}{
+		"isIsolated": "TRUE",
+		"symbol":     self.Market(symbol).Id,
+	}
+}
+
+func (self *Binance) marginLoan(method string, code string, amount float64, params map[string]interface{}) *MarginLoan {
+	symbol, query := self.MarginSymbol(params)
+	request := self.isolatedMarginRequest(symbol)
+	request["asset"] = code
+	request["amount"] = self.Float64ToString(amount)
+	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
+	return &MarginLoan{
+		Id:        fmt.Sprint(self.SafeInteger(response, "tranId", 0)),
+		Currency:  code,
+		Amount:    amount,
+		Symbol:    symbol,
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+}
+
+// Borrow 杠杆账户借币, params 中给出 symbol 时为逐仓借币
+func (self *Binance) Borrow(code string, amount float64, params map[string]interface{}) (result *MarginLoan, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.marginLoan("sapiPostMarginLoan", code, amount, params), nil
+}
+
+func (self *Binance) Repay(code string, amount float64, params map[string]interface{}) (result *MarginLoan, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.marginLoan("sapiPostMarginRepay", code, amount, params), nil
+}
+
+// 全仓返回 userAssets, 逐仓返回交易对的 baseAsset 和 quoteAsset, 以及账户信息
+func (self *Binance) fetchMarginAssets(symbol string, params map[string]interface{}) ([]interface{}, interface{}) {
+	if symbol == "" {
+		response := self.ApiFunc("sapiGetMarginAccount", params, nil, nil)
+		return self.SafeValue(response, "userAssets", []interface{}{}).([]interface{}), response
+	}
+	self.LoadMarkets()
+	request := map[string]interface{}{
+		"symbols": self.Market(symbol).Id,
+	}
+	response := self.ApiFunc("sapiGetMarginIsolatedAccount", self.Extend(request, params), nil, nil)
+	accounts := self.SafeValue(response, "assets", []interface{}{})
+	account := self.SafeValue(accounts, 0, map[string]interface{}{})
+	assets := []interface{}{}
+	for _, key := range []string{"baseAsset", "quoteAsset"} {
+		if asset := self.SafeValue(account, key, nil); asset != nil {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, account
+}
+
+// FetchBorrowInterest 账户接口不返回利率, InterestRate 为 0
+func (self *Binance) FetchBorrowInterest(code string, params map[string]interface{}) (result []*BorrowInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbol, query := self.MarginSymbol(params)
+	assets, _ := self.fetchMarginAssets(symbol, query)
+	result = []*BorrowInterest{}
+	for _, asset := range assets {
+		currency := self.SafeCurrencyCode(self.SafeString(asset, "asset", ""))
+		borrowed := self.SafeFloat(asset, "borrowed", 0)
+		interest := self.SafeFloat(asset, "interest", 0)
+		if (code != "" && currency != code) || (code == "" && borrowed == 0 && interest == 0) {
+			continue
+		}
+		result = append(result, &BorrowInterest{
+			Currency:  currency,
+			Symbol:    symbol,
+			Borrowed:  borrowed,
+			Interest:  interest,
+			Timestamp: self.Milliseconds(),
+			Info:      asset,
+		})
+	}
+	return
+}
+
+func (self *Binance) FetchMaxBorrowable(code string, params map[string]interface{}) (result *MaxBorrowable, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbol, query := self.MarginSymbol(params)
+	request := map[string]interface{}{
+		"asset": code,
+	}
+	if symbol != "" {
+		self.LoadMarkets()
+		request["isolatedSymbol"] = self.Market(symbol).Id
+	}
+	response := self.ApiFunc("sapiGetMarginMaxBorrowable", self.Extend(request, query), nil, nil)
+	result = &MaxBorrowable{
+		Currency: code,
+		Symbol:   symbol,
+		Amount:   self.SafeFloat(response, "amount", 0),
+		Info:     response,
+	}
+	return
+}
+
+// FetchMarginRisk 全仓的资产和负债按 BTC 计价, 逐仓只返回 MarginLevel.
+// binance 不返回强平的风险率, LiquidationLevel 取 Options["liquidationLevel"]
+func (self *Binance) FetchMarginRisk(params map[string]interface{}) (result *MarginRisk, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbol, query := self.MarginSymbol(params)
+	_, account := self.fetchMarginAssets(symbol, query)
+	result = &MarginRisk{
+		Symbol:           symbol,
+		MarginLevel:      self.SafeFloat(account, "marginLevel", 0),
+		LiquidationLevel: self.SafeFloat(self.Options, "liquidationLevel", 0),
+		Info:             account,
+	}
+	if symbol == "" {
+		result.TotalAsset = self.SafeFloat(account, "totalAssetOfBtc", 0)
+		result.TotalDebt = self.SafeFloat(account, "totalLiabilityOfBtc", 0)
+		result.Currency = "BTC"
+	}
+	return
+}
+
+// Transfer 万向划转, 逐仓杠杆账户需要在 params 中给出 symbol
+func (self *Binance) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbol, query := self.MarginSymbol(params)
+	fromId := self.TransferAccountId(fromAccount)
+	toId := self.TransferAccountId(toAccount)
+	request := map[string]interface{}{
+		"type":   fromId + "_" + toId,
+		"asset":  code,
+		"amount": self.Float64ToString(amount),
+	}
+	if symbol != "" {
+		self.LoadMarkets()
+		if fromId == "ISOLATEDMARGIN" {
+			request["fromSymbol"] = self.Market(symbol).Id
+		}
+		if toId == "ISOLATEDMARGIN" {
+			request["toSymbol"] = self.Market(symbol).Id
+		}
+	}
+	response := self.ApiFunc("sapiPostAssetTransfer", self.Extend(request, query), nil, nil)
+	result = self.parseTransfer(response)
+	result.Currency = code
+	result.Amount = amount
+	result.FromAccount = self.TransferAccountType(fromId)
+	result.ToAccount = self.TransferAccountType(toId)
+	result.Status = "ok"
+	result.Timestamp = self.Milliseconds()
+	return
+}
+
+// FetchTransfers 交易所要求按划转方向查询, params 中需要给出 fromAccount 和 toAccount
+func (self *Binance) FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) (result []*Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	fromAccount := self.SafeString(params, "fromAccount", "")
+	toAccount := self.SafeString(params, "toAccount", "")
+	if fromAccount == "" || toAccount == "" {
+		self.RaiseException("ArgumentsRequired", self.Id+" fetchTransfers requires fromAccount and toAccount params")
+	}
+	query := self.Omit(self.Extend(params).(map[string]interface{}), []string{"fromAccount", "toAccount"})
+	request := map[string]interface{}{
+		"type": self.TransferAccountId(fromAccount) + "_" + self.TransferAccountId(toAccount),
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["size"] = limit
+	}
+	response := self.ApiFunc("sapiGetAssetTransfer", self.Extend(request, query), nil, nil)
+	result = []*Transfer{}
+	for _, row := range self.SafeValue(response, "rows", []interface{}{}).([]interface{}) {
+		transfer := self.parseTransfer(row)
+		if code != "" && transfer.Currency != code {
+			continue
+		}
+		result = append(result, transfer)
+	}
+	return
+}
+
+func (self *Binance) parseTransfer(transfer interface{}) *Transfer {
+	result := &Transfer{
+		Id:        fmt.Sprint(self.SafeInteger(transfer, "tranId", 0)),
+		Currency:  self.SafeCurrencyCode(self.SafeString(transfer, "asset", "")),
+		Amount:    self.SafeFloat(transfer, "amount", 0),
+		Status:    self.ParseTransferStatus(self.SafeString(transfer, "status", "")),
+		Timestamp: self.SafeInteger(transfer, "timestamp", 0),
+		Info:      transfer,
+	}
+	if parts := strings.Split(self.SafeString(transfer, "type", ""), "_"); len(parts) == 2 {
+		result.FromAccount = self.TransferAccountType(parts[0])
+		result.ToAccount = self.TransferAccountType(parts[1])
+	}
+	return result
+}
+
+// SubAccountTransfer 子账户 id 为邮箱, 需要母账户的 API key
+func (self *Binance) SubAccountTransfer(code string, amount float64, fromSubAccount, toSubAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	fromAccount := self.SafeString(params, "fromAccount", AccountTypeSpot)
+	toAccount := self.SafeString(params, "toAccount", AccountTypeSpot)
+	query := self.Omit(self.Extend(params).(map[string]interface{}), []string{"fromAccount", "toAccount"})
+	request := map[string]interface{}{
+		"fromAccountType": self.subAccountTypeId(fromAccount),
+		"toAccountType":   self.subAccountTypeId(toAccount),
+		"asset":           code,
+		"amount":          self.Float64ToString(amount),
+	}
+	if fromSubAccount != "" {
+		request["fromEmail"] = fromSubAccount
+	}
+	if toSubAccount != "" {
+		request["toEmail"] = toSubAccount
+	}
+	response := self.ApiFunc("sapiPostSubAccountUniversalTransfer", self.Extend(request, query), nil, nil)
+	result = &Transfer{
+		Id:             fmt.Sprint(self.SafeInteger(response, "tranId", 0)),
+		Currency:       code,
+		Amount:         amount,
+		FromAccount:    fromAccount,
+		ToAccount:      toAccount,
+		FromSubAccount: fromSubAccount,
+		ToSubAccount:   toSubAccount,
+		Status:         "ok",
+		Timestamp:      self.Milliseconds(),
+		Info:           response,
+	}
+	return
+}
+
+// 子账户万向划转的账户名称和母账户不同
+func (self *Binance) subAccountTypeId(account string) string {
+	typ, _ := self.AccountType(map[string]interface{}{"type": account}, "")
+	subAccountsByType := self.SafeValue(self.Options, "subAccountsByType", map[string]interface{}{})
+	id := self.SafeString(subAccountsByType, typ, "")
+	if id == "" {
+		self.RaiseException("NotSupported", self.Id+" subAccountTransfer does not support the "+typ+" account")
+	}
+	return id
+}
+
+func (self *Binance) FetchTradingFee(symbol string, params map[string]interface{}) (result *TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	response := self.ApiFuncReturnList("sapiGetAssetTradeFee", self.Extend(request, params), nil, nil)
+	if len(response) == 0 {
+		self.RaiseException("BadSymbol", self.Id+" fetchTradingFee no fee for "+symbol)
+	}
+	tier, discount := self.tradingFeeTier()
+	return self.parseTradingFee(response[0], tier, discount), nil
+}
+
+// FetchTradingFees 只返回已上线的交易对
+func (self *Binance) FetchTradingFees(params map[string]interface{}) (result map[string]*TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	response := self.ApiFuncReturnList("sapiGetAssetTradeFee", params, nil, nil)
+	tier, discount := self.tradingFeeTier()
+	result = map[string]*TradingFee{}
+	for _, item := range response {
+		if _, ok := self.MarketsById[self.SafeString(item, "symbol", "")]; !ok {
+			continue
+		}
+		fee := self.parseTradingFee(item, tier, discount)
+		result[fee.Symbol] = fee
+	}
+	return
+}
+
+// tradingFeeTier 返回 VIP 等级和 BNB 抵扣比例, 未开启 BNB 抵扣时比例为 0
+func (self *Binance) tradingFeeTier() (string, float64) {
+	info := self.ApiFunc("sapiGetAccountInfo", nil, nil, nil)
+	tier := fmt.Sprint(self.SafeInteger(info, "vipLevel", 0))
+	burn := self.ApiFunc("sapiGetBnbBurn", nil, nil, nil)
+	if self.ToBool(self.SafeValue(burn, "spotBNBBurn", false)) {
+		return tier, self.SafeFloat(self.Options, "bnbDiscount", 0.75)
+	}
+	return tier, 0
+}
+
+func (self *Binance) parseTradingFee(item interface{}, tier string, discount float64) *TradingFee {
+	fee := &TradingFee{
+		Symbol: self.MarketsById[self.SafeString(item, "symbol", "")].Symbol,
+		Maker:  self.SafeFloat(item, "makerCommission", 0),
+		Taker:  self.SafeFloat(item, "takerCommission", 0),
+		Tier:   tier,
+		Info:   item,
+	}
+	if discount > 0 {
+		fee.DiscountCurrency = "BNB"
+		fee.Discount = discount
+	}
+	return fee
+}
+
+// FetchCurrencies 需要 apiKey, 币种精度取默认链的提现精度
+func (self *Binance) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFuncReturnList("sapiGetCapitalConfigGetall", params, nil, nil)
+	result = map[string]*Currency{}
+	for _, entry := range response {
+		id := self.SafeString(entry, "coin", "")
+		code := self.SafeCurrencyCode(id)
+		currency := &Currency{
+			Id:   id,
+			Code: code,
+			Name: self.SafeString(entry, "name", ""),
+			Info: entry,
+		}
+		networkList, _ := self.SafeValue(entry, "networkList", nil).([]interface{})
+		for _, item := range networkList {
+			network := &CurrencyNetwork{
+				Id:            self.SafeString(item, "network", ""),
+				Name:          self.SafeString(item, "name", ""),
+				Deposit:       self.ToBool(self.SafeValue(item, "depositEnable", false)),
+				Withdraw:      self.ToBool(self.SafeValue(item, "withdrawEnable", false)),
+				Fee:           self.SafeFloat(item, "withdrawFee", 0),
+				FeeKnown:      self.SafeValue(item, "withdrawFee", nil) != nil,
+				WithdrawMin:   self.SafeFloat(item, "withdrawMin", 0),
+				WithdrawMax:   self.SafeFloat(item, "withdrawMax", 0),
+				Confirmations: self.SafeInteger(item, "minConfirm", 0),
+				Precision:     self.PrecisionFromString(self.SafeString(item, "withdrawIntegerMultiple", "")),
+				Info:          item,
+			}
+			if self.ToBool(self.SafeValue(item, "isDefault", false)) {
+				currency.Precision = network.Precision
+			}
+			self.AddCurrencyNetwork(currency, network)
+		}
+		result[code] = currency
+	}
+	return result, nil
+}
+
+// FetchStatus 系统维护时 status 为 1. fetchStatusDetails 为 true 且配置了 apiKey 时同时返回暂停充提的币种
+func (self *Binance) FetchStatus(params map[string]interface{}) (result *ExchangeStatus, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFunc("sapiGetSystemStatus", params, nil, nil)
+	result = &ExchangeStatus{
+		Status:  StatusOk,
+		Updated: self.Milliseconds(),
+		Message: self.SafeString(response, "msg", ""),
+		Info:    response,
+	}
+	if self.SafeInteger(response, "status", 0) != 0 {
+		result.Status = StatusMaintenance
+		return result, nil
+	}
+	err = self.FetchSuspensions(result, self.ApiKey != "")
+	return result, err
+}
+
+func (self *Binance) FetchDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"coin": code,
+	}
+	if network != "" {
+		request["network"] = self.NetworkId(network)
+	}
+	response := self.ApiFunc("sapiGetCapitalDepositAddress", self.Extend(request, params), nil, nil)
+	result = &DepositAddress{
+		Currency: code,
+		Address:  self.SafeString(response, "address", ""),
+		Tag:      self.SafeString(response, "tag", ""),
+		Network:  self.NetworkCode(self.NetworkId(network)),
+		Status:   "ok",
+		Info:     response,
+	}
+	return
+}
+
+// CreateDepositAddress binance 在获取充值地址时自动生成
+func (self *Binance) CreateDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	return self.FetchDepositAddress(code, network, params)
+}
+
+func (self *Binance) Withdraw(code string, amount float64, address string, tag string, network string, params map[string]interface{}) (result *Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"coin":    code,
+		"address": address,
+		"amount":  self.Float64ToString(amount),
+	}
+	if tag != "" {
+		request["addressTag"] = tag
+	}
+	if network != "" {
+		request["network"] = self.NetworkId(network)
+	}
+	response := self.ApiFunc("sapiPostCapitalWithdrawApply", self.Extend(request, params), nil, nil)
+	result = &Transaction{
+		Id:        self.SafeString(response, "id", ""),
+		Type:      "withdrawal",
+		Currency:  code,
+		Network:   self.NetworkCode(self.NetworkId(network)),
+		Amount:    amount,
+		Address:   address,
+		Tag:       tag,
+		Status:    "pending",
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+	return
+}
+
+func (self *Binance) FetchDeposits(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("sapiGetCapitalDepositHisrec", "deposit", code, since, limit, params), nil
+}
+
+func (self *Binance) FetchWithdrawals(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("sapiGetCapitalWithdrawHistory", "withdrawal", code, since, limit, params), nil
+}
+
+func (self *Binance) fetchTransactions(method string, typ string, code string, since int64, limit int64, params map[string]interface{}) []*Transaction {
+	request := map[string]interface{}{}
+	if code != "" {
+		request["coin"] = code
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList(method, self.Extend(request, params), nil, nil)
+	result := []*Transaction{}
+	for _, item := range response {
+		result = append(result, self.parseTransaction(item, typ))
+	}
+	return result
+}
+
+func (self *Binance) parseTransactionStatus(typ string, status string) string {
+	statuses := map[string]interface{}{
+		"0": "pending",
+		"1": "ok",
+		"6": "ok",
+		"7": "failed",
+	}
+	if typ == "withdrawal" {
+		statuses = map[string]interface{}{
+			"0": "pending",
+			"1": "canceled",
+			"2": "pending",
+			"3": "failed",
+			"4": "pending",
+			"5": "failed",
+			"6": "ok",
+		}
+	}
+	return self.SafeString(statuses, status, status)
+}
+
+// 充值记录的时间为毫秒, 提现记录的时间为 UTC 字符串
+func (self *Binance) parseTransaction(item interface{}, typ string) *Transaction {
+	result := &Transaction{
+		Id:       self.SafeString(item, "id", ""),
+		Txid:     self.SafeString(item, "txId", ""),
+		Type:     typ,
+		Currency: self.SafeCurrencyCode(self.SafeString(item, "coin", "")),
+		Network:  self.NetworkCode(self.SafeString(item, "network", "")),
+		Amount:   self.SafeFloat(item, "amount", 0),
+		Fee:      self.SafeFloat(item, "transactionFee", 0),
+		Address:  self.SafeString(item, "address", ""),
+		Tag:      self.SafeString(item, "addressTag", ""),
+		Status:   self.parseTransactionStatus(typ, self.SafeString(item, "status", "")),
+		Info:     item,
+	}
+	if typ == "deposit" {
+		result.Timestamp = self.SafeInteger(item, "insertTime", 0)
+		result.Updated = self.SafeInteger(item, "completeTime", 0)
+	} else {
+		result.Timestamp = self.ParseDate(self.SafeString(item, "applyTime", ""))
+		result.Updated = self.ParseDate(self.SafeString(item, "completeTime", ""))
+	}
+	return result
+}
+
 func (self *Binance) ParseTicker(response interface{}) (ticker *Ticker) {
 	timestamp := self.SafeInteger(response, "closeTime")
 	datetime := self.Iso8601(timestamp)
@@ -563,6 +1203,28 @@ func (self *Binance) FetchTicker(symbol string, params map[string]interface{}) (
 	return ticker, nil
 }
 
+func (self *Binance) FetchTickers(symbols []string, params map[string]interface{}) (tickers []*Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	method := self.SafeString(self.Options, "fetchTickersMethod", "publicGetTicker24hr")
+	response := self.ApiFuncReturnList(method, params, nil, nil)
+	tickers = []*Ticker{}
+	for _, item := range response {
+		market, ok := self.MarketsById[self.SafeString(item, "symbol", "")]
+		if !ok {
+			continue
+		}
+		ticker := self.ParseTicker(item)
+		ticker.Symbol = market.Symbol
+		tickers = append(tickers, ticker)
+	}
+	return self.FilterTickers(tickers, symbols), nil
+}
+
 func (self *Binance) ParseOHLCV(response interface{}) *OHLCV {
 	data := response.([]interface{})
 	return &OHLCV{
@@ -618,7 +1280,7 @@ func (self *Binance) FetchOrderBook(symbol string, limit int64, params map[strin
 	method := self.IfThenElse(self.ToBool(self.Member(market, "spot")), "publicGetDepth", "fapiPublicGetDepth").(string)
 	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
 	orderbook := self.ParseOrderBook(response, 0, "bids", "asks", 0, 1)
-	self.SetValue(orderbook, "nonce", self.SafeInteger(response, "lastUpdateId", 0))
+	orderbook.Nonce = self.SafeInteger(response, "lastUpdateId", 0)
 	return orderbook, nil
 }
 
@@ -741,6 +1403,7 @@ func (self *Binance) ParseOrder(order interface{}, market interface{}) (result m
 		"status":             status,
 		"fee":                fee,
 		"trades":             trades,
+		"stopPrice":          self.SafeFloat(order, "stopPrice", 0),
 	}
 }
 
@@ -752,11 +1415,85 @@ func (self *Binance) CreateOrder(symbol string, typ string, side string, amount
 	}()
 	self.LoadMarkets()
 	market := self.Market(symbol)
+	method, request := self.createOrderRequest(market, typ, side, amount, price, params)
+	response := self.ApiFunc(method, request, nil, nil)
+	result = self.ToOrder(self.ParseOrder(response, market))
+	trigger, _ := self.ParseTriggerOrder(params)
+	if trigger != nil && (trigger.StopLoss != nil || trigger.TakeProfit != nil) {
+		// NOTE: 只有合约支持附带止损止盈, createOrderRequest 中已经检查过
+		return self.CreateAttachedOrders(result, trigger, symbol, side, amount, map[string]interface{}{"type": "future"})
+	}
+	return result, nil
+}
+
+// 把统一的条件单参数转换为币安的订单类型, 触发价和跟踪参数写入 params. typ 只能为 limit 或 market
+func (self *Binance) triggerOrderType(symbol string, orderType string, typ string, trigger *TriggerOrder, params map[string]interface{}) string {
+	isLimit := strings.ToLower(typ) == "limit"
+	if self.ToBool(orderType == "future") {
+		if trigger.TrailingPercent > 0 {
+			// NOTE: callbackRate 的单位为百分比, 精度为 0.1
+			callbackRate, _ := DecimalToPrecision(trigger.TrailingPercent, Round, 1, DecimalPlaces, NoPadding)
+			self.SetValue(params, "callbackRate", callbackRate)
+			if trigger.TriggerPrice() > 0 {
+				self.SetValue(params, "activationPrice", self.PriceToPrecision(symbol, trigger.TriggerPrice()))
+			}
+			return "TRAILING_STOP_MARKET"
+		}
+		self.SetValue(params, "stopPrice", self.PriceToPrecision(symbol, trigger.TriggerPrice()))
+		if trigger.IsTakeProfit() {
+			if isLimit {
+				return "TAKE_PROFIT"
+			}
+			return "TAKE_PROFIT_MARKET"
+		}
+		if isLimit {
+			return "STOP"
+		}
+		return "STOP_MARKET"
+	}
+	if trigger.TrailingPercent > 0 {
+		// NOTE: trailingDelta 的单位为 BIPS, 1% 为 100
+		self.SetValue(params, "trailingDelta", int64(math.Round(trigger.TrailingPercent*100)))
+	}
+	if trigger.TriggerPrice() > 0 {
+		self.SetValue(params, "stopPrice", self.PriceToPrecision(symbol, trigger.TriggerPrice()))
+	}
+	if trigger.IsTakeProfit() {
+		if isLimit {
+			return "TAKE_PROFIT_LIMIT"
+		}
+		return "TAKE_PROFIT"
+	}
+	if isLimit {
+		return "STOP_LOSS_LIMIT"
+	}
+	return "STOP_LOSS"
+}
+
+// 返回下单使用的接口和完整的请求参数, CreateOrder 和 CreateOrders 共用
+func (self *Binance) createOrderRequest(market *Market, typ string, side string, amount float64, price float64, params map[string]interface{}) (method string, request map[string]interface{}) {
+	symbol := market.Symbol
 	defaultType := self.SafeString2(self.Options, "createOrder", "defaultType", market.Type)
 	orderType := self.SafeString(params, "type", defaultType)
-	clientOrderId := self.SafeString2(params, "newClientOrderId", "clientOrderId", "")
-	params = self.Omit(params, []interface{}{"type", "newClientOrderId", "clientOrderId"})
-	method := "privatePostOrder"
+	clientOrderId, params := self.ParseClientOrderId(params, "newClientOrderId")
+	params = self.Omit(params, "type")
+	trigger, query := self.ParseTriggerOrder(params)
+	if trigger != nil {
+		if self.ToBool(orderType != "future" && (trigger.StopLoss != nil || trigger.TakeProfit != nil)) {
+			self.RaiseException("NotSupported", self.Id+" createOrder attached stopLoss/takeProfit is supported for future orders only")
+		}
+		lowerType := strings.ToLower(typ)
+		if lowerType == "limit" || lowerType == "market" {
+			params = query
+			if !trigger.IsAttached() {
+				typ = self.triggerOrderType(symbol, orderType, typ, trigger, params)
+			}
+		} else {
+			// NOTE: 原生的订单类型(如 STOP_LOSS_LIMIT)原样下单, 保留调用者的 stopPrice 等参数
+			params = self.Omit(params, []string{"stopLoss", "takeProfit"})
+		}
+	}
+	method = "privatePostOrder"
 	if self.ToBool(orderType == "future") {
 		method = "fapiPrivatePostOrder"
 	} else if self.ToBool(orderType == "margin") {
@@ -770,13 +1507,11 @@ func (self *Binance) CreateOrder(symbol string, typ string, side string, amount
 		params = self.Omit(params, "test")
 	}
 	uppercaseType := strings.ToUpper(typ)
-	request := map[string]interface{}{
-		"symbol": self.Member(market, "id"),
-		"type":   uppercaseType,
-		"side":   strings.ToUpper(side),
-	}
-	if self.ToBool(!self.TestNil(clientOrderId)) {
-		self.SetValue(request, "newClientOrderId", clientOrderId)
+	request = map[string]interface{}{
+		"symbol":           self.Member(market, "id"),
+		"type":             uppercaseType,
+		"side":             strings.ToUpper(side),
+		"newClientOrderId": clientOrderId,
 	}
 	if self.ToBool(self.Member(market, "spot")) {
 		self.SetValue(request, "newOrderRespType", self.SafeValue(self.Member(self.Options, "newOrderRespType"), typ, "RESULT"))
@@ -831,6 +1566,12 @@ func (self *Binance) CreateOrder(symbol string, typ string, side string, amount
 			quantityIsRequired = true
 		}
 		stopPriceIsRequired = true
+	} else if self.ToBool(uppercaseType == "TRAILING_STOP_MARKET") {
+		quantityIsRequired = true
+	}
+	// NOTE: 现货的跟踪止损可以只有 trailingDelta 而没有 stopPrice
+	if self.ToBool(stopPriceIsRequired && !self.TestNil(self.SafeValue(params, "trailingDelta", nil)) && self.SafeFloat(params, "stopPrice", 0) <= 0) {
+		stopPriceIsRequired = false
 	}
 	if self.ToBool(quantityIsRequired) {
 		self.SetValue(request, "quantity", self.AmountToPrecision(symbol, amount))
@@ -853,8 +1594,149 @@ func (self *Binance) CreateOrder(symbol string, typ string, side string, amount
 			self.SetValue(request, "stopPrice", self.PriceToPrecision(symbol, stopPrice))
 		}
 	}
-	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
-	return self.ToOrder(self.ParseOrder(response, market)), nil
+	return method, self.Extend(request, params).(map[string]interface{})
+}
+
+// 现货使用 cancelReplace 接口, 合约使用修改订单接口 (只支持限价单), 杠杆先撤单再下单.
+// params["origClientOrderId"] 不为空时按 clientOrderId 改单
+func (self *Binance) EditOrder(id string, symbol string, typ string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if self.ToBool(self.TestNil(symbol)) {
+		self.RaiseException("ArgumentsRequired", self.Id+" editOrder requires a symbol argument")
+	}
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	defaultType := self.SafeString2(self.Options, "editOrder", "defaultType", market.Type)
+	orderType := self.SafeString(params, "type", defaultType)
+	if self.ToBool(orderType == "margin") {
+		return self.Exchange.EditOrder(id, symbol, typ, side, amount, price, params)
+	}
+	origClientOrderId := self.SafeString(params, "origClientOrderId", "")
+	query := self.Extend(params, map[string]interface{}{"type": orderType}).(map[string]interface{})
+	delete(query, "origClientOrderId")
+	if self.ToBool(orderType == "future") {
+		request := map[string]interface{}{
+			"symbol":   market.Id,
+			"side":     strings.ToUpper(side),
+			"quantity": self.AmountToPrecision(symbol, amount),
+			"price":    self.PriceToPrecision(symbol, price),
+		}
+		if self.ToBool(self.TestNil(origClientOrderId)) {
+			self.SetValue(request, "orderId", ToInteger(id))
+		} else {
+			self.SetValue(request, "origClientOrderId", origClientOrderId)
+		}
+		response := self.ApiFunc("fapiPrivatePutOrder", self.Extend(request, self.Omit(query, "type")), nil, nil)
+		return self.ToOrder(self.ParseOrder(response, market)), nil
+	}
+	_, request := self.createOrderRequest(market, typ, side, amount, price, query)
+	self.SetValue(request, "cancelReplaceMode", "STOP_ON_FAILURE")
+	if self.ToBool(self.TestNil(origClientOrderId)) {
+		self.SetValue(request, "cancelOrderId", ToInteger(id))
+	} else {
+		self.SetValue(request, "cancelOrigClientOrderId", origClientOrderId)
+	}
+	response, err := self.cancelReplace(request)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: -2021 表示撤单成功但是下单失败 (STOP_ON_FAILURE 模式下), 撤单结果和下单错误在 data 中
+	if self.SafeString(response, "code") == "-2021" {
+		data := self.SafeValue(response, "data", map[string]interface{}{})
+		newOrderResponse := self.SafeValue(data, "newOrderResponse", map[string]interface{}{})
+		code := self.SafeString(newOrderResponse, "code")
+		return nil, &EditOrderError{
+			Id:            id,
+			ClientOrderId: self.SafeString(request, "newClientOrderId"),
+			Canceled:      self.ToOrder(self.ParseOrder(self.SafeValue(data, "cancelResponse", map[string]interface{}{}), market)),
+			Err:           self.ExactlyMatchedError(self.SafeValue(self.Exceptions, "exact", nil), code, self.Id+" "+self.Json(newOrderResponse)),
+		}
+	}
+	newOrderResponse := self.SafeValue(response, "newOrderResponse", map[string]interface{}{})
+	return self.ToOrder(self.ParseOrder(newOrderResponse, market)), nil
+}
+
+// cancelReplaceFailure 撤单成功但是下单失败 (code 为 -2021) 时 HandleErrors 抛出, 携带原始响应
+type cancelReplaceFailure struct {
+	response map[string]interface{}
+}
+
+// 撤单成功但是下单失败时返回原始响应而不是错误
+func (self *Binance) cancelReplace(request map[string]interface{}) (response map[string]interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			if failure, ok := e.(*cancelReplaceFailure); ok {
+				response = failure.response
+				return
+			}
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.ApiFunc("privatePostOrderCancelReplace", request, nil, nil), nil
+}
+
+// 只有合约支持批量下单 (每次最多 5 个), 现货和杠杆并发调用 CreateOrder
+func (self *Binance) CreateOrders(orders []*OrderRequest, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if len(orders) == 0 {
+		return []*OrderResult{}, nil
+	}
+	self.LoadMarkets()
+	defaultType := self.SafeString2(self.Options, "createOrders", "defaultType", self.Market(orders[0].Symbol).Type)
+	typ := self.SafeString(params, "type", defaultType)
+	if self.ToBool(typ != "future") {
+		return self.Exchange.CreateOrders(orders, params)
+	}
+	query := self.Extend(params, map[string]interface{}{"type": "future"}).(map[string]interface{})
+	batchSize := int(self.SafeInteger(self.Options, "createOrdersBatchSize", 5))
+	result = self.CreateOrdersInBatches(orders, batchSize, func(order *OrderRequest) interface{} {
+		market := self.Market(order.Symbol)
+		orderParams := self.Extend(query, order.Params).(map[string]interface{})
+		if trigger, _ := self.ParseTriggerOrder(orderParams); trigger != nil && (trigger.StopLoss != nil || trigger.TakeProfit != nil) {
+			self.RaiseException("NotSupported", self.Id+" createOrders does not support attached stopLoss/takeProfit")
+		}
+		_, request := self.createOrderRequest(market, order.Type, order.Side, order.Amount, order.Price, orderParams)
+		// NOTE: batchOrders 中的值都要求为字符串, 浮点数不能用科学计数法
+		for k, v := range request {
+			if f, ok := v.(float64); ok {
+				request[k] = self.Float64ToString(f)
+			} else {
+				request[k] = fmt.Sprintf("%v", v)
+			}
+		}
+		return request
+	}, func(requests []interface{}) []*OrderResult {
+		request := map[string]interface{}{
+			"batchOrders": self.Json(requests),
+		}
+		response := self.ApiFuncReturnList("fapiPrivatePostBatchOrders", request, nil, nil)
+		batch := []*OrderResult{}
+		for _, item := range response {
+			code := self.SafeString(item, "code", "")
+			if self.ToBool(!self.TestNil(code) && code != "200") {
+				batch = append(batch, &OrderResult{
+					Error: self.ExactlyMatchedError(self.Exceptions, code, self.Id+" "+self.Json(item)),
+				})
+				continue
+			}
+			order := self.ToOrder(self.ParseOrder(item, nil))
+			batch = append(batch, &OrderResult{
+				Id:            order.Id,
+				ClientOrderId: order.ClientOrderId,
+				Order:         order,
+			})
+		}
+		return batch
+	})
+	return result, nil
 }
 
 func (self *Binance) FetchOrder(id string, symbol string, params map[string]interface{}) (result *Order, err error) {
@@ -885,7 +1767,7 @@ func (self *Binance) FetchOrder(id string, symbol string, params map[string]inte
 	} else {
 		self.SetValue(request, "orderId", ToInteger(id))
 	}
-	query := self.Omit(params, []interface{}{"type", "clientOrderId", "origClientOrderId"})
+	query := self.Omit(self.Extend(params).(map[string]interface{}), []string{"type", "clientOrderId", "origClientOrderId"})
 	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
 	return self.ToOrder(self.ParseOrder(response, market)), nil
 }
@@ -917,6 +1799,8 @@ func (self *Binance) FetchOpenOrders(symbol string, since int64, limit int64, pa
 		typ = self.SafeString(params, "type", defaultType)
 		query = self.Omit(params, "type")
 	}
+	// NOTE: 币安的条件单和普通订单在同一个接口查询, stop 为 true 时只返回条件单
+	stop, query := self.IsStopOrderRequest(self.Extend(query).(map[string]interface{}))
 	method := "privateGetOpenOrders"
 	if self.ToBool(typ == "future") {
 		method = "fapiPrivateGetOpenOrders"
@@ -924,7 +1808,17 @@ func (self *Binance) FetchOpenOrders(symbol string, since int64, limit int64, pa
 		method = "sapiGetMarginOpenOrders"
 	}
 	response := self.ApiFuncReturnList(method, self.Extend(request, query), nil, nil)
-	return self.ToOrders(self.ParseOrders(response, market, since, limit)), nil
+	result = self.ToOrders(self.ParseOrders(response, market, since, limit))
+	if stop {
+		orders := []*Order{}
+		for _, order := range result {
+			if order.StopPrice > 0 || strings.HasPrefix(order.Type, "trailing") {
+				orders = append(orders, order)
+			}
+		}
+		result = orders
+	}
+	return result, nil
 }
 
 func (self *Binance) CancelOrder(id string, symbol string, params map[string]interface{}) (response interface{}, err error) {
@@ -955,11 +1849,114 @@ func (self *Binance) CancelOrder(id string, symbol string, params map[string]int
 	} else if self.ToBool(typ == "margin") {
 		method = "sapiDeleteMarginOrder"
 	}
-	query := self.Omit(params, []interface{}{"type", "origClientOrderId", "clientOrderId"})
+	_, query := self.IsStopOrderRequest(params)
+	query = self.Omit(query, []string{"type", "origClientOrderId", "clientOrderId"})
 	response = self.ApiFunc(method, self.Extend(request, query), nil, nil)
 	return self.ToOrder(self.ParseOrder(response, market)), nil
 }
 
+func (self *Binance) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if self.ToBool(self.TestNil(symbol)) {
+		self.RaiseException("ArgumentsRequired", self.Id+" cancelAllOrders requires a symbol argument")
+	}
+	// NOTE: 撤销全部挂单的接口会同时撤销普通订单, 只撤条件单时逐个撤销
+	if stop, _ := self.IsStopOrderRequest(params); stop {
+		return self.Exchange.CancelAllOrders(symbol, params)
+	}
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	defaultType := self.SafeString2(self.Options, "cancelAllOrders", "defaultType", market.Type)
+	typ := self.SafeString(params, "type", defaultType)
+	query := self.Omit(params, "type")
+	request := map[string]interface{}{
+		"symbol": self.Member(market, "id"),
+	}
+	if self.ToBool(typ == "future") {
+		// NOTE: 合约的撤销全部挂单接口只返回 {"code": 200, "msg": "..."}, 没有订单明细, 先查询挂单作为撤销结果
+		orders, err := self.FetchOpenOrders(symbol, 0, 0, self.Extend(query, map[string]interface{}{"type": typ}).(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		self.ApiFunc("fapiPrivateDeleteAllOpenOrders", self.Extend(request, query), nil, nil)
+		return self.CanceledOrderResults(orders), nil
+	}
+	result = []*OrderResult{}
+	method := "privateDeleteOpenOrders"
+	if self.ToBool(typ == "margin") {
+		method = "sapiDeleteMarginOpenOrders"
+	}
+	response := self.ApiFuncReturnList(method, self.Extend(request, query), nil, nil)
+	for _, order := range self.ToOrders(self.ParseOrders(response, market, 0, 0)) {
+		result = append(result, &OrderResult{
+			Id:            order.Id,
+			ClientOrderId: order.ClientOrderId,
+			Order:         order,
+		})
+	}
+	return result, nil
+}
+
+// 只有合约支持批量撤单 (每次最多 10 个), 现货和杠杆逐个撤单
+func (self *Binance) CancelOrders(ids []string, symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if self.ToBool(self.TestNil(symbol)) {
+		self.RaiseException("ArgumentsRequired", self.Id+" cancelOrders requires a symbol argument")
+	}
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	defaultType := self.SafeString2(self.Options, "cancelOrders", "defaultType", market.Type)
+	typ := self.SafeString(params, "type", defaultType)
+	if self.ToBool(typ != "future") {
+		return self.Exchange.CancelOrders(ids, symbol, params)
+	}
+	query := self.Omit(params, "type")
+	batchSize := int(self.SafeInteger(self.Options, "cancelOrdersBatchSize", 10))
+	result = []*OrderResult{}
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		orderIdList := []interface{}{}
+		for _, id := range ids[start:end] {
+			orderIdList = append(orderIdList, ToInteger(id))
+		}
+		request := map[string]interface{}{
+			"symbol":      self.Member(market, "id"),
+			"orderIdList": self.Json(orderIdList),
+		}
+		response := self.ApiFuncReturnList("fapiPrivateDeleteBatchOrders", self.Extend(request, query), nil, nil)
+		for i, item := range response {
+			// NOTE: 失败的订单没有 orderId, 按请求顺序对应, 返回的数量不一致时不越界
+			id := self.SafeString(item, "orderId", "")
+			if id == "" && start+i < end {
+				id = ids[start+i]
+			}
+			one := &OrderResult{
+				Id: id,
+			}
+			code := self.SafeString(item, "code", "")
+			if self.ToBool(!self.TestNil(code) && code != "200") {
+				one.Error = self.ExactlyMatchedError(self.Exceptions, code, self.Id+" "+self.Json(item))
+			} else {
+				one.Order = self.ToOrder(self.ParseOrder(item, market))
+				one.ClientOrderId = one.Order.ClientOrderId
+			}
+			result = append(result, one)
+		}
+	}
+	return result, nil
+}
+
 func (self *Binance) FetchTrades(symbol string, since int64, limit int64, params map[string]interface{}) (trades []*Trade, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -1032,7 +2029,7 @@ func (self *Binance) Sign(path string, api string, method string, params map[str
 			self.RaiseException("AuthenticationError", self.Id+" userDataStream endpoint requires `apiKey` credential")
 		}
 	}
-	if self.ToBool(api == "private" || api == "sapi" || api == "wapi" && path != "systemStatus" || api == "fapiPrivate") {
+	if self.ToBool(api == "private" || api == "sapi" && path != "system/status" || api == "wapi" && path != "systemStatus" || api == "fapiPrivate") {
 		self.CheckRequiredCredentials()
 		var query string
 		if self.ToBool(api == "sapi" && path == "asset/dust") {
@@ -1083,6 +2080,10 @@ func (self *Binance) HandleErrors(httpCode int64, reason string, url string, met
 	if self.ToBool(httpCode == 418 || httpCode == 429) {
 		self.RaiseException("DDoSProtection", self.Id+" "+fmt.Sprintf("%v", httpCode)+" "+reason+" "+body)
 	}
+	if strings.Contains(url, "order/cancelReplace") && self.SafeString(response, "code") == "-2021" {
+		// NOTE: 撤单成功但是下单失败, 交给 cancelReplace 处理
+		panic(&cancelReplaceFailure{response.(map[string]interface{})})
+	}
 	if self.ToBool(httpCode >= 400) {
 		if strings.Contains(body, "Price * QTY is zero or less") {
 			self.RaiseException("InvalidOrder", self.Id+" order cost = amount * price is zero or less "+body)
@@ -1107,11 +2108,11 @@ func (self *Binance) HandleErrors(httpCode int64, reason string, url string, met
 			}
 		}
 	}
+	errorStr := self.SafeString(response, "code", "")
 	message := self.SafeString(response, "msg", "")
 	if self.ToBool(!self.TestNil(message)) {
 		self.ThrowExactlyMatchedException(self.Exceptions, message, self.Id+" "+message)
 	}
-	errorStr := self.SafeString(response, "code", "")
 	if errorStr != "" {
 		if self.ToBool(errorStr == "200") {
 			return
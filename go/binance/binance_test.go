@@ -61,12 +61,25 @@ func TestAll(t *testing.T) {
 	testFetchTrades(t)
 	testFetchOrderBook(t)
 	//testFetchTicker(t)
+	//testFetchTickers(t)
+	//testFetchOrderBooks(t)
 	//testFetchOHLCV(t)
+	//testOHLCVRange(t)
 	//testFetchBalance(t)
+	//testFetchBalances(t)
+	//testTransfer(t)
+	//testFetchTradingFees(t)
+	//testFetchCurrencies(t)
+	//testFetchStatus(t)
+	//testTransactions(t)
 	//order := testCreateOrder(t); _ = order
+	//stopOrder := testCreateStopOrder(t); _ = stopOrder
 	//testFetchOrder(t, "11555864984")
 	//testFetchOpenOrders(t)
+	//testEditOrder(t, "11555864984")
 	//testCancelOrder(t, "11555864984")
+	//testCancelOrders(t, []string{"11555864984"})
+	//testCancelAllOrders(t)
 }
 
 func testFetchOrderBook(t *testing.T) {
@@ -100,6 +113,25 @@ func testFetchTicker(t *testing.T) {
 	log.Println("##### FetchTicker:", symbol, ex.JsonIndent(ticker))
 }
 
+func testFetchTickers(t *testing.T) {
+	tickers, err := ex.FetchTickers([]string{symbol}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTickers:", ex.JsonIndent(tickers))
+}
+
+func testFetchOrderBooks(t *testing.T) {
+	results := ex.FetchOrderBooks([]string{symbol, "ETH/USDT", "NONE/USDT"}, 5, nil)
+	for _, result := range results {
+		if result.Error != nil {
+			log.Println("##### FetchOrderBooks:", result.Symbol, result.Error)
+			continue
+		}
+		log.Println("##### FetchOrderBooks:", result.Symbol, ex.JsonIndent(result.OrderBook))
+	}
+}
+
 func testFetchOHLCV(t *testing.T) {
 	klines, err := ex.FetchOHLCV(symbol, "1h", 0, 10, nil)
 	if err != nil {
@@ -109,6 +141,16 @@ func testFetchOHLCV(t *testing.T) {
 	log.Println("count:", len(klines))
 }
 
+func testOHLCVRange(t *testing.T) {
+	since := ex.Milliseconds() - 3*24*3600*1000
+	klines, err := ex.OHLCVRange(symbol, "1m", since, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### OHLCVRange:", symbol, ex.JsonIndent(klines[len(klines)-1]))
+	log.Println("count:", len(klines))
+}
+
 func testFetchBalance(t *testing.T) {
 	// @ FetchBalance
 	balance, err := ex.FetchBalance(nil)
@@ -118,6 +160,92 @@ func testFetchBalance(t *testing.T) {
 	log.Println("##### FetchBalance:", ex.JsonIndent(balance))
 }
 
+func testFetchBalances(t *testing.T) {
+	// @ FetchBalances
+	balances, err := ex.FetchBalances(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchBalances:", ex.JsonIndent(balances))
+}
+
+func testTransfer(t *testing.T) {
+	// @ Transfer
+	transfer, err := ex.Transfer("USDT", 1, "spot", "swap", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### Transfer:", ex.JsonIndent(transfer))
+	// @ FetchTransfers
+	transfers, err := ex.FetchTransfers("USDT", 0, 10, map[string]interface{}{"fromAccount": "spot", "toAccount": "swap"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTransfers:", ex.JsonIndent(transfers))
+}
+
+func testFetchTradingFees(t *testing.T) {
+	// @ FetchTradingFee
+	fee, err := ex.FetchTradingFee(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTradingFee:", ex.JsonIndent(fee))
+	// @ LoadTradingFees
+	fees, err := ex.LoadTradingFees(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### LoadTradingFees:", len(fees))
+	// @ CalculateFee
+	estimate, err := ex.CalculateFee(symbol, "limit", "sell", 1, 100, "maker", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CalculateFee:", ex.JsonIndent(estimate))
+}
+
+func testFetchCurrencies(t *testing.T) {
+	// @ FetchCurrencies
+	currencies, err := ex.FetchCurrencies(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchCurrencies:", ex.JsonIndent(currencies["USDT"]))
+	log.Println("##### CheapestNetwork:", ex.JsonIndent(currencies["USDT"].CheapestNetwork()))
+}
+
+func testFetchStatus(t *testing.T) {
+	// @ FetchStatus
+	status, err := ex.FetchStatus(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchStatus:", ex.JsonIndent(status))
+	log.Println("##### NextMaintenance:", ex.JsonIndent(status.NextMaintenance(ex.Milliseconds())))
+}
+
+func testTransactions(t *testing.T) {
+	// @ FetchDepositAddress
+	address, err := ex.FetchDepositAddress("USDT", "TRC20", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchDepositAddress:", ex.JsonIndent(address))
+	// @ FetchDeposits
+	deposits, err := ex.FetchDeposits("USDT", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchDeposits:", ex.JsonIndent(deposits))
+	// @ FetchWithdrawals
+	withdrawals, err := ex.FetchWithdrawals("USDT", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchWithdrawals:", ex.JsonIndent(withdrawals))
+}
+
 func testCreateOrder(t *testing.T) *base.Order {
 	// @ CreateOrder
 	order, err := ex.CreateOrder(symbol, "limit", "buy", 0.001 /*amount*/, 10000 /*price*/, nil)
@@ -128,6 +256,17 @@ func testCreateOrder(t *testing.T) *base.Order {
 	return order
 }
 
+func testCreateStopOrder(t *testing.T) *base.Order {
+	// @ CreateOrder
+	params := map[string]interface{}{"stopPrice": 9000}
+	order, err := ex.CreateOrder(symbol, "limit", "sell", 0.001 /*amount*/, 8900 /*price*/, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CreateStopOrder:", symbol, ex.JsonIndent(order))
+	return order
+}
+
 func testFetchOrder(t *testing.T, orderId string) {
 	// @ FetchOrder
 	o, err := ex.FetchOrder(orderId, symbol, nil)
@@ -137,6 +276,16 @@ func testFetchOrder(t *testing.T, orderId string) {
 	log.Println("##### FetchOrder:", ex.JsonIndent(o))
 }
 
+func testEditOrder(t *testing.T, orderId string) *base.Order {
+	// @ EditOrder
+	order, err := ex.EditOrder(orderId, symbol, "limit", "buy", 0.001 /*amount*/, 10001 /*price*/, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### EditOrder:", symbol, ex.JsonIndent(order))
+	return order
+}
+
 func testFetchOpenOrders(t *testing.T) []*base.Order {
 	// @ FetchOpenOrders
 	openOrders, err := ex.FetchOpenOrders(symbol, 0, 0, nil)
@@ -155,3 +304,21 @@ func testCancelOrder(t *testing.T, orderId string) {
 	}
 	log.Println("##### CancelOrder:", resp)
 }
+
+func testCancelOrders(t *testing.T, orderIds []string) {
+	// @ CancelOrders
+	results, err := ex.CancelOrders(orderIds, symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelOrders:", ex.JsonIndent(results))
+}
+
+func testCancelAllOrders(t *testing.T) {
+	// @ CancelAllOrders
+	results, err := ex.CancelAllOrders(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelAllOrders:", ex.JsonIndent(results))
+}
@@ -50,6 +50,7 @@ func (self *Bitmax) Describe() []byte {
         "createOrder": true,
         "cancelOrder": true,
         "cancelAllOrders": true,
+        "cancelOrders": true,
         "fetchDepositAddress": true,
         "fetchTransactions": true,
         "fetchDeposits": true,
@@ -87,6 +88,7 @@ func (self *Bitmax) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "assets",
                 "products",
@@ -152,8 +154,12 @@ func (self *Bitmax) Describe() []byte {
     },
     "precisionMode": "TICK_SIZE",
     "options": {
+        "clientOrderIdReplaceSeparator": "r",
         "account-category": "cash",
         "account-group": null,
+        "accountTypes": ["spot", "margin", "swap"],
+        "fetchOHLCVLimit": 500,
+        "cancelOrdersBatchSize": 10,
         "fetchClosedOrders": {
             "method": "accountGroupGetOrderHist"
         }
@@ -247,7 +253,12 @@ func (self *Bitmax) ParseOrderStatus(status string) string {
 	return status
 }
 
-func (self *Bitmax) FetchCurrencies(params map[string]interface{}) map[string]interface{} {
+func (self *Bitmax) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
 	assets := self.ApiFunc("publicGetAssets", params, nil, nil)
 	margin := self.ApiFunc("publicGetMarginAssets", params, nil, nil)
 	cash := self.ApiFunc("publicGetCashAssets", params, nil, nil)
@@ -259,47 +270,34 @@ func (self *Bitmax) FetchCurrencies(params map[string]interface{}) map[string]in
 	cashById := self.IndexBy(cashData, "assetCode")
 	dataById := self.DeepExtend(assetsById, marginById, cashById)
 	ids := reflect.ValueOf(dataById).MapKeys()
-	result := map[string]interface{}{}
+	result = map[string]*Currency{}
 	for i := 0; i < self.Length(ids); i++ {
 		id := self.Member(ids, i)
 		currency := self.Member(dataById, id)
 		code := self.SafeCurrencyCode(id)
 		precision := self.SafeInteger2(currency, "precisionScale", "nativeScale", 0)
-		fee := self.SafeFloat2(currency, "withdrawFee", "withdrawalFee", 0.0)
 		status := self.SafeString2(currency, "status", "statusCode", "")
-		active := status == "Normal"
-		margin := self.InMap("borrowAssetCode", currency)
-		self.SetValue(result, code, map[string]interface{}{
-			"id":        id,
-			"code":      code,
-			"info":      currency,
-			"type":      nil,
-			"margin":    margin,
-			"name":      self.SafeString(currency, "assetName", ""),
-			"active":    active,
-			"fee":       fee,
-			"precision": precision,
-			"limits": map[string]interface{}{
-				"amount": map[string]interface{}{
-					"min": math.Pow10(int(-precision)),
-					"max": nil,
-				},
-				"price": map[string]interface{}{
-					"min": math.Pow10(int(-precision)),
-					"max": nil,
-				},
-				"cost": map[string]interface{}{
-					"min": nil,
-					"max": nil,
-				},
-				"withdraw": map[string]interface{}{
-					"min": self.SafeFloat(currency, "minWithdrawalAmt", 0),
-					"max": nil,
-				},
-			},
-		})
+		// NOTE: 接口不区分链, 只有一条默认链
+		network := &CurrencyNetwork{
+			Id:          self.SafeString(currency, "blockChain", ""),
+			Deposit:     status == "Normal",
+			Withdraw:    status == "Normal",
+			Fee:         self.SafeFloat2(currency, "withdrawFee", "withdrawalFee", 0.0),
+			FeeKnown:    self.SafeValue2(currency, "withdrawFee", "withdrawalFee", nil) != nil,
+			WithdrawMin: self.SafeFloat(currency, "minWithdrawalAmt", 0),
+			Precision:   int(precision),
+			Info:        currency,
+		}
+		result[code] = &Currency{
+			Id:        self.SafeString(currency, "assetCode", ""),
+			Code:      code,
+			Name:      self.SafeString(currency, "assetName", ""),
+			Precision: int(precision),
+			Info:      currency,
+		}
+		self.AddCurrencyNetwork(result[code], network)
 	}
-	return result
+	return result, nil
 }
 
 func (self *Bitmax) FetchMarkets(params map[string]interface{}) ([]*Market, error) {
@@ -398,6 +396,24 @@ func (self *Bitmax) FetchBalance(params map[string]interface{}) (balanceResult *
 	accountCategory := self.SafeString(options, "account-category", defaultAccountCategory)
 	accountCategory = self.SafeString(params, "account-category", accountCategory)
 	params = self.Omit(params, "account-category")
+	// account-category: cash 为币币账户, margin 为杠杆账户, futures 为永续合约账户
+	defaultType := AccountTypeSpot
+	if accountCategory == "margin" {
+		defaultType = AccountTypeMargin
+	} else if accountCategory == "futures" {
+		defaultType = AccountTypeSwap
+	}
+	typ, params := self.AccountType(params, defaultType)
+	switch typ {
+	case AccountTypeSpot:
+		accountCategory = "cash"
+	case AccountTypeMargin:
+		accountCategory = "margin"
+	case AccountTypeFutures, AccountTypeSwap:
+		accountCategory = "futures"
+	default:
+		self.UnsupportedAccountType(typ)
+	}
 	account := self.SafeValue(self.Accounts, 0, map[string]interface{}{})
 	accountGroup := self.SafeString(account, "id", "")
 	request := map[string]interface{}{
@@ -411,6 +427,7 @@ func (self *Bitmax) FetchBalance(params map[string]interface{}) (balanceResult *
 	}
 	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
 	result := map[string]interface{}{
+		"type": typ,
 		"info": response,
 	}
 	balances := self.SafeValue(response, "data", []interface{}{})
@@ -422,8 +439,10 @@ func (self *Bitmax) FetchBalance(params map[string]interface{}) (balanceResult *
 		total := self.SafeFloat(balance, "totalBalance", 0)
 		if accountCategory == "margin" {
 			borrowed := self.SafeFloat(balance, "borrowed", 0)
-			free -= borrowed
-			total -= borrowed
+			interest := self.SafeFloat(balance, "interest", 0)
+			account["borrowed"] = borrowed
+			account["interest"] = interest
+			account["netEquity"] = total - borrowed - interest
 		}
 		account["free"] = free
 		account["total"] = total
@@ -449,10 +468,132 @@ func (self *Bitmax) FetchOrderBook(symbol string, limit int64, params map[string
 	orderbook := self.SafeValue(data, "data", map[string]interface{}{})
 	timestamp := self.SafeInteger(orderbook, "ts", 0)
 	result := self.ParseOrderBook(orderbook, timestamp, "bids", "asks", 0, 1)
-	self.SetValue(result, "nonce", self.SafeInteger(orderbook, "seqnum", 0))
+	result.Nonce = self.SafeInteger(orderbook, "seqnum", 0)
 	return result, nil
 }
 
+func (self *Bitmax) ParseTicker(ticker interface{}) *Ticker {
+	last := self.SafeFloat(ticker, "close", 0)
+	open := self.SafeFloat(ticker, "open", 0)
+	bid := self.SafeValue(ticker, "bid", []interface{}{})
+	ask := self.SafeValue(ticker, "ask", []interface{}{})
+	result := &Ticker{
+		Symbol:     self.SafeString(ticker, "symbol", ""),
+		Last:       last,
+		Bid:        ToFloat(self.SafeValue(bid, 0, 0)),
+		BidQty:     ToFloat(self.SafeValue(bid, 1, 0)),
+		Ask:        ToFloat(self.SafeValue(ask, 0, 0)),
+		AskQty:     ToFloat(self.SafeValue(ask, 1, 0)),
+		Open:       open,
+		High:       self.SafeFloat(ticker, "high", 0),
+		Low:        self.SafeFloat(ticker, "low", 0),
+		Close:      last,
+		BaseVolume: self.SafeFloat(ticker, "volume", 0),
+		Change:     last - open,
+		Info:       ticker,
+	}
+	if open > 0 {
+		result.Percentage = result.Change / open * 100
+	}
+	return result
+}
+
+func (self *Bitmax) FetchTicker(symbol string, params map[string]interface{}) (ticker *Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": self.Member(market, "id"),
+	}
+	response := self.ApiFunc("publicGetTicker", self.Extend(request, params), nil, nil)
+	ticker = self.ParseTicker(self.SafeValue(response, "data", map[string]interface{}{}))
+	ticker.Symbol = symbol
+	return ticker, nil
+}
+
+// NOTE: 不指定 symbol 时返回所有交易对
+func (self *Bitmax) FetchTickers(symbols []string, params map[string]interface{}) (tickers []*Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{}
+	if len(symbols) > 0 {
+		ids := []string{}
+		for _, symbol := range symbols {
+			ids = append(ids, self.Market(symbol).Id)
+		}
+		request["symbol"] = strings.Join(ids, ",")
+	}
+	response := self.ApiFunc("publicGetTicker", self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data", []interface{}{})
+	// 只有一个交易对时返回的是对象
+	if _, ok := data.(map[string]interface{}); ok {
+		data = []interface{}{data}
+	}
+	tickers = []*Ticker{}
+	for _, item := range data.([]interface{}) {
+		tickers = append(tickers, self.ParseTicker(item))
+	}
+	return self.FilterTickers(tickers, symbols), nil
+}
+
+// {"m": "bar", "s": "BTC/USDT", "data": {"i": "1", "ts": 1575398940000, "o": "0.04993", "c": "0.04970", "h": "0.04993", "l": "0.04970", "v": "8052"}}
+func (self *Bitmax) ParseOHLCV(ohlcv interface{}) *OHLCV {
+	data := self.SafeValue(ohlcv, "data", map[string]interface{}{})
+	return &OHLCV{
+		Timestamp: self.SafeInteger(data, "ts", 0),
+		Open:      self.SafeFloat(data, "o", 0),
+		High:      self.SafeFloat(data, "h", 0),
+		Low:       self.SafeFloat(data, "l", 0),
+		Close:     self.SafeFloat(data, "c", 0),
+		Volume:    self.SafeFloat(data, "v", 0),
+		Info:      ohlcv,
+	}
+}
+
+// 每次最多返回 500 根 K 线
+func (self *Bitmax) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol":   self.Member(market, "id"),
+		"interval": interval,
+	}
+	if limit > 0 {
+		request["n"] = limit
+	}
+	if since > 0 {
+		// NOTE: 默认返回截止到当前时间的 K 线, 需要指定 to
+		count := limit
+		if count <= 0 || count > 500 {
+			count = 500
+		}
+		request["from"] = since
+		request["to"] = since + count*self.ParseTimeframe(timeframe)*1000 - 1
+	}
+	response := self.ApiFunc("publicGetBarhist", self.Extend(request, params), nil, nil)
+	klines = []*OHLCV{}
+	for _, item := range self.SafeValue(response, "data", []interface{}{}).([]interface{}) {
+		klines = append(klines, self.ParseOHLCV(item))
+	}
+	return self.FilterOHLCVs(klines, since, limit), nil
+}
+
 func (self *Bitmax) ParseOrder(order interface{}, market interface{}) (result map[string]interface{}) {
 	status := self.ParseOrderStatus(self.SafeString(order, "status", ""))
 	marketId := self.SafeString(order, "symbol", "")
@@ -512,7 +653,7 @@ func (self *Bitmax) ParseOrder(order interface{}, market interface{}) (result ma
 	return map[string]interface{}{
 		"info":               order,
 		"id":                 id,
-		"clientOrderId":      nil,
+		"clientOrderId":      clientOrderId,
 		"timestamp":          timestamp,
 		"datetime":           self.Iso8601(timestamp),
 		"lastTradeTimestamp": lastTradeTimestamp,
@@ -547,7 +688,8 @@ func (self *Bitmax) CreateOrder(symbol string, typ string, side string, amount f
 	params = self.Omit(params, "account-category")
 	account := self.SafeValue(self.Accounts, 0, map[string]interface{}{})
 	accountGroup := self.SafeValue(account, "id", nil)
-	clientOrderId := self.SafeString2(params, "clientOrderId", "id", "")
+	// NOTE: id 为 9-32 位的字母和数字, 没有时自动生成
+	clientOrderId, params := self.ParseClientOrderId(params, "id")
 	request := map[string]interface{}{
 		"account-group":    accountGroup,
 		"account-category": accountCategory,
@@ -557,10 +699,7 @@ func (self *Bitmax) CreateOrder(symbol string, typ string, side string, amount f
 		"orderType":        typ,
 		"side":             side,
 	}
-	if self.ToBool(!self.TestNil(clientOrderId)) {
-		self.SetValue(request, "id", clientOrderId)
-		params = self.Omit(params, []interface{}{"clientOrderId", "id"})
-	}
+	self.SetValue(request, "id", clientOrderId)
 	if self.ToBool(typ == "limit" || typ == "stop_limit") {
 		self.SetValue(request, "orderPrice", self.PriceToPrecision(symbol, price))
 	}
@@ -671,7 +810,7 @@ func (self *Bitmax) CancelOrder(id string, symbol string, params map[string]inte
 		self.SetValue(request, "orderId", id)
 	} else {
 		self.SetValue(request, "id", clientOrderId)
-		params = self.Omit(params, []interface{}{"clientOrderId", "id"})
+		params = self.Omit(params, []string{"clientOrderId", "id"})
 	}
 	response = self.ApiFunc("accountGroupDeleteAccountCategoryOrder", self.Extend(request, params), nil, nil)
 	data := self.SafeValue(response, "data", map[string]interface{}{})
@@ -679,6 +818,100 @@ func (self *Bitmax) CancelOrder(id string, symbol string, params map[string]inte
 	return self.ParseOrder(info, market), nil
 }
 
+// 接口只返回受理状态, 没有订单明细, 所以结果为 nil
+func (self *Bitmax) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	self.LoadAccounts()
+	defaultAccountCategory := self.SafeString(self.Options, "account-category", "cash")
+	options := self.SafeValue(self.Options, "cancelAllOrders", map[string]interface{}{})
+	accountCategory := self.SafeString(options, "account-category", defaultAccountCategory)
+	accountCategory = self.SafeString(params, "account-category", accountCategory)
+	params = self.Omit(params, "account-category")
+	account := self.SafeValue(self.Accounts, 0, map[string]interface{}{})
+	accountGroup := self.SafeValue(account, "id", nil)
+	request := map[string]interface{}{
+		"account-group":    accountGroup,
+		"account-category": accountCategory,
+		"time":             self.Milliseconds(),
+	}
+	if !self.TestNil(symbol) {
+		self.SetValue(request, "symbol", self.Market(symbol).Id)
+	}
+	self.ApiFunc("accountGroupDeleteAccountCategoryOrderAll", self.Extend(request, params), nil, nil)
+	return nil, nil
+}
+
+// NOTE: 批量撤单要么全部受理要么整体报错, 所以整批失败时每个订单都带上同一个错误
+func (self *Bitmax) CancelOrders(ids []string, symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if self.ToBool(self.TestNil(symbol)) {
+		self.RaiseException("ArgumentsRequired", self.Id+" cancelOrders requires a symbol argument")
+	}
+	self.LoadMarkets()
+	self.LoadAccounts()
+	market := self.Market(symbol)
+	defaultAccountCategory := self.SafeString(self.Options, "account-category", "cash")
+	options := self.SafeValue(self.Options, "cancelOrders", map[string]interface{}{})
+	accountCategory := self.SafeString(options, "account-category", defaultAccountCategory)
+	accountCategory = self.SafeString(params, "account-category", accountCategory)
+	params = self.Omit(params, "account-category")
+	account := self.SafeValue(self.Accounts, 0, map[string]interface{}{})
+	accountGroup := self.SafeValue(account, "id", nil)
+	batchSize := int(self.SafeInteger(self.Options, "cancelOrdersBatchSize", 10))
+	result = []*OrderResult{}
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		orders := []interface{}{}
+		for _, id := range ids[start:end] {
+			orders = append(orders, map[string]interface{}{
+				"id":      "foobar",
+				"orderId": id,
+				"symbol":  market.Id,
+				"time":    self.Milliseconds(),
+			})
+		}
+		request := map[string]interface{}{
+			"account-group":    accountGroup,
+			"account-category": accountCategory,
+			"orders":           orders,
+		}
+		batchErr := self.cancelOrdersBatch(self.Extend(request, params).(map[string]interface{}))
+		for _, id := range ids[start:end] {
+			one := &OrderResult{
+				Id:    id,
+				Error: batchErr,
+			}
+			if batchErr == nil {
+				one.Order = self.CanceledOrder(id, symbol, nil)
+			}
+			result = append(result, one)
+		}
+	}
+	return result, nil
+}
+
+func (self *Bitmax) cancelOrdersBatch(request map[string]interface{}) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.ApiFunc("accountGroupDeleteAccountCategoryOrderBatch", request, nil, nil)
+	return nil
+}
+
 func (self *Bitmax) Sign(path string, api string, method string, params map[string]interface{}, headers interface{}, body interface{}) (ret interface{}) {
 	url := ""
 	query := params
@@ -89,6 +89,7 @@ func (self *Bitmax2) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "assets",
                 "products",
@@ -160,8 +161,11 @@ func (self *Bitmax2) Describe() []byte {
     },
     "precisionMode": "TICK_SIZE",
     "options": {
+        "clientOrderIdReplaceSeparator": "r",
         "account-category": "cash",
         "account-group": null,
+        "accountTypes": ["spot", "margin", "swap"],
+        "fetchOHLCVLimit": 500,
         "fetchClosedOrders": {
             "method": "accountGroupGetOrderHist"
         }
@@ -269,6 +273,24 @@ func (self *Bitmax2) FetchBalance(params map[string]interface{}) (balanceResult
 	accountCategory := self.SafeString(options, "account-category", defaultAccountCategory)
 	accountCategory = self.SafeString(params, "account-category", accountCategory)
 	params = self.Omit(params, "account-category")
+	// account-category: cash 为币币账户, margin 为杠杆账户, futures 为永续合约账户
+	defaultType := AccountTypeSpot
+	if accountCategory == "margin" {
+		defaultType = AccountTypeMargin
+	} else if accountCategory == "futures" {
+		defaultType = AccountTypeSwap
+	}
+	typ, params := self.AccountType(params, defaultType)
+	switch typ {
+	case AccountTypeSpot:
+		accountCategory = "cash"
+	case AccountTypeMargin:
+		accountCategory = "margin"
+	case AccountTypeFutures, AccountTypeSwap:
+		accountCategory = "futures"
+	default:
+		self.UnsupportedAccountType(typ)
+	}
 	account := self.SafeValue(self.Accounts, 0, map[string]interface{}{})
 	accountGroup := self.SafeString(account, "id", "")
 	request := map[string]interface{}{
@@ -282,6 +304,7 @@ func (self *Bitmax2) FetchBalance(params map[string]interface{}) (balanceResult
 	}
 	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
 	result := map[string]interface{}{
+		"type":      typ,
 		"info":      response,
 		"timestamp": nil,
 		"datetime":  nil,
@@ -317,10 +340,132 @@ func (self *Bitmax2) FetchOrderBook(symbol string, limit int64, params map[strin
 	orderbook := self.SafeValue(data, "data", map[string]interface{}{})
 	timestamp := self.SafeInteger(orderbook, "ts", 0)
 	result := self.ParseOrderBook(orderbook, timestamp, "bids", "asks", 0, 1)
-	self.SetValue(result, "nonce", self.SafeInteger(orderbook, "seqnum", 0))
+	result.Nonce = self.SafeInteger(orderbook, "seqnum", 0)
 	return result, nil
 }
 
+func (self *Bitmax2) ParseTicker(ticker interface{}) *Ticker {
+	last := self.SafeFloat(ticker, "close", 0)
+	open := self.SafeFloat(ticker, "open", 0)
+	bid := self.SafeValue(ticker, "bid", []interface{}{})
+	ask := self.SafeValue(ticker, "ask", []interface{}{})
+	result := &Ticker{
+		Symbol:     self.SafeString(ticker, "symbol", ""),
+		Last:       last,
+		Bid:        ToFloat(self.SafeValue(bid, 0, 0)),
+		BidQty:     ToFloat(self.SafeValue(bid, 1, 0)),
+		Ask:        ToFloat(self.SafeValue(ask, 0, 0)),
+		AskQty:     ToFloat(self.SafeValue(ask, 1, 0)),
+		Open:       open,
+		High:       self.SafeFloat(ticker, "high", 0),
+		Low:        self.SafeFloat(ticker, "low", 0),
+		Close:      last,
+		BaseVolume: self.SafeFloat(ticker, "volume", 0),
+		Change:     last - open,
+		Info:       ticker,
+	}
+	if open > 0 {
+		result.Percentage = result.Change / open * 100
+	}
+	return result
+}
+
+func (self *Bitmax2) FetchTicker(symbol string, params map[string]interface{}) (ticker *Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": self.Member(market, "id"),
+	}
+	response := self.ApiFunc("publicGetTicker", self.Extend(request, params), nil, nil)
+	ticker = self.ParseTicker(self.SafeValue(response, "data", map[string]interface{}{}))
+	ticker.Symbol = symbol
+	return ticker, nil
+}
+
+// NOTE: 不指定 symbol 时返回所有交易对
+func (self *Bitmax2) FetchTickers(symbols []string, params map[string]interface{}) (tickers []*Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{}
+	if len(symbols) > 0 {
+		ids := []string{}
+		for _, symbol := range symbols {
+			ids = append(ids, self.Market(symbol).Id)
+		}
+		request["symbol"] = strings.Join(ids, ",")
+	}
+	response := self.ApiFunc("publicGetTicker", self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data", []interface{}{})
+	// 只有一个交易对时返回的是对象
+	if _, ok := data.(map[string]interface{}); ok {
+		data = []interface{}{data}
+	}
+	tickers = []*Ticker{}
+	for _, item := range data.([]interface{}) {
+		tickers = append(tickers, self.ParseTicker(item))
+	}
+	return self.FilterTickers(tickers, symbols), nil
+}
+
+// {"m": "bar", "s": "BTC/USDT", "data": {"i": "1", "ts": 1575398940000, "o": "0.04993", "c": "0.04970", "h": "0.04993", "l": "0.04970", "v": "8052"}}
+func (self *Bitmax2) ParseOHLCV(ohlcv interface{}) *OHLCV {
+	data := self.SafeValue(ohlcv, "data", map[string]interface{}{})
+	return &OHLCV{
+		Timestamp: self.SafeInteger(data, "ts", 0),
+		Open:      self.SafeFloat(data, "o", 0),
+		High:      self.SafeFloat(data, "h", 0),
+		Low:       self.SafeFloat(data, "l", 0),
+		Close:     self.SafeFloat(data, "c", 0),
+		Volume:    self.SafeFloat(data, "v", 0),
+		Info:      ohlcv,
+	}
+}
+
+// 每次最多返回 500 根 K 线
+func (self *Bitmax2) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol":   self.Member(market, "id"),
+		"interval": interval,
+	}
+	if limit > 0 {
+		request["n"] = limit
+	}
+	if since > 0 {
+		// NOTE: 默认返回截止到当前时间的 K 线, 需要指定 to
+		count := limit
+		if count <= 0 || count > 500 {
+			count = 500
+		}
+		request["from"] = since
+		request["to"] = since + count*self.ParseTimeframe(timeframe)*1000 - 1
+	}
+	response := self.ApiFunc("publicGetBarhist", self.Extend(request, params), nil, nil)
+	klines = []*OHLCV{}
+	for _, item := range self.SafeValue(response, "data", []interface{}{}).([]interface{}) {
+		klines = append(klines, self.ParseOHLCV(item))
+	}
+	return self.FilterOHLCVs(klines, since, limit), nil
+}
+
 func (self *Bitmax2) ParseOrderStatus(status string) string {
 	statuses := map[string]interface{}{
 		"PendingNew":      "open",
@@ -366,7 +511,7 @@ func (self *Bitmax2) ParseOrder(order interface{}, market interface{}) (result m
 	return map[string]interface{}{
 		"info":               order,
 		"id":                 id,
-		"clientOrderId":      nil,
+		"clientOrderId":      clientOrderId,
 		"timestamp":          timestamp,
 		"datetime":           self.Iso8601(timestamp),
 		"lastTradeTimestamp": lastTradeTimestamp,
@@ -404,7 +549,8 @@ func (self *Bitmax2) CreateOrder(symbol string, typ string, side string, amount
 	params = self.Omit(params, "account-category")
 	account := self.SafeValue(self.Accounts, 0, map[string]interface{}{})
 	accountGroup := self.SafeValue(account, "id", nil)
-	clientOrderId := self.SafeString2(params, "clientOrderId", "id", "")
+	// NOTE: id 为 9-32 位的字母和数字, 没有时自动生成
+	clientOrderId, params := self.ParseClientOrderId(params, "id")
 	request := map[string]interface{}{
 		"account-group":    accountGroup,
 		"account-category": accountCategory,
@@ -414,10 +560,7 @@ func (self *Bitmax2) CreateOrder(symbol string, typ string, side string, amount
 		"orderType":        typ,
 		"side":             side,
 	}
-	if self.ToBool(!self.TestNil(clientOrderId)) {
-		self.SetValue(request, "id", clientOrderId)
-		params = self.Omit(params, []interface{}{"clientOrderId", "id"})
-	}
+	self.SetValue(request, "id", clientOrderId)
 	if self.ToBool(typ == "limit" || typ == "stop_limit") {
 		self.SetValue(request, "orderPrice", self.PriceToPrecision(symbol, price))
 	}
@@ -528,7 +671,7 @@ func (self *Bitmax2) CancelOrder(id string, symbol string, params map[string]int
 		self.SetValue(request, "orderId", id)
 	} else {
 		self.SetValue(request, "id", clientOrderId)
-		params = self.Omit(params, []interface{}{"clientOrderId", "id"})
+		params = self.Omit(params, []string{"clientOrderId", "id"})
 	}
 	response = self.ApiFunc("accountCategoryDeleteOrder", self.Extend(request, params), nil, nil)
 	data := self.SafeValue(response, "data", map[string]interface{}{})
@@ -3,6 +3,7 @@ package bybit
 import (
 	"fmt"
 	. "github.com/epheien/ccxt/go/base"
+	"sort"
 	"strings"
 )
 
@@ -46,6 +47,7 @@ func (self *Bybit) Describe() []byte {
         "option": null,
         "cancelAllOrders": true,
         "cancelOrder": true,
+        "cancelOrders": true,
         "createOrder": true,
         "createStopLimitOrder": true,
         "createStopMarketOrder": true,
@@ -64,14 +66,16 @@ func (self *Bybit) Describe() []byte {
         "fetchDepositAddressesByNetwork": true,
         "fetchDeposits": true,
         "fetchFundingRate": true,
-        "fetchFundingRateHistory": false,
+        "fetchFundingRateHistory": true,
         "fetchIndexOHLCV": true,
         "fetchLedger": true,
+        "fetchLeverageTiers": true,
         "fetchMarketLeverageTiers": true,
         "fetchMarkets": true,
         "fetchMarkOHLCV": true,
         "fetchMyTrades": true,
         "fetchOHLCV": true,
+        "fetchOpenInterest": true,
         "fetchOpenInterestHistory": true,
         "fetchOpenOrders": true,
         "fetchOrder": true,
@@ -81,35 +85,38 @@ func (self *Bybit) Describe() []byte {
         "fetchPositions": true,
         "fetchPremiumIndexOHLCV": true,
         "fetchTicker": true,
+        "fetchStatus": true,
         "fetchTickers": true,
         "fetchTime": true,
         "fetchTrades": true,
-        "fetchTradingFee": false,
-        "fetchTradingFees": false,
+        "fetchTradingFee": true,
+        "fetchTradingFees": true,
         "fetchTransactions": null,
         "fetchTransfers": true,
         "fetchWithdrawals": true,
         "setLeverage": true,
         "setMarginMode": true,
         "setPositionMode": true,
+        "addMargin": true,
+        "reduceMargin": true,
         "transfer": true,
         "withdraw": true
     },
     "timeframes": {
-        "1m": "1",
-        "3m": "3",
-        "5m": "5",
-        "15m": "15",
-        "30m": "30",
-        "1h": "60",
-        "2h": "120",
-        "4h": "240",
-        "6h": "360",
-        "12h": "720",
-        "1d": "D",
-        "1w": "W",
-        "1M": "M",
-        "1y": "Y"
+        "1m": "1m",
+        "3m": "3m",
+        "5m": "5m",
+        "15m": "15m",
+        "30m": "30m",
+        "1h": "1h",
+        "2h": "2h",
+        "4h": "4h",
+        "6h": "6h",
+        "12h": "12h",
+        "1d": "1d",
+        "1w": "1w",
+        "1M": "1M",
+        "1y": "1M"
     },
     "urls": {
         "test": {
@@ -117,6 +124,8 @@ func (self *Bybit) Describe() []byte {
             "futures": "https://api-testnet.{hostname}",
             "v2": "https://api-testnet.{hostname}",
             "public": "https://api-testnet.{hostname}",
+            "publicV5": "https://api-testnet.{hostname}",
+            "privateV5": "https://api-testnet.{hostname}",
             "private": "https://api-testnet.{hostname}"
         },
         "logo": "https://user-images.githubusercontent.com/51840849/76547799-daff5b80-649e-11ea-87fb-3be9bac08954.jpg",
@@ -125,6 +134,8 @@ func (self *Bybit) Describe() []byte {
             "futures": "https://api.{hostname}",
             "v2": "https://api.{hostname}",
             "public": "https://api.{hostname}",
+            "publicV5": "https://api.{hostname}",
+            "privateV5": "https://api.{hostname}",
             "private": "https://api.{hostname}"
         },
         "www": "https://www.bybit.com",
@@ -138,6 +149,7 @@ func (self *Bybit) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
 				"public/symbols",
 				"public/quote/depth",
@@ -150,6 +162,42 @@ func (self *Bybit) Describe() []byte {
 				"public/server-time",
             ]
         },
+        "publicV5": {
+            "cacheable": true,
+            "get": [
+				"market/tickers",
+				"market/funding/history",
+				"market/open-interest",
+				"market/mark-price-kline",
+				"market/index-price-kline",
+				"market/premium-index-price-kline",
+				"market/risk-limit",
+				"system/status",
+            ]
+        },
+        "privateV5": {
+            "get": [
+				"account/wallet-balance",
+				"account/fee-rate",
+				"account/transaction-log",
+				"account/contract-transaction-log",
+				"asset/transfer/query-account-coins-balance",
+				"asset/transfer/query-inter-transfer-list",
+				"asset/coin/query-info",
+				"asset/deposit/query-address",
+				"asset/deposit/query-record",
+				"asset/withdraw/query-record",
+            ],
+            "post": [
+				"position/set-leverage",
+				"position/switch-isolated",
+				"position/switch-mode",
+				"position/add-margin",
+				"asset/transfer/inter-transfer",
+				"asset/transfer/universal-transfer",
+				"asset/withdraw/create",
+            ]
+        },
         "private": {
             "get": [
 				"private/order",
@@ -294,6 +342,7 @@ func (self *Bybit) Describe() []byte {
     "precisionMode": "TICK_SIZE",
     "options": {
         "createMarketBuyOrderRequiresPrice": true,
+        "fetchOHLCVLimit": 1000,
         "defaultType": "swap",
         "defaultSubType": "linear",
         "defaultSettle": "USDT",
@@ -301,11 +350,55 @@ func (self *Bybit) Describe() []byte {
         "recvWindow": 5000,
         "timeDifference": 0,
         "adjustForTimeDifference": false,
+        "cancelOrdersBatchSize": 100,
+        "derivativesCategory": "linear",
+        "accountTypes": ["spot", "swap", "funding"],
+        "contractAccountType": "CONTRACT",
+        "fetchLedgerWindow": 604800000,
+        "ledgerTypes": {
+            "TRADE": "trade",
+            "SETTLEMENT": "funding",
+            "TRANSFER_IN": "transfer",
+            "TRANSFER_OUT": "transfer",
+            "LIQUIDATION": "liquidation",
+            "BONUS": "rebate",
+            "FEE_REFUND": "rebate",
+            "INTEREST": "interest"
+        },
+        "networks": {
+            "ERC20": "ETH",
+            "TRC20": "TRX",
+            "BEP20": "BSC"
+        },
+        "v5Timeframes": {
+            "1m": "1",
+            "3m": "3",
+            "5m": "5",
+            "15m": "15",
+            "30m": "30",
+            "1h": "60",
+            "2h": "120",
+            "4h": "240",
+            "6h": "360",
+            "12h": "720",
+            "1d": "D",
+            "1w": "W",
+            "1M": "M"
+        },
+        "openInterestIntervals": {
+            "5m": "5min",
+            "15m": "15min",
+            "30m": "30min",
+            "1h": "1h",
+            "4h": "4h",
+            "1d": "1d"
+        },
         "brokerId": "CCXT",
         "accountsByType": {
             "spot": "SPOT",
             "margin": "SPOT",
-            "future": "CONTRACT",
+            "funding": "FUND",
+            "futures": "CONTRACT",
             "swap": "CONTRACT",
             "option": "OPTION"
         },
@@ -345,7 +438,69 @@ func (self *Bybit) Market(symbol string) *Market {
 		Symbol: symbol,
 		Base:   li[0],
 		Quote:  li[1],
+		Type:   "spot",
+		Spot:   true,
+	}
+}
+
+func (self *Bybit) FetchMarkets(params map[string]interface{}) ([]*Market, error) {
+	response := self.ApiFunc("publicGetPublicSymbols", params, nil, nil)
+	data := self.SafeValue(self.SafeValue(response, "result"), "list", []interface{}{}).([]interface{})
+	result := []interface{}{}
+	for _, market := range data {
+		/*
+			{
+				"name": "BTCUSDT",
+				"alias": "BTCUSDT",
+				"baseCoin": "BTC",
+				"quoteCoin": "USDT",
+				"basePrecision": "0.000001",
+				"quotePrecision": "0.00000001",
+				"minTradeQty": "0.00004",
+				"minTradeAmt": "1",
+				"maxTradeQty": "46.13",
+				"maxTradeAmt": "938901",
+				"minPricePrecision": "0.01",
+				"category": "1",
+				"showStatus": "1"
+			}
+		*/
+		id := self.SafeString(market, "name", "")
+		baseId := self.SafeString(market, "baseCoin", "")
+		quoteId := self.SafeString(market, "quoteCoin", "")
+		base := self.SafeCurrencyCode(baseId)
+		quote := self.SafeCurrencyCode(quoteId)
+		precision := map[string]interface{}{
+			"base":   self.PrecisionFromString(self.SafeString(market, "basePrecision")),
+			"quote":  self.PrecisionFromString(self.SafeString(market, "quotePrecision")),
+			"amount": self.PrecisionFromString(self.SafeString(market, "basePrecision")),
+			"price":  self.PrecisionFromString(self.SafeString(market, "minPricePrecision")),
+		}
+		limits := map[string]interface{}{
+			"amount": map[string]interface{}{
+				"min": self.SafeFloat(market, "minTradeQty", 0),
+				"max": self.SafeFloat(market, "maxTradeQty", 0),
+			},
+			"cost": map[string]interface{}{
+				"min": self.SafeFloat(market, "minTradeAmt", 0),
+				"max": self.SafeFloat(market, "maxTradeAmt", 0),
+			},
+		}
+		result = append(result, map[string]interface{}{
+			"id":        id,
+			"symbol":    base + "/" + quote,
+			"baseId":    baseId,
+			"quoteId":   quoteId,
+			"base":      base,
+			"quote":     quote,
+			"active":    self.SafeString(market, "showStatus", "") == "1",
+			"precision": precision,
+			"limits":    limits,
+			"info":      market,
+			"spot":      true,
+		})
 	}
+	return self.ToMarkets(result), nil
 }
 
 func (self *Bybit) FetchOrderBook(symbol string, limit int64, params map[string]interface{}) (orderBook *OrderBook, err error) {
@@ -368,28 +523,983 @@ func (self *Bybit) FetchOrderBook(symbol string, limit int64, params map[string]
 	return self.ParseOrderBook(result, timestamp, "bids", "asks", 0, 1), nil
 }
 
+// ticker/24hr 没有买一卖一的数量, 需要用 bookTicker 补全
+func (self *Bybit) ParseTicker(ticker interface{}, bookTicker interface{}) *Ticker {
+	timestamp := self.SafeInteger(ticker, "t", 0)
+	last := self.SafeFloat(ticker, "lp", 0)
+	open := self.SafeFloat(ticker, "o", 0)
+	result := &Ticker{
+		Timestamp:   timestamp,
+		Datetime:    self.Iso8601(timestamp),
+		Last:        last,
+		Bid:         self.SafeFloat(ticker, "bp", 0),
+		Ask:         self.SafeFloat(ticker, "ap", 0),
+		Open:        open,
+		High:        self.SafeFloat(ticker, "h", 0),
+		Low:         self.SafeFloat(ticker, "l", 0),
+		Close:       last,
+		BaseVolume:  self.SafeFloat(ticker, "v", 0),
+		QuoteVolume: self.SafeFloat(ticker, "qv", 0),
+		Change:      last - open,
+		Info:        ticker,
+	}
+	if open > 0 {
+		result.Percentage = result.Change / open * 100
+	}
+	if bookTicker != nil {
+		result.Bid = self.SafeFloat(bookTicker, "bp", result.Bid)
+		result.BidQty = self.SafeFloat(bookTicker, "bq", 0)
+		result.Ask = self.SafeFloat(bookTicker, "ap", result.Ask)
+		result.AskQty = self.SafeFloat(bookTicker, "aq", 0)
+	}
+	return result
+}
+
+func (self *Bybit) FetchTicker(symbol string, params map[string]interface{}) (ticker *Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	response := self.ApiFunc("publicGetPublicQuoteTicker24hr", self.Extend(request, params), nil, nil)
+	book := self.ApiFunc("publicGetPublicQuoteTickerBookTicker", request, nil, nil)
+	ticker = self.ParseTicker(response["result"], book["result"])
+	ticker.Symbol = symbol
+	return ticker, nil
+}
+
+// NOTE: 交易对 id 没有分隔符, 未指定 symbols 时需要加载市场信息来转换
+func (self *Bybit) FetchTickers(symbols []string, params map[string]interface{}) (tickers []*Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	marketsById := map[string]string{}
+	for _, symbol := range symbols {
+		marketsById[self.Market(symbol).Id] = symbol
+	}
+	if len(symbols) == 0 {
+		for id, market := range self.Exchange.LoadMarkets() {
+			marketsById[market.Id] = id
+		}
+	}
+	response := self.ApiFunc("publicGetPublicQuoteTicker24hr", params, nil, nil)
+	book := self.ApiFunc("publicGetPublicQuoteTickerBookTicker", params, nil, nil)
+	books := map[string]interface{}{}
+	for _, item := range self.SafeValue(book["result"], "list", []interface{}{}).([]interface{}) {
+		books[self.SafeString(item, "s", "")] = item
+	}
+	tickers = []*Ticker{}
+	for _, item := range self.SafeValue(response["result"], "list", []interface{}{}).([]interface{}) {
+		marketId := self.SafeString(item, "s", "")
+		symbol, ok := marketsById[marketId]
+		if !ok {
+			continue
+		}
+		ticker := self.ParseTicker(item, books[marketId])
+		ticker.Symbol = symbol
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
+}
+
+func (self *Bybit) ParseOHLCV(ohlcv interface{}) *OHLCV {
+	return &OHLCV{
+		Timestamp: self.SafeInteger(ohlcv, "t", 0),
+		Open:      self.SafeFloat(ohlcv, "o", 0),
+		High:      self.SafeFloat(ohlcv, "h", 0),
+		Low:       self.SafeFloat(ohlcv, "l", 0),
+		Close:     self.SafeFloat(ohlcv, "c", 0),
+		Volume:    self.SafeFloat(ohlcv, "v", 0),
+		Info:      ohlcv,
+	}
+}
+
+func (self *Bybit) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
+	request := map[string]interface{}{
+		"symbol":   self.Market(symbol).Id,
+		"interval": interval,
+	}
+	// NOTE: 接口没有年线, 用月线合并, 起始时间对齐到自然年
+	yearly := timeframe == "1y"
+	if yearly && since > 0 {
+		since = self.RoundTimeframe(timeframe, since)
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		if yearly {
+			// 单次最多 1000 根
+			request["limit"] = limit * 12
+			if limit*12 > 1000 {
+				request["limit"] = 1000
+			}
+		} else {
+			request["limit"] = limit
+		}
+	}
+	response := self.ApiFunc("publicGetPublicQuoteKline", self.Extend(request, params), nil, nil)
+	klines = []*OHLCV{}
+	for _, item := range self.SafeValue(response["result"], "list", []interface{}{}).([]interface{}) {
+		klines = append(klines, self.ParseOHLCV(item))
+	}
+	if yearly {
+		klines = self.ResampleOHLCV(klines, timeframe)
+	}
+	return self.FilterOHLCVs(klines, since, limit), nil
+}
+
+// NOTE: 以下合约行情使用 v5 接口, category 由 options 中的 derivativesCategory 指定, 默认为 USDT 永续(linear).
+// v5 接口的列表都按时间降序返回
+
+func (self *Bybit) derivativesRequest(symbol string) map[string]interface{} {
+	return map[string]interface{}{
+		"category": self.SafeString(self.Options, "derivativesCategory", "linear"),
+		"symbol":   self.Market(symbol).Id,
+	}
+}
+
+func (self *Bybit) derivativesList(response map[string]interface{}) []interface{} {
+	return self.SafeValue(response["result"], "list", []interface{}{}).([]interface{})
+}
+
+func (self *Bybit) fetchDerivativesTicker(symbol string, params map[string]interface{}) interface{} {
+	response := self.ApiFunc("publicV5GetMarketTickers", self.Extend(self.derivativesRequest(symbol), params), nil, nil)
+	list := self.derivativesList(response)
+	if len(list) == 0 {
+		self.RaiseException("BadSymbol", self.Id+" ticker not found "+symbol)
+	}
+	return list[0]
+}
+
+// NOTE: 只有当前周期的资金费率
+func (self *Bybit) FetchFundingRate(symbol string, params map[string]interface{}) (fundingRate *FundingRate, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	ticker := self.fetchDerivativesTicker(symbol, params)
+	return &FundingRate{
+		Symbol:           symbol,
+		FundingRate:      self.SafeFloat(ticker, "fundingRate", 0),
+		FundingTimestamp: self.SafeInteger(ticker, "nextFundingTime", 0),
+		MarkPrice:        self.SafeFloat(ticker, "markPrice", 0),
+		IndexPrice:       self.SafeFloat(ticker, "indexPrice", 0),
+		Timestamp:        self.Milliseconds(),
+		Info:             ticker,
+	}, nil
+}
+
+func (self *Bybit) FetchFundingRateHistory(symbol string, since int64, limit int64, params map[string]interface{}) (result []*FundingRateHistory, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := self.derivativesRequest(symbol)
+	if since > 0 {
+		// NOTE: 只给出 startTime 时会报错, 需要同时给出 endTime
+		request["startTime"] = since
+		request["endTime"] = self.Milliseconds()
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFunc("publicV5GetMarketFundingHistory", self.Extend(request, params), nil, nil)
+	list := self.derivativesList(response)
+	result = []*FundingRateHistory{}
+	for i := len(list) - 1; i >= 0; i-- {
+		item := list[i]
+		result = append(result, &FundingRateHistory{
+			Symbol:      symbol,
+			FundingRate: self.SafeFloat(item, "fundingRate", 0),
+			Timestamp:   self.SafeInteger(item, "fundingRateTimestamp", 0),
+			Info:        item,
+		})
+	}
+	return result, nil
+}
+
+func (self *Bybit) FetchOpenInterest(symbol string, params map[string]interface{}) (openInterest *OpenInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	ticker := self.fetchDerivativesTicker(symbol, params)
+	return &OpenInterest{
+		Symbol:    symbol,
+		Amount:    self.SafeFloat(ticker, "openInterest", 0),
+		Value:     self.SafeFloat(ticker, "openInterestValue", 0),
+		Timestamp: self.Milliseconds(),
+		Info:      ticker,
+	}, nil
+}
+
+// 历史持仓量只有数量, timeframe 支持 5m 15m 30m 1h 4h 1d
+func (self *Bybit) FetchOpenInterestHistory(symbol string, timeframe string, since int64, limit int64, params map[string]interface{}) (result []*OpenInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval := self.SafeString(self.SafeValue(self.Options, "openInterestIntervals"), timeframe, "")
+	if interval == "" {
+		self.RaiseException("BadRequest", self.Id+" fetchOpenInterestHistory does not support timeframe "+timeframe)
+	}
+	request := self.derivativesRequest(symbol)
+	request["intervalTime"] = interval
+	if since > 0 {
+		request["startTime"] = since
+		request["endTime"] = self.Milliseconds()
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFunc("publicV5GetMarketOpenInterest", self.Extend(request, params), nil, nil)
+	list := self.derivativesList(response)
+	result = []*OpenInterest{}
+	for i := len(list) - 1; i >= 0; i-- {
+		item := list[i]
+		result = append(result, &OpenInterest{
+			Symbol:    symbol,
+			Amount:    self.SafeFloat(item, "openInterest", 0),
+			Timestamp: self.SafeInteger(item, "timestamp", 0),
+			Info:      item,
+		})
+	}
+	return result, nil
+}
+
+func (self *Bybit) FetchMarkOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchPriceKlines("publicV5GetMarketMarkPriceKline", symbol, timeframe, since, limit, params)
+}
+
+func (self *Bybit) FetchIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchPriceKlines("publicV5GetMarketIndexPriceKline", symbol, timeframe, since, limit, params)
+}
+
+func (self *Bybit) FetchPremiumIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchPriceKlines("publicV5GetMarketPremiumIndexPriceKline", symbol, timeframe, since, limit, params)
+}
+
+// [startTime, open, high, low, close], 没有成交量
+func (self *Bybit) fetchPriceKlines(method string, symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval := self.SafeString(self.SafeValue(self.Options, "v5Timeframes"), timeframe, "")
+	if interval == "" {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
+	request := self.derivativesRequest(symbol)
+	request["interval"] = interval
+	if since > 0 {
+		request["start"] = since
+		if limit > 0 {
+			request["end"] = since + limit*self.ParseTimeframe(timeframe)*1000 - 1
+		}
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
+	list := self.derivativesList(response)
+	klines = []*OHLCV{}
+	for i := len(list) - 1; i >= 0; i-- {
+		data := list[i].([]interface{})
+		klines = append(klines, &OHLCV{
+			Timestamp: ToInteger(data[0]),
+			Open:      ToFloat(data[1]),
+			High:      ToFloat(data[2]),
+			Low:       ToFloat(data[3]),
+			Close:     ToFloat(data[4]),
+			Info:      data,
+		})
+	}
+	return self.FilterOHLCVs(klines, since, limit), nil
+}
+
+func (self *Bybit) SetLeverage(symbol string, leverage float64, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := self.derivativesRequest(symbol)
+	request["buyLeverage"] = self.Float64ToString(leverage)
+	request["sellLeverage"] = self.Float64ToString(leverage)
+	return self.ApiFunc("privateV5PostPositionSetLeverage", self.Extend(request, params), nil, nil), nil
+}
+
+// NOTE: 切换时需要同时指定杠杆, 由 params 中的 leverage 给出
+func (self *Bybit) SetMarginMode(symbol string, marginMode string, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	leverage := self.SafeString(params, "leverage", "")
+	if leverage == "" {
+		self.RaiseException("ArgumentsRequired", self.Id+" setMarginMode requires leverage in params")
+	}
+	request := self.derivativesRequest(symbol)
+	request["tradeMode"] = 0
+	if self.CheckMarginMode(marginMode) == "isolated" {
+		request["tradeMode"] = 1
+	}
+	request["buyLeverage"] = leverage
+	request["sellLeverage"] = leverage
+	return self.ApiFunc("privateV5PostPositionSwitchIsolated", self.Extend(request, self.Omit(params, "leverage")), nil, nil), nil
+}
+
+// 对结算货币(options 中的 defaultSettle)下的所有交易对生效
+func (self *Bybit) SetPositionMode(hedged bool, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"category": self.SafeString(self.Options, "derivativesCategory", "linear"),
+		"coin":     self.SafeString(self.Options, "defaultSettle", "USDT"),
+		"mode":     0,
+	}
+	if hedged {
+		request["mode"] = 3
+	}
+	return self.ApiFunc("privateV5PostPositionSwitchMode", self.Extend(request, params), nil, nil), nil
+}
+
+func (self *Bybit) AddMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	return self.modifyMargin(symbol, "add", amount, params)
+}
+
+func (self *Bybit) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	return self.modifyMargin(symbol, "reduce", amount, params)
+}
+
+// 双向持仓时需要在 params 中指定 positionIdx, 1 为多仓, 2 为空仓
+func (self *Bybit) modifyMargin(symbol string, type_ string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	margin := amount
+	if type_ == "reduce" {
+		margin = -amount
+	}
+	request := self.derivativesRequest(symbol)
+	request["margin"] = self.Float64ToString(margin)
+	response := self.ApiFunc("privateV5PostPositionAddMargin", self.Extend(request, params), nil, nil)
+	return &MarginModification{
+		Symbol: symbol,
+		Type:   type_,
+		Amount: amount,
+		Total:  self.SafeFloat(response["result"], "positionIM", 0),
+		Info:   response["result"],
+	}, nil
+}
+
+// 每个交易对单独查询, 必须指定 symbols. 第 n 档的下限为第 n-1 档的 riskLimitValue
+func (self *Bybit) FetchLeverageTiers(symbols []string, params map[string]interface{}) (result map[string][]*LeverageTier, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if len(symbols) == 0 {
+		self.RaiseException("ArgumentsRequired", self.Id+" fetchLeverageTiers requires symbols")
+	}
+	result = map[string][]*LeverageTier{}
+	for _, symbol := range symbols {
+		response := self.ApiFunc("publicV5GetMarketRiskLimit", self.Extend(self.derivativesRequest(symbol), params), nil, nil)
+		tiers := []*LeverageTier{}
+		floor := 0.0
+		for _, item := range self.derivativesList(response) {
+			riskLimit := self.SafeFloat(item, "riskLimitValue", 0)
+			tiers = append(tiers, &LeverageTier{
+				Tier:                  self.SafeInteger(item, "id", 0),
+				Symbol:                symbol,
+				MinNotional:           floor,
+				MaxNotional:           riskLimit,
+				MaintenanceMarginRate: self.SafeFloat(item, "maintenanceMargin", 0),
+				MaxLeverage:           self.SafeFloat(item, "maxLeverage", 0),
+				Info:                  item,
+			})
+			floor = riskLimit
+		}
+		result[symbol] = tiers
+	}
+	return result, nil
+}
+
+// FetchBalance spot 为现货账户, swap 为合约账户 (统一账户需设置 Options["contractAccountType"] 为 UNIFIED), funding 为资金账户
 func (self *Bybit) FetchBalance(params map[string]interface{}) (balanceResult *Account, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
 		}
 	}()
-	response := self.ApiFunc("privateGetPrivateAccount", params, nil, nil)
-	balances := response["result"].(map[string]interface{})["balances"].([]interface{})
+	typ, query := self.AccountType(params, AccountTypeSpot)
 	result := map[string]interface{}{
-		"info": response,
+		"type": typ,
 	}
-	for _, balance := range balances {
-		account := self.Account()
-		account["free"] = self.SafeFloat(balance, "free", 0)
-		account["used"] = self.SafeFloat(balance, "locked", 0)
-		account["total"] = self.SafeFloat(balance, "total", 0)
-		currencyId := self.SafeString(balance, "coinId", "")
-		result[currencyId] = account
+	switch typ {
+	case AccountTypeSpot:
+		response := self.ApiFunc("privateGetPrivateAccount", query, nil, nil)
+		result["info"] = response
+		balances := response["result"].(map[string]interface{})["balances"].([]interface{})
+		for _, balance := range balances {
+			account := self.Account()
+			account["free"] = self.SafeFloat(balance, "free", 0)
+			account["used"] = self.SafeFloat(balance, "locked", 0)
+			account["total"] = self.SafeFloat(balance, "total", 0)
+			currencyId := self.SafeString(balance, "coinId", "")
+			result[currencyId] = account
+		}
+	case AccountTypeFutures, AccountTypeSwap:
+		request := map[string]interface{}{
+			"accountType": self.SafeString(self.Options, "contractAccountType", "CONTRACT"),
+		}
+		response := self.ApiFunc("privateV5GetAccountWalletBalance", self.Extend(request, query), nil, nil)
+		result["info"] = response
+		wallet := self.SafeValue(self.derivativesList(response), 0, map[string]interface{}{})
+		balances := self.SafeValue(wallet, "coin", []interface{}{}).([]interface{})
+		for _, balance := range balances {
+			account := self.Account()
+			total := self.SafeFloat(balance, "equity", 0)
+			free := self.SafeFloat(balance, "availableToWithdraw", 0)
+			account["free"] = free
+			account["used"] = total - free
+			account["total"] = total
+			account["unrealPnl"] = self.SafeFloat(balance, "unrealisedPnl", 0)
+			result[self.SafeString(balance, "coin", "")] = account
+		}
+	case AccountTypeFunding:
+		request := map[string]interface{}{
+			"accountType": "FUND",
+		}
+		response := self.ApiFunc("privateV5GetAssetTransferQueryAccountCoinsBalance", self.Extend(request, query), nil, nil)
+		result["info"] = response
+		balances := self.SafeValue(response["result"], "balance", []interface{}{}).([]interface{})
+		for _, balance := range balances {
+			account := self.Account()
+			total := self.SafeFloat(balance, "walletBalance", 0)
+			free := self.SafeFloat(balance, "transferBalance", 0)
+			account["free"] = free
+			account["used"] = total - free
+			account["total"] = total
+			result[self.SafeString(balance, "coin", "")] = account
+		}
+	default:
+		self.UnsupportedAccountType(typ)
 	}
 	return self.ParseBalance(result), nil
 }
 
+// 合约账户的名称取 Options["contractAccountType"], 其他账户取 Options["accountsByType"]
+func (self *Bybit) transferAccountId(account string) string {
+	typ, _ := self.AccountType(map[string]interface{}{"type": account}, "")
+	if typ == AccountTypeSwap {
+		return self.SafeString(self.Options, "contractAccountType", "CONTRACT")
+	}
+	return self.TransferAccountId(typ)
+}
+
+func (self *Bybit) transferAccountType(id string) string {
+	if id == self.SafeString(self.Options, "contractAccountType", "CONTRACT") {
+		return AccountTypeSwap
+	}
+	return self.TransferAccountType(id)
+}
+
+func (self *Bybit) parseTransfer(transfer interface{}) *Transfer {
+	return &Transfer{
+		Id:             self.SafeString(transfer, "transferId", ""),
+		Currency:       self.SafeCurrencyCode(self.SafeString(transfer, "coin", "")),
+		Amount:         self.SafeFloat(transfer, "amount", 0),
+		FromAccount:    self.transferAccountType(self.SafeString(transfer, "fromAccountType", "")),
+		ToAccount:      self.transferAccountType(self.SafeString(transfer, "toAccountType", "")),
+		FromSubAccount: self.SafeString(transfer, "fromMemberId", ""),
+		ToSubAccount:   self.SafeString(transfer, "toMemberId", ""),
+		Status:         self.ParseTransferStatus(self.SafeString(transfer, "status", "")),
+		Timestamp:      self.SafeInteger(transfer, "timestamp", 0),
+		Info:           transfer,
+	}
+}
+
+func (self *Bybit) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"transferId":      self.Uuid(),
+		"coin":            code,
+		"amount":          self.Float64ToString(amount),
+		"fromAccountType": self.transferAccountId(fromAccount),
+		"toAccountType":   self.transferAccountId(toAccount),
+	}
+	response := self.ApiFunc("privateV5PostAssetTransferInterTransfer", self.Extend(request, params), nil, nil)
+	result = self.parseTransfer(request)
+	result.Id = self.SafeString(response["result"], "transferId", result.Id)
+	result.Currency = code
+	result.Status = "ok"
+	result.Timestamp = self.Milliseconds()
+	result.Info = response
+	return
+}
+
+func (self *Bybit) FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) (result []*Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{}
+	if code != "" {
+		request["coin"] = code
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFunc("privateV5GetAssetTransferQueryInterTransferList", self.Extend(request, params), nil, nil)
+	result = []*Transfer{}
+	for _, item := range self.derivativesList(response) {
+		result = append(result, self.parseTransfer(item))
+	}
+	return
+}
+
+// SubAccountTransfer 子账户 id 为 UID, 母账户的 UID 需要通过 SetUid 设置
+func (self *Bybit) SubAccountTransfer(code string, amount float64, fromSubAccount, toSubAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if (fromSubAccount == "" || toSubAccount == "") && self.Uid == "" {
+		self.RaiseException("ArgumentsRequired", self.Id+" subAccountTransfer requires uid for the master account")
+	}
+	fromMemberId, toMemberId := fromSubAccount, toSubAccount
+	if fromMemberId == "" {
+		fromMemberId = self.Uid
+	}
+	if toMemberId == "" {
+		toMemberId = self.Uid
+	}
+	fromAccount := self.SafeString(params, "fromAccount", AccountTypeSpot)
+	toAccount := self.SafeString(params, "toAccount", AccountTypeSpot)
+	query := self.Omit(self.Extend(params).(map[string]interface{}), []string{"fromAccount", "toAccount"})
+	request := map[string]interface{}{
+		"transferId":      self.Uuid(),
+		"coin":            code,
+		"amount":          self.Float64ToString(amount),
+		"fromMemberId":    fromMemberId,
+		"toMemberId":      toMemberId,
+		"fromAccountType": self.transferAccountId(fromAccount),
+		"toAccountType":   self.transferAccountId(toAccount),
+	}
+	response := self.ApiFunc("privateV5PostAssetTransferUniversalTransfer", self.Extend(request, query), nil, nil)
+	result = self.parseTransfer(request)
+	result.Id = self.SafeString(response["result"], "transferId", result.Id)
+	result.Currency = code
+	result.FromSubAccount = fromSubAccount
+	result.ToSubAccount = toSubAccount
+	result.Status = "ok"
+	result.Timestamp = self.Milliseconds()
+	result.Info = response
+	return
+}
+
+// FetchLedger 合约账户的流水, 统一账户需设置 Options["contractAccountType"] 为 UNIFIED.
+// 交易所限制查询范围为 7 天, 有 since 时取回 since 之后 7 天内的所有流水, 否则只取最新的一页
+func (self *Bybit) FetchLedger(code string, since int64, limit int64, params map[string]interface{}) (result []*LedgerEntry, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	method := "privateV5GetAccountContractTransactionLog"
+	request := map[string]interface{}{
+		"limit": 50,
+	}
+	if self.SafeString(self.Options, "contractAccountType", "CONTRACT") == "UNIFIED" {
+		method = "privateV5GetAccountTransactionLog"
+		request["accountType"] = "UNIFIED"
+	}
+	if code != "" {
+		request["currency"] = self.CurrencyId(code)
+	}
+	if since > 0 {
+		request["startTime"] = since
+		request["endTime"] = since + self.SafeInteger(self.Options, "fetchLedgerWindow", 604800000) - 1
+	}
+	result = []*LedgerEntry{}
+	for {
+		response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
+		for _, item := range self.derivativesList(response) {
+			result = append(result, self.parseLedgerEntry(item))
+		}
+		cursor := self.SafeString(response["result"], "nextPageCursor", "")
+		if since <= 0 || cursor == "" {
+			break
+		}
+		request["cursor"] = cursor
+	}
+	return self.LimitLedger(result, since, limit), nil
+}
+
+func (self *Bybit) parseLedgerEntry(item interface{}) *LedgerEntry {
+	change := self.SafeFloat(item, "change", 0)
+	after := self.SafeFloat(item, "cashBalance", 0)
+	entry := &LedgerEntry{
+		Id:          self.SafeString(item, "id", ""),
+		Direction:   "in",
+		Account:     AccountTypeSwap,
+		ReferenceId: self.SafeString(item, "tradeId", ""),
+		Type:        self.ParseLedgerType(self.SafeString(item, "type", "")),
+		Currency:    self.SafeCurrencyCode(self.SafeString(item, "currency", "")),
+		Amount:      change,
+		Before:      after - change,
+		After:       after,
+		Fee:         self.SafeFloat(item, "fee", 0),
+		Timestamp:   self.SafeInteger(item, "transactionTime", 0),
+		Info:        item,
+	}
+	if change < 0 {
+		entry.Direction = "out"
+		entry.Amount = -change
+	}
+	// NOTE: 不加载交易对, 和 Market 相反, 把 BTCUSDT 转换为 BTC/USDT
+	if marketId := self.SafeString(item, "symbol", ""); marketId != "" {
+		entry.Symbol = self.SymbolFromQuoteSuffix(marketId)
+	}
+	return entry
+}
+
+// FetchTradingFee params 中的 type 为 swap 时返回合约的费率, 默认现货
+func (self *Bybit) FetchTradingFee(symbol string, params map[string]interface{}) (result *TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	typ, query := self.AccountType(params, AccountTypeSpot)
+	request := map[string]interface{}{
+		"category": self.feeCategory(typ),
+		"symbol":   self.Market(symbol).Id,
+	}
+	response := self.ApiFunc("privateV5GetAccountFeeRate", self.Extend(request, query), nil, nil)
+	list := self.derivativesList(response)
+	if len(list) == 0 {
+		self.RaiseException("BadSymbol", self.Id+" fetchTradingFee no fee for "+symbol)
+	}
+	return self.parseTradingFee(list[0], symbol), nil
+}
+
+func (self *Bybit) FetchTradingFees(params map[string]interface{}) (result map[string]*TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	markets, err := self.FetchMarkets(nil)
+	if err != nil {
+		return nil, err
+	}
+	symbolsById := map[string]string{}
+	for _, market := range markets {
+		symbolsById[market.Id] = market.Symbol
+	}
+	typ, query := self.AccountType(params, AccountTypeSpot)
+	request := map[string]interface{}{
+		"category": self.feeCategory(typ),
+	}
+	response := self.ApiFunc("privateV5GetAccountFeeRate", self.Extend(request, query), nil, nil)
+	result = map[string]*TradingFee{}
+	for _, item := range self.derivativesList(response) {
+		symbol, ok := symbolsById[self.SafeString(item, "symbol", "")]
+		if !ok {
+			continue
+		}
+		result[symbol] = self.parseTradingFee(item, symbol)
+	}
+	return
+}
+
+func (self *Bybit) feeCategory(typ string) string {
+	if typ == AccountTypeSwap || typ == AccountTypeFutures {
+		return self.SafeString(self.Options, "derivativesCategory", "linear")
+	}
+	return "spot"
+}
+
+func (self *Bybit) parseTradingFee(item interface{}, symbol string) *TradingFee {
+	return &TradingFee{
+		Symbol: symbol,
+		Maker:  self.SafeFloat(item, "makerFeeRate", 0),
+		Taker:  self.SafeFloat(item, "takerFeeRate", 0),
+		Info:   item,
+	}
+}
+
+// FetchCurrencies 需要 apiKey
+func (self *Bybit) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFunc("privateV5GetAssetCoinQueryInfo", params, nil, nil)
+	rows, _ := self.SafeValue(response["result"], "rows", nil).([]interface{})
+	result = map[string]*Currency{}
+	for _, entry := range rows {
+		id := self.SafeString(entry, "coin", "")
+		code := self.SafeCurrencyCode(id)
+		currency := &Currency{
+			Id:   id,
+			Code: code,
+			Name: self.SafeString(entry, "name", ""),
+			Info: entry,
+		}
+		chains, _ := self.SafeValue(entry, "chains", nil).([]interface{})
+		for _, item := range chains {
+			self.AddCurrencyNetwork(currency, &CurrencyNetwork{
+				Id:            self.SafeString(item, "chain", ""),
+				Name:          self.SafeString(item, "chainType", ""),
+				Deposit:       self.SafeString(item, "chainDeposit", "") == "1",
+				Withdraw:      self.SafeString(item, "chainWithdraw", "") == "1",
+				Fee:           self.SafeFloat(item, "withdrawFee", 0),
+				FeeKnown:      self.SafeValue(item, "withdrawFee", nil) != nil,
+				WithdrawMin:   self.SafeFloat(item, "withdrawMin", 0),
+				DepositMin:    self.SafeFloat(item, "depositMin", 0),
+				Confirmations: self.SafeInteger(item, "confirmation", 0),
+				Precision:     int(self.SafeInteger(item, "minAccuracy", 0)),
+				Info:          item,
+			})
+		}
+		result[code] = currency
+	}
+	return result, nil
+}
+
+// FetchStatus 返回计划中和正在进行的维护, state 为 scheduled, ongoing 或 completed. fetchStatusDetails 为 true 且配置了 apiKey 时同时返回暂停充提的币种
+func (self *Bybit) FetchStatus(params map[string]interface{}) (result *ExchangeStatus, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFunc("publicV5GetSystemStatus", params, nil, nil)
+	list, _ := self.SafeValue(response["result"], "list", nil).([]interface{})
+	result = &ExchangeStatus{
+		Status:       StatusOk,
+		Updated:      self.Milliseconds(),
+		Maintenances: []*Maintenance{},
+		Info:         response,
+	}
+	for _, item := range list {
+		state := self.SafeString(item, "state", "")
+		if state != "scheduled" && state != "ongoing" {
+			continue
+		}
+		maintenance := &Maintenance{
+			Title: self.SafeString(item, "title", ""),
+			Start: self.SafeInteger(item, "begin", 0),
+			End:   self.SafeInteger(item, "end", 0),
+			Url:   self.SafeString(item, "href", ""),
+			Info:  item,
+		}
+		result.Maintenances = append(result.Maintenances, maintenance)
+		if state == "ongoing" {
+			result.Status = StatusMaintenance
+			result.Eta = maintenance.End
+			result.Url = maintenance.Url
+			result.Message = maintenance.Title
+		}
+	}
+	sort.Slice(result.Maintenances, func(i, j int) bool {
+		return result.Maintenances[i].Start < result.Maintenances[j].Start
+	})
+	if result.Status == StatusMaintenance {
+		return result, nil
+	}
+	err = self.FetchSuspensions(result, self.ApiKey != "")
+	return result, err
+}
+
+// FetchDepositAddress 交易所返回该币种所有链的地址, network 为空时取第一个
+func (self *Bybit) FetchDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"coin": code,
+	}
+	response := self.ApiFunc("privateV5GetAssetDepositQueryAddress", self.Extend(request, params), nil, nil)
+	chains := self.SafeValue(response["result"], "chains", []interface{}{}).([]interface{})
+	for _, item := range chains {
+		chain := self.SafeString(item, "chain", "")
+		if network == "" || chain == self.NetworkId(network) {
+			return &DepositAddress{
+				Currency: code,
+				Address:  self.SafeString(item, "addressDeposit", ""),
+				Tag:      self.SafeString(item, "tagDeposit", ""),
+				Network:  self.NetworkCode(chain),
+				Status:   "ok",
+				Info:     item,
+			}, nil
+		}
+	}
+	self.RaiseException("InvalidAddress", self.Id+" fetchDepositAddress no "+network+" address for "+code)
+	return
+}
+
+// CreateDepositAddress bybit 在获取充值地址时自动生成
+func (self *Bybit) CreateDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	return self.FetchDepositAddress(code, network, params)
+}
+
+func (self *Bybit) Withdraw(code string, amount float64, address string, tag string, network string, params map[string]interface{}) (result *Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if network == "" {
+		self.RaiseException("ArgumentsRequired", self.Id+" withdraw requires a network argument")
+	}
+	request := map[string]interface{}{
+		"coin":      code,
+		"chain":     self.NetworkId(network),
+		"address":   address,
+		"amount":    self.Float64ToString(amount),
+		"timestamp": self.Milliseconds(),
+	}
+	if tag != "" {
+		request["tag"] = tag
+	}
+	response := self.ApiFunc("privateV5PostAssetWithdrawCreate", self.Extend(request, params), nil, nil)
+	result = &Transaction{
+		Id:        self.SafeString(response["result"], "id", ""),
+		Type:      "withdrawal",
+		Currency:  code,
+		Network:   self.NetworkCode(self.NetworkId(network)),
+		Amount:    amount,
+		Address:   address,
+		Tag:       tag,
+		Status:    "pending",
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+	return
+}
+
+func (self *Bybit) FetchDeposits(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateV5GetAssetDepositQueryRecord", "deposit", code, since, limit, params), nil
+}
+
+func (self *Bybit) FetchWithdrawals(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateV5GetAssetWithdrawQueryRecord", "withdrawal", code, since, limit, params), nil
+}
+
+func (self *Bybit) fetchTransactions(method string, typ string, code string, since int64, limit int64, params map[string]interface{}) []*Transaction {
+	request := map[string]interface{}{}
+	if code != "" {
+		request["coin"] = code
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
+	rows := self.SafeValue(response["result"], "rows", []interface{}{}).([]interface{})
+	result := []*Transaction{}
+	for _, item := range rows {
+		transaction := &Transaction{
+			Id:       self.SafeString2(item, "withdrawId", "id", ""),
+			Txid:     self.SafeString(item, "txID", ""),
+			Type:     typ,
+			Currency: self.SafeCurrencyCode(self.SafeString(item, "coin", "")),
+			Network:  self.NetworkCode(self.SafeString(item, "chain", "")),
+			Amount:   self.SafeFloat(item, "amount", 0),
+			Address:  self.SafeString(item, "toAddress", ""),
+			Tag:      self.SafeString(item, "tag", ""),
+			Status:   self.parseTransactionStatus(self.SafeString(item, "status", "")),
+			Info:     item,
+		}
+		if typ == "deposit" {
+			transaction.Fee = self.SafeFloat(item, "depositFee", 0)
+			transaction.Timestamp = self.SafeInteger(item, "successAt", 0)
+		} else {
+			transaction.Fee = self.SafeFloat(item, "withdrawFee", 0)
+			transaction.Timestamp = self.SafeInteger(item, "createTime", 0)
+			transaction.Updated = self.SafeInteger(item, "updateTime", 0)
+		}
+		result = append(result, transaction)
+	}
+	return result
+}
+
+// 充值状态为数字, 提现状态为字符串
+func (self *Bybit) parseTransactionStatus(status string) string {
+	statuses := map[string]interface{}{
+		"0":                   "pending",
+		"1":                   "pending",
+		"2":                   "pending",
+		"3":                   "ok",
+		"4":                   "failed",
+		"SecurityCheck":       "pending",
+		"Pending":             "pending",
+		"success":             "ok",
+		"CancelByUser":        "canceled",
+		"Reject":              "failed",
+		"Fail":                "failed",
+		"BlockchainConfirmed": "ok",
+	}
+	return self.SafeString(statuses, status, status)
+}
+
 func (self *Bybit) FetchOpenOrders(symbol string, since int64, limit int64, params map[string]interface{}) (result []*Order, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -401,7 +1511,11 @@ func (self *Bybit) FetchOpenOrders(symbol string, since int64, limit int64, para
 		"symbol": market.Id,
 		"limit":  "500",
 	}
-	response := self.ApiFunc("privateGetPrivateOpenOrders", self.Extend(request, params), nil, nil)
+	stop, query := self.IsStopOrderRequest(params)
+	if stop {
+		request["orderCategory"] = 1
+	}
+	response := self.ApiFunc("privateGetPrivateOpenOrders", self.Extend(request, query), nil, nil)
 	rs := self.SafeValue(response, "result", map[string]interface{}{})
 	orders := self.SafeValue(rs, "list", []interface{}{})
 	return self.ToOrders(self.ParseOrders(orders, market, since, limit)), nil
@@ -439,6 +1553,7 @@ func (self *Bybit) ParseOrder(order interface{}, market interface{}) (result map
 		"remaining":     remaining,
 		"type":          type_,
 		"status":        status,
+		"stopPrice":     self.SafeFloat(order, "triggerPrice", 0),
 	}
 }
 
@@ -468,7 +1583,18 @@ func (self *Bybit) CreateOrder(symbol string, type_ string, side string, amount
 		"orderType":  strings.ToUpper(type_),
 		"orderPrice": self.Float64ToString(price),
 	}
-	response := self.ApiFunc("privatePostPrivateOrder", self.Extend(request, params), nil, nil)
+	orderLinkId, query := self.ParseClientOrderId(params, "orderLinkId")
+	request["orderLinkId"] = orderLinkId
+	trigger, query := self.ParseTriggerOrder(query)
+	if trigger != nil {
+		if trigger.TrailingPercent > 0 || trigger.StopLoss != nil || trigger.TakeProfit != nil {
+			self.RaiseException("NotSupported", self.Id+" createOrder supports stopPrice/takeProfitPrice trigger orders only")
+		}
+		// NOTE: orderCategory 为 1 表示止盈止损单, 触发方向由交易所根据当前价格判断
+		request["orderCategory"] = 1
+		request["triggerPrice"] = self.Float64ToString(trigger.TriggerPrice())
+	}
+	response := self.ApiFunc("privatePostPrivateOrder", self.Extend(request, query), nil, nil)
 	data := response["result"]
 	order := map[string]interface{}{
 		"id":            self.SafeString(data, "orderId", ""),
@@ -478,7 +1604,8 @@ func (self *Bybit) CreateOrder(symbol string, type_ string, side string, amount
 		"price":         self.SafeFloat(data, "orderPrice", 0),
 		"amount":        self.SafeFloat(data, "orderQty", 0),
 		"timestamp":     self.SafeInteger(data, "createTime", 0),
-		"clientOrderId": self.SafeString(data, "orderLinkId", ""),
+		"clientOrderId": self.SafeString(data, "orderLinkId", orderLinkId),
+		"stopPrice":     self.SafeFloat(data, "triggerPrice", 0),
 		"info":          data,
 	}
 	return self.ToOrder(order), nil
@@ -491,11 +1618,12 @@ func (self *Bybit) FetchOrder(id string, symbol string, params map[string]interf
 		}
 	}()
 	self.LoadMarkets()
-	request := map[string]interface{}{}
-	if id != "" {
-		request["orderId"] = id
+	stop, query := self.IsStopOrderRequest(params)
+	request := self.orderIdRequest(id, query)
+	if stop {
+		request["orderCategory"] = 1
 	}
-	response := self.ApiFunc("privateGetPrivateOrder", self.Extend(request, params), nil, nil)
+	response := self.ApiFunc("privateGetPrivateOrder", self.Extend(request, query), nil, nil)
 	order := self.ParseOrder(response["result"], nil)
 	return self.ToOrder(order), nil
 }
@@ -507,22 +1635,102 @@ func (self *Bybit) CancelOrder(id string, symbol string, params map[string]inter
 		}
 	}()
 	self.LoadMarkets()
+	stop, query := self.IsStopOrderRequest(params)
+	request := self.orderIdRequest(id, query)
+	if stop {
+		request["orderCategory"] = 1
+	}
+	response = self.ApiFunc("privatePostPrivateCancelOrder", self.Extend(request, query), nil, nil)
+	return response, nil
+}
+
+// params 中有 clientOrderId 时按 orderLinkId 查询或撤单, 否则按 orderId. 会从 params 中删除 clientOrderId
+func (self *Bybit) orderIdRequest(id string, params map[string]interface{}) map[string]interface{} {
 	request := map[string]interface{}{}
-	if id != "" {
+	if orderLinkId := self.SafeString(params, "clientOrderId", ""); orderLinkId != "" {
+		request["orderLinkId"] = orderLinkId
+	} else if id != "" {
 		request["orderId"] = id
 	}
-	response = self.ApiFunc("privatePostPrivateCancelOrder", self.Extend(request, params), nil, nil)
-	return response, nil
+	delete(params, "clientOrderId")
+	return request
+}
+
+// 接口只返回成功与否, 所以结果为 nil
+func (self *Bybit) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	stop, query := self.IsStopOrderRequest(params)
+	if stop {
+		request["orderCategory"] = 1
+	}
+	self.ApiFunc("privatePostPrivateCancelOrders", self.Extend(request, query), nil, nil)
+	return nil, nil
+}
+
+// 每次最多撤销 100 个订单, 返回的 list 中只包含撤单失败的订单
+func (self *Bybit) CancelOrders(ids []string, symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	batchSize := int(self.SafeInteger(self.Options, "cancelOrdersBatchSize", 100))
+	exact := self.Member(self.Exceptions, "exact")
+	result = []*OrderResult{}
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		request := map[string]interface{}{
+			"orderIds": strings.Join(ids[start:end], ","),
+		}
+		response := self.ApiFunc("privatePostPrivateCancelOrdersByIds", self.Extend(request, params), nil, nil)
+		rs := self.SafeValue(response, "result", map[string]interface{}{})
+		failed := map[string]string{}
+		for _, item := range self.SafeValue(rs, "list", []interface{}{}).([]interface{}) {
+			code := self.SafeString(item, "code", "0")
+			if code != "0" {
+				failed[self.SafeString(item, "orderId")] = code
+			}
+		}
+		for _, id := range ids[start:end] {
+			one := &OrderResult{
+				Id: id,
+			}
+			if code, ok := failed[id]; ok {
+				one.Error = self.ExactlyMatchedError(exact, code, self.Id+" cancel order "+id+" failed: "+code)
+			} else {
+				one.Order = self.CanceledOrder(id, symbol, nil)
+			}
+			result = append(result, one)
+		}
+	}
+	return result, nil
 }
 
 func (self *Bybit) Sign(path string, api string, method string, params map[string]interface{}, headers interface{}, body interface{}) (ret interface{}) {
 	//url := self.ImplodeHostname(self.Member(self.Member(self.Urls, "api"), api).(string)) + "/spot/v3/" + path
 	url := self.ImplodeHostname(self.DescribeJson.Get("urls.api").Get(api).String()) + "/spot/" + self.Version + "/" + path
-	if api == "public" {
+	if api == "publicV5" || api == "privateV5" {
+		url = self.ImplodeHostname(self.DescribeJson.Get("urls.api").Get(api).String()) + "/v5/" + path
+	}
+	if api == "public" || api == "publicV5" {
 		if len(params) > 0 {
 			url += "?" + self.Urlencode(params)
 		}
-	} else if api == "private" {
+	} else if api == "private" || api == "privateV5" {
 		recvWindow := self.DescribeJson.Get("options.recvWindow").String()
 		timestamp := self.Nonce()
 		payload := fmt.Sprintf("%d%s%s", timestamp, self.ApiKey, recvWindow)
@@ -543,6 +1751,9 @@ func (self *Bybit) Sign(path string, api string, method string, params map[strin
 			"X-BAPI-TIMESTAMP":   fmt.Sprint(timestamp),
 			"X-BAPI-RECV-WINDOW": recvWindow,
 		}, headers)
+		if api == "privateV5" && method == "POST" {
+			headers.(map[string]interface{})["Content-Type"] = "application/json"
+		}
 	}
 	return map[string]interface{}{
 		"url":     url,
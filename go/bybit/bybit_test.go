@@ -59,11 +59,26 @@ func loadApiKey(ex *Bybit) {
 
 func TestAll(t *testing.T) {
 	testFetchOrderBook(t)
+	//testFetchTicker(t)
+	//testFetchTickers(t)
+	//testFetchOHLCV(t)
+	//testSetLeverage(t)
+	//testFetchLeverageTiers(t)
+	//testFetchFundingRate(t)
+	//testFetchFundingRateHistory(t)
+	//testFetchOpenInterest(t)
+	//testFetchOpenInterestHistory(t)
+	//testFetchMarkOHLCV(t)
 	//testFetchBalance(t)
+	//testFetchLedger(t)
+	//testFetchStatus(t)
 	//order := testCreateOrder(t); _ = order
 	//testFetchOrder(t, "1241960757397043712")
+	//testFetchOrderByClientId(t, "3f2a9c0d5e6b4a1c8d7e9f0a1b2c3d4e")
 	//openOrders := testFetchOpenOrders(t); _ = openOrders
 	//testCancelOrder(t, "1241960757397043712")
+	//testCancelOrders(t, []string{"1241960757397043712"})
+	//testCancelAllOrders(t)
 }
 
 func testFetchOrderBook(t *testing.T) {
@@ -75,6 +90,31 @@ func testFetchOrderBook(t *testing.T) {
 	log.Println("##### FetchOrderBook:", symbol, ex.Json(orderbook))
 }
 
+func testFetchTicker(t *testing.T) {
+	ticker, err := ex.FetchTicker(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTicker:", symbol, ex.JsonIndent(ticker))
+}
+
+func testFetchTickers(t *testing.T) {
+	tickers, err := ex.FetchTickers([]string{symbol}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTickers:", ex.JsonIndent(tickers))
+}
+
+func testFetchOHLCV(t *testing.T) {
+	klines, err := ex.FetchOHLCV(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOHLCV:", symbol, ex.JsonIndent(klines))
+	log.Println("count:", len(klines))
+}
+
 func testFetchBalance(t *testing.T) {
 	// @ FetchBalance
 	balance, err := ex.FetchBalance(nil)
@@ -84,6 +124,25 @@ func testFetchBalance(t *testing.T) {
 	log.Println("##### FetchBalance:", ex.Json(balance))
 }
 
+func testFetchLedger(t *testing.T) {
+	// @ FetchLedger
+	ledger, err := ex.FetchLedger("USDT", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchLedger:", ex.JsonIndent(ledger))
+}
+
+func testFetchStatus(t *testing.T) {
+	// @ FetchStatus
+	status, err := ex.FetchStatus(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchStatus:", ex.JsonIndent(status))
+	log.Println("##### NextMaintenance:", ex.JsonIndent(status.NextMaintenance(ex.Milliseconds())))
+}
+
 func testCreateOrder(t *testing.T) *base.Order {
 	// @ CreateOrder
 	order, err := ex.CreateOrder(symbol, "limit", "buy", 0.001 /*amount*/, 10000 /*price*/, nil)
@@ -103,6 +162,15 @@ func testFetchOrder(t *testing.T, orderId string) {
 	log.Println("##### FetchOrder:", ex.JsonIndent(o))
 }
 
+func testFetchOrderByClientId(t *testing.T, clientOrderId string) {
+	// @ FetchOrder
+	o, err := ex.FetchOrder("", symbol, map[string]interface{}{"clientOrderId": clientOrderId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOrderByClientId:", ex.JsonIndent(o))
+}
+
 func testFetchOpenOrders(t *testing.T) []*base.Order {
 	// @ FetchOpenOrders
 	openOrders, err := ex.FetchOpenOrders(symbol, 0, 0, nil)
@@ -121,3 +189,77 @@ func testCancelOrder(t *testing.T, orderId string) {
 	}
 	log.Println("##### CancelOrder:", ex.JsonIndent(resp))
 }
+
+func testCancelOrders(t *testing.T, orderIds []string) {
+	// @ CancelOrders
+	results, err := ex.CancelOrders(orderIds, symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelOrders:", ex.JsonIndent(results))
+}
+
+func testCancelAllOrders(t *testing.T) {
+	// @ CancelAllOrders
+	results, err := ex.CancelAllOrders(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelAllOrders:", ex.JsonIndent(results))
+}
+
+func testFetchFundingRate(t *testing.T) {
+	fundingRate, err := ex.FetchFundingRate(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRate:", ex.JsonIndent(fundingRate))
+}
+
+func testFetchFundingRateHistory(t *testing.T) {
+	history, err := ex.FetchFundingRateHistory(symbol, 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRateHistory:", ex.JsonIndent(history))
+}
+
+func testFetchOpenInterest(t *testing.T) {
+	openInterest, err := ex.FetchOpenInterest(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOpenInterest:", ex.JsonIndent(openInterest))
+}
+
+func testFetchOpenInterestHistory(t *testing.T) {
+	history, err := ex.FetchOpenInterestHistory(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOpenInterestHistory:", ex.JsonIndent(history))
+}
+
+func testFetchMarkOHLCV(t *testing.T) {
+	klines, err := ex.FetchMarkOHLCV(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchMarkOHLCV:", symbol, ex.JsonIndent(klines))
+}
+
+func testSetLeverage(t *testing.T) {
+	resp, err := ex.SetLeverage(symbol, 5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### SetLeverage:", ex.JsonIndent(resp))
+}
+
+func testFetchLeverageTiers(t *testing.T) {
+	tiers, err := ex.FetchLeverageTiers([]string{symbol}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchLeverageTiers:", ex.JsonIndent(tiers))
+}
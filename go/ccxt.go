@@ -23,6 +23,50 @@ type IExchange = base.ExchangeInterface
 type ExchangeConfig = base.ExchangeConfig
 type Order = base.Order
 type Position = base.Position
+type OrderResult = base.OrderResult
+type OrderRequest = base.OrderRequest
+type EditOrderError = base.EditOrderError
+type TriggerOrder = base.TriggerOrder
+type AttachedOrder = base.AttachedOrder
+type AttachedOrderError = base.AttachedOrderError
+type OrderBook = base.OrderBook
+type OrderBookDelta = base.OrderBookDelta
+type FillEstimate = base.FillEstimate
+type OrderBookResult = base.OrderBookResult
+type TickerResult = base.TickerResult
+type OHLCVResult = base.OHLCVResult
+type FundingRate = base.FundingRate
+type FundingRateHistory = base.FundingRateHistory
+type OpenInterest = base.OpenInterest
+type LeverageTier = base.LeverageTier
+type MarginModification = base.MarginModification
+type MarginLoan = base.MarginLoan
+type BorrowInterest = base.BorrowInterest
+type MaxBorrowable = base.MaxBorrowable
+type MarginRisk = base.MarginRisk
+type Transfer = base.Transfer
+type Transaction = base.Transaction
+type LedgerEntry = base.LedgerEntry
+type TradingFee = base.TradingFee
+type Fee = base.Fee
+type DepositAddress = base.DepositAddress
+type Portfolio = base.Portfolio
+type PortfolioValuation = base.PortfolioValuation
+
+// FetchBalance 参数 "type" 的账户类型
+const (
+	AccountTypeSpot     = base.AccountTypeSpot
+	AccountTypeMargin   = base.AccountTypeMargin
+	AccountTypeIsolated = base.AccountTypeIsolated
+	AccountTypeFutures  = base.AccountTypeFutures
+	AccountTypeSwap     = base.AccountTypeSwap
+	AccountTypeFunding  = base.AccountTypeFunding
+)
+
+// NewPortfolio 多个交易所的余额以 quote 计价的估值
+func NewPortfolio(quote string) *Portfolio {
+	return base.NewPortfolio(quote)
+}
 
 func New(exchange string, config *base.ExchangeConfig) (ex IExchange, err error) {
 	switch exchange {
@@ -38,11 +38,31 @@ func (self *FuturesBinance) Describe() []byte {
         "fetchBidsAsks": true,
         "fetchTickers": true,
         "fetchOHLCV": true,
+        "fetchFundingRate": true,
+        "fetchFundingRateHistory": true,
+        "fetchOpenInterest": true,
+        "fetchOpenInterestHistory": true,
+        "fetchMarkOHLCV": true,
+        "fetchIndexOHLCV": true,
+        "fetchPremiumIndexOHLCV": true,
+        "setLeverage": true,
+        "setMarginMode": true,
+        "setPositionMode": true,
+        "addMargin": true,
+        "reduceMargin": true,
+        "fetchLeverageTiers": true,
         "fetchMyTrades": true,
         "fetchOrder": true,
         "fetchOrders": true,
         "fetchOpenOrders": true,
-        "fetchClosedOrders": true
+        "fetchClosedOrders": true,
+        "cancelAllOrders": true,
+        "cancelOrders": true,
+        "createOrders": true,
+        "editOrder": true,
+        "createStopOrder": true,
+        "createTrailingStopOrder": true,
+        "createAttachedOrders": true
     },
     "timeframes": {
         "1m": "1m",
@@ -65,7 +85,9 @@ func (self *FuturesBinance) Describe() []byte {
         "logo": "https://user-images.githubusercontent.com/1294454/29604020-d5483cdc-87ee-11e7-94c7-d1a8d9169293.jpg",
         "api": {
             "public": "https://fapi.binance.com/fapi",
-            "private": "https://fapi.binance.com/fapi"
+            "publicData": "https://fapi.binance.com/futures/data",
+            "private": "https://fapi.binance.com/fapi",
+            "sapi": "https://api.binance.com/sapi/v1"
         },
         "www": "https://www.binance.com",
         "doc": "https://github.com/binance-exchange/binance-official-api-docs/blob/master/rest-api.md",
@@ -76,6 +98,7 @@ func (self *FuturesBinance) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "ping",
                 "time",
@@ -85,13 +108,23 @@ func (self *FuturesBinance) Describe() []byte {
                 "historicalTrades",
                 "aggTrades",
                 "klines",
+                "markPriceKlines",
+                "indexPriceKlines",
+                "premiumIndexKlines",
                 "premiumIndex",
                 "fundingRate",
+                "openInterest",
                 "ticker/24hr",
                 "ticker/price",
                 "ticker/bookTicker"
             ]
         },
+        "publicData": {
+            "cacheable": true,
+            "get": [
+                "openInterestHist"
+            ]
+        },
         "private": {
             "get": [
                 "order",
@@ -104,21 +137,37 @@ func (self *FuturesBinance) Describe() []byte {
                 "v2/account",
                 "v2/positionRisk",
                 "userTrades",
-                "income"
+                "income",
+                "leverageBracket"
             ],
             "post": [
                 "order",
                 "order/test",
+                "batchOrders",
                 "leverage",
+                "marginType",
+                "positionSide/dual",
+                "positionMargin",
                 "listenKey"
             ],
             "delete": [
                 "order",
+                "allOpenOrders",
+                "batchOrders",
                 "listenKey"
             ],
             "put": [
+                "order",
                 "listenKey"
             ]
+        },
+        "sapi": {
+            "get": [
+                "futures/transfer"
+            ],
+            "post": [
+                "futures/transfer"
+            ]
         }
     },
     "fees": {
@@ -131,9 +180,25 @@ func (self *FuturesBinance) Describe() []byte {
     },
     "options": {
         "warnOnFetchOpenOrdersWithoutSymbol": true,
+        "fetchOHLCVLimit": 1500,
         "recvWindow": 5000,
         "timeDifference": 0,
-        "adjustForTimeDifference": false
+        "adjustForTimeDifference": false,
+        "cancelOrdersBatchSize": 10,
+        "createOrdersBatchSize": 5,
+        "accountTypes": ["swap"],
+        "fetchLedgerLimit": 1000,
+        "fetchLedgerByCode": false,
+        "ledgerTypes": {
+            "TRANSFER": "transfer",
+            "REALIZED_PNL": "pnl",
+            "FUNDING_FEE": "funding",
+            "COMMISSION": "fee",
+            "INSURANCE_CLEAR": "liquidation",
+            "REFERRAL_KICKBACK": "rebate",
+            "COMMISSION_REBATE": "rebate",
+            "API_REBATE": "rebate"
+        }
     },
     "exceptions": {
 		"exact": {
@@ -181,10 +246,15 @@ func (self *FuturesBinance) FetchBalance(params map[string]interface{}) (balance
 		}
 	}()
 
-	response := self.ApiFunc("privateGetV2Account", params, nil, nil)
+	typ, query := self.AccountType(params, AccountTypeSwap)
+	if typ != AccountTypeSwap && typ != AccountTypeFutures {
+		self.UnsupportedAccountType(typ)
+	}
+	response := self.ApiFunc("privateGetV2Account", query, nil, nil)
 
 	result := map[string]interface{}{
 		"info": response,
+		"type": typ,
 	}
 
 	balances := response["assets"].([]interface{})
@@ -196,6 +266,7 @@ func (self *FuturesBinance) FetchBalance(params map[string]interface{}) (balance
 		account["free"] = free
 		account["used"] = total - free
 		account["unrealPnl"] = self.SafeFloat(balance, "unrealizedProfit")
+		self.parseMarginFields(account, balance)
 		currency := self.SafeString(balance, "asset")
 		result[currency] = account
 	}
@@ -203,6 +274,16 @@ func (self *FuturesBinance) FetchBalance(params map[string]interface{}) (balance
 	return self.ParseBalance(result), nil
 }
 
+func (self *FuturesBinance) parseMarginFields(account map[string]interface{}, balance interface{}) {
+	account["walletBalance"] = self.SafeFloat(balance, "walletBalance", 0)
+	account["marginBalance"] = self.SafeFloat(balance, "marginBalance", 0)
+	account["availableWithdraw"] = self.SafeFloat(balance, "maxWithdrawAmount", 0)
+	account["initialMargin"] = self.SafeFloat(balance, "initialMargin", 0)
+	account["maintenanceMargin"] = self.SafeFloat(balance, "maintMargin", 0)
+	account["crossUnrealPnl"] = self.SafeFloat(balance, "crossUnPnl", 0)
+}
+
+// FetchBalanceV1 使用 v1 接口, used 为保证金之和. 一般使用 FetchBalance 即可
 func (self *FuturesBinance) FetchBalanceV1(params map[string]interface{}) (balanceResult *Account, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -210,10 +291,15 @@ func (self *FuturesBinance) FetchBalanceV1(params map[string]interface{}) (balan
 		}
 	}()
 
-	response := self.ApiFunc("privateGetAccount", params, nil, nil)
+	typ, query := self.AccountType(params, AccountTypeSwap)
+	if typ != AccountTypeSwap && typ != AccountTypeFutures {
+		self.UnsupportedAccountType(typ)
+	}
+	response := self.ApiFunc("privateGetAccount", query, nil, nil)
 
 	result := map[string]interface{}{
 		"info": response,
+		"type": typ,
 	}
 
 	balances := response["assets"].([]interface{})
@@ -225,6 +311,7 @@ func (self *FuturesBinance) FetchBalanceV1(params map[string]interface{}) (balan
 		account["used"] = used
 		account["free"] = total - used
 		account["unrealPnl"] = self.SafeFloat(balance, "unrealizedProfit")
+		self.parseMarginFields(account, balance)
 		currency := self.SafeString(balance, "asset")
 		result[currency] = account
 	}
@@ -247,6 +334,7 @@ func (self *FuturesBinance) FetchOrderBook(symbol string, limit int64, params ma
 	}
 	response := self.ApiFunc("publicGetDepth", self.Extend(request, params), nil, nil)
 	orderBook = self.ParseOrderBook(response, ToInteger(response["T"]), "bids", "asks", 0, 1)
+	orderBook.Nonce = self.SafeInteger(response, "lastUpdateId", 0)
 	return orderBook, nil
 }
 
@@ -303,6 +391,26 @@ func (self *FuturesBinance) ParseOHLCV(response interface{}) *OHLCV {
 }
 
 func (self *FuturesBinance) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchKlines("publicGetKlines", "symbol", symbol, timeframe, since, limit, params)
+}
+
+// 标记价格 K 线, 成交量为 0
+func (self *FuturesBinance) FetchMarkOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchKlines("publicGetMarkPriceKlines", "symbol", symbol, timeframe, since, limit, params)
+}
+
+// 指数价格 K 线, 成交量为 0
+func (self *FuturesBinance) FetchIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchKlines("publicGetIndexPriceKlines", "pair", symbol, timeframe, since, limit, params)
+}
+
+// 溢价指数 K 线, 成交量为 0
+func (self *FuturesBinance) FetchPremiumIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchKlines("publicGetPremiumIndexKlines", "symbol", symbol, timeframe, since, limit, params)
+}
+
+// 各种 K 线接口的返回格式相同, 指数价格 K 线的交易对参数为 pair
+func (self *FuturesBinance) fetchKlines(method string, key string, symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
@@ -311,7 +419,7 @@ func (self *FuturesBinance) FetchOHLCV(symbol, timeframe string, since int64, li
 	self.LoadMarkets()
 	market := self.Market(symbol)
 	request := map[string]interface{}{
-		"symbol":   market.Id,
+		key:        market.Id,
 		"interval": self.Timeframes[timeframe],
 	}
 	if since > 0 {
@@ -320,7 +428,7 @@ func (self *FuturesBinance) FetchOHLCV(symbol, timeframe string, since int64, li
 	if limit > 0 {
 		request["limit"] = limit
 	}
-	response := self.ApiFuncReturnList("publicGetKlines", self.Extend(request, params), nil, nil)
+	response := self.ApiFuncReturnList(method, self.Extend(request, params), nil, nil)
 	for _, item := range response {
 		klines = append(klines, self.ParseOHLCV(item))
 	}
@@ -369,6 +477,7 @@ func (self *FuturesBinance) ParseOrder(order interface{}, market interface{}) (r
 		"timestamp":     timestamp,
 		"datetime":      datetime,
 		"status":        status,
+		"stopPrice":     self.SafeFloat(order, "stopPrice", 0),
 		"info":          order,
 	}
 }
@@ -379,27 +488,122 @@ func (self *FuturesBinance) CreateOrder(symbol string, type_ string, side string
 			err = self.PanicToError(e)
 		}
 	}()
-	market := self.Market(symbol)
+	trigger, query := self.ParseTriggerOrder(params)
+	clientOrderId, query := self.ParseClientOrderId(query, "newClientOrderId")
+	request := self.createOrderRequest(self.Market(symbol), type_, side, amount, price, trigger)
+	request["newClientOrderId"] = clientOrderId
+	response := self.ApiFunc("privatePostOrder", self.Extend(request, query), nil, nil)
+	result = &Order{
+		Id:            fmt.Sprintf("%d", self.SafeInteger(response, "orderId")),
+		Symbol:        symbol,
+		Type:          type_,
+		Side:          side,
+		Status:        "open",
+		ClientOrderId: self.SafeString(response, "clientOrderId", clientOrderId),
+		StopPrice:     self.SafeFloat(response, "stopPrice"),
+		Info:          response,
+	}
+	// 附带的止损止盈在开仓订单之后单独下单
+	return self.CreateAttachedOrders(result, trigger, symbol, side, amount, nil)
+}
+
+// trigger 不为 nil 时转换为对应的条件单类型, 附带的止损止盈不在这里处理
+func (self *FuturesBinance) createOrderRequest(market *Market, type_ string, side string, amount float64, price float64, trigger *TriggerOrder) map[string]interface{} {
 	request := map[string]interface{}{
 		"symbol":   market.Id,
-		"quantity": amount,
+		"quantity": self.AmountToPrecision(market.Symbol, amount),
 		"side":     strings.ToUpper(side),
 		"type":     strings.ToUpper(type_),
 	}
 	if type_ == "limit" {
-		request["price"] = price
+		request["price"] = self.PriceToPrecision(market.Symbol, price)
 		request["timeInForce"] = "GTC"
 	}
-	response := self.ApiFunc("privatePostOrder", self.Extend(request, params), nil, nil)
-	return &Order{
-		Id:            fmt.Sprintf("%d", self.SafeInteger(response, "orderId")),
-		Symbol:        symbol,
-		Type:          type_,
-		Side:          side,
-		Status:        "open",
-		ClientOrderId: self.SafeString(response, "clientOrderId"),
-		Info:          response,
-	}, nil
+	if trigger == nil || trigger.IsAttached() {
+		return request
+	}
+	if trigger.TrailingPercent > 0 {
+		delete(request, "price")
+		delete(request, "timeInForce")
+		request["type"] = "TRAILING_STOP_MARKET"
+		// NOTE: callbackRate 的单位为百分比, 精度为 0.1
+		request["callbackRate"], _ = DecimalToPrecision(trigger.TrailingPercent, Round, 1, DecimalPlaces, NoPadding)
+		if trigger.TriggerPrice() > 0 {
+			request["activationPrice"] = self.PriceToPrecision(market.Symbol, trigger.TriggerPrice())
+		}
+		return request
+	}
+	request["stopPrice"] = self.PriceToPrecision(market.Symbol, trigger.TriggerPrice())
+	switch {
+	case trigger.IsTakeProfit() && type_ == "limit":
+		request["type"] = "TAKE_PROFIT"
+	case trigger.IsTakeProfit():
+		request["type"] = "TAKE_PROFIT_MARKET"
+	case type_ == "limit":
+		request["type"] = "STOP"
+	default:
+		request["type"] = "STOP_MARKET"
+	}
+	delete(request, "timeInForce")
+	return request
+}
+
+func (self *FuturesBinance) CreateOrders(orders []*OrderRequest, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	exact := self.SafeValue(self.Exceptions, "exact", nil)
+	batchSize := int(self.SafeInteger(self.Options, "createOrdersBatchSize", 5))
+	markets := map[string]*Market{}
+	result = self.CreateOrdersInBatches(orders, batchSize, func(order *OrderRequest) interface{} {
+		market := self.Market(order.Symbol)
+		markets[market.Id] = market
+		trigger, query := self.ParseTriggerOrder(self.Extend(params, order.Params).(map[string]interface{}))
+		if trigger != nil && (trigger.StopLoss != nil || trigger.TakeProfit != nil) {
+			self.RaiseException("NotSupported", self.Id+" createOrders does not support attached stopLoss/takeProfit")
+		}
+		clientOrderId, query := self.ParseClientOrderId(query, "newClientOrderId")
+		request := self.Extend(self.createOrderRequest(market, order.Type, order.Side, order.Amount, order.Price, trigger), query).(map[string]interface{})
+		request["newClientOrderId"] = clientOrderId
+		// NOTE: batchOrders 中的值都要求为字符串, 浮点数不能用科学计数法
+		for k, v := range request {
+			if f, ok := v.(float64); ok {
+				request[k] = self.Float64ToString(f)
+			} else {
+				request[k] = fmt.Sprintf("%v", v)
+			}
+		}
+		return request
+	}, func(requests []interface{}) []*OrderResult {
+		request := map[string]interface{}{
+			"batchOrders": self.Json(requests),
+		}
+		response := self.ApiFuncReturnList("privatePostBatchOrders", request, nil, nil)
+		batch := []*OrderResult{}
+		for _, item := range response {
+			code := self.SafeString(item, "code")
+			if code != "" && code != "200" {
+				batch = append(batch, &OrderResult{
+					Error: self.ExactlyMatchedError(exact, code, self.Id+" "+self.Json(item)),
+				})
+				continue
+			}
+			var market interface{}
+			if m, ok := markets[self.SafeString(item, "symbol")]; ok {
+				market = m
+			}
+			order := self.ToOrder(self.ParseOrder(item, market))
+			batch = append(batch, &OrderResult{
+				Id:            order.Id,
+				ClientOrderId: order.ClientOrderId,
+				Order:         order,
+			})
+		}
+		return batch
+	})
+	return result, nil
 }
 
 func (self *FuturesBinance) FetchOrder(id string, symbol string, params map[string]interface{}) (result *Order, err error) {
@@ -412,10 +616,8 @@ func (self *FuturesBinance) FetchOrder(id string, symbol string, params map[stri
 	request := map[string]interface{}{
 		"symbol": market.Id,
 	}
-	if id != "" {
-		request["orderId"] = id
-	}
-	response := self.ApiFunc("privateGetOrder", self.Extend(request, params), nil, nil)
+	query := self.orderIdRequest(request, id, params)
+	response := self.ApiFunc("privateGetOrder", self.Extend(request, query), nil, nil)
 	return self.ToOrder(self.ParseOrder(response, market)), nil
 }
 
@@ -426,13 +628,26 @@ func (self *FuturesBinance) FetchOpenOrders(symbol string, since int64, limit in
 		}
 	}()
 	request := map[string]interface{}{}
-	var market *Market
+	var market interface{}
 	if symbol != "" {
-		market = self.Market(symbol)
-		request["symbol"] = market.Id
+		m := self.Market(symbol)
+		request["symbol"] = m.Id
+		market = m
+	}
+	// NOTE: 条件单和普通订单在同一个接口查询, stop 为 true 时只返回条件单
+	stop, query := self.IsStopOrderRequest(params)
+	response := self.ApiFuncReturnList("privateGetOpenOrders", self.Extend(request, query), nil, nil)
+	result = self.ToOrders(self.ParseOrders(response, market, since, limit))
+	if stop {
+		orders := []*Order{}
+		for _, order := range result {
+			if order.StopPrice > 0 || strings.HasPrefix(order.Type, "trailing") {
+				orders = append(orders, order)
+			}
+		}
+		result = orders
 	}
-	response := self.ApiFuncReturnList("privateGetOpenOrders", self.Extend(request, params), nil, nil)
-	return self.ToOrders(self.ParseOrders(response, market, since, limit)), nil
+	return result, nil
 }
 
 func (self *FuturesBinance) CancelOrder(id string, symbol string, params map[string]interface{}) (response interface{}, err error) {
@@ -445,11 +660,119 @@ func (self *FuturesBinance) CancelOrder(id string, symbol string, params map[str
 	request := map[string]interface{}{
 		"symbol": market.Id,
 	}
-	if id != "" {
+	_, query := self.IsStopOrderRequest(params)
+	query = self.orderIdRequest(request, id, query)
+	response = self.ApiFunc("privateDeleteOrder", self.Extend(request, query), nil, nil)
+	return response, nil
+}
+
+// params 中有 clientOrderId 时按 origClientOrderId 查询或撤单, 否则按 orderId. 返回去掉 clientOrderId 后的 params
+func (self *FuturesBinance) orderIdRequest(request map[string]interface{}, id string, params map[string]interface{}) map[string]interface{} {
+	query := self.Extend(params).(map[string]interface{})
+	if clientOrderId := self.SafeString(query, "clientOrderId", ""); clientOrderId != "" {
+		request["origClientOrderId"] = clientOrderId
+	} else if id != "" {
 		request["orderId"] = id
 	}
-	response = self.ApiFunc("privateDeleteOrder", self.Extend(request, params), nil, nil)
-	return response, nil
+	delete(query, "clientOrderId")
+	return query
+}
+
+// 接口只返回成功与否, 所以先查询挂单作为撤销结果
+func (self *FuturesBinance) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	// NOTE: 撤销全部挂单的接口会同时撤销普通订单, 只撤条件单时逐个撤销
+	if stop, _ := self.IsStopOrderRequest(params); stop {
+		return self.Exchange.CancelAllOrders(symbol, params)
+	}
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	orders, err := self.FetchOpenOrders(symbol, 0, 0, self.Extend(params).(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	self.ApiFunc("privateDeleteAllOpenOrders", self.Extend(request, params), nil, nil)
+	return self.CanceledOrderResults(orders), nil
+}
+
+func (self *FuturesBinance) CancelOrders(ids []string, symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	market := self.Market(symbol)
+	exact := self.SafeValue(self.Exceptions, "exact", nil)
+	batchSize := int(self.SafeInteger(self.Options, "cancelOrdersBatchSize", 10))
+	result = []*OrderResult{}
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		orderIdList := []interface{}{}
+		for _, id := range ids[start:end] {
+			orderIdList = append(orderIdList, ToInteger(id))
+		}
+		request := map[string]interface{}{
+			"symbol":      market.Id,
+			"orderIdList": self.Json(orderIdList),
+		}
+		response := self.ApiFuncReturnList("privateDeleteBatchOrders", self.Extend(request, params), nil, nil)
+		// NOTE: 返回的列表和请求的 orderIdList 顺序一致, 失败的订单为 {"code": -2011, "msg": "..."}
+		for i, item := range response {
+			id := self.SafeString(item, "orderId")
+			if id == "" && start+i < end {
+				id = ids[start+i]
+			}
+			one := &OrderResult{
+				Id: id,
+			}
+			code := self.SafeString(item, "code")
+			if code != "" && code != "200" {
+				one.Error = self.ExactlyMatchedError(exact, code, self.Id+" "+self.Json(item))
+			} else {
+				one.Order = self.ToOrder(self.ParseOrder(item, market))
+				one.ClientOrderId = one.Order.ClientOrderId
+			}
+			result = append(result, one)
+		}
+	}
+	return result, nil
+}
+
+// 只支持修改限价单的价格和数量, 其他类型先撤单再下单
+func (self *FuturesBinance) EditOrder(id string, symbol string, type_ string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if type_ != "limit" {
+		return self.Exchange.EditOrder(id, symbol, type_, side, amount, price, params)
+	}
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol":   market.Id,
+		"side":     strings.ToUpper(side),
+		"quantity": amount,
+		"price":    price,
+	}
+	query := self.orderIdRequest(request, id, params)
+	response := self.ApiFunc("privatePutOrder", self.Extend(request, query), nil, nil)
+	return self.ToOrder(self.ParseOrder(response, market)), nil
 }
 
 func (self *FuturesBinance) FetchMarkPrice(symbol string, params map[string]interface{}) (markPrice *MarkPrice, err error) {
@@ -473,6 +796,233 @@ func (self *FuturesBinance) FetchMarkPrice(symbol string, params map[string]inte
 	}, nil
 }
 
+// NOTE: 币安只返回当前周期的资金费率, 没有预测费率
+func (self *FuturesBinance) FetchFundingRate(symbol string, params map[string]interface{}) (fundingRate *FundingRate, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	response := self.ApiFunc("publicGetPremiumIndex", self.Extend(request, params), nil, nil)
+	return &FundingRate{
+		Symbol:           symbol,
+		FundingRate:      self.SafeFloat(response, "lastFundingRate", 0),
+		FundingTimestamp: self.SafeInteger(response, "nextFundingTime", 0),
+		MarkPrice:        self.SafeFloat(response, "markPrice", 0),
+		IndexPrice:       self.SafeFloat(response, "indexPrice", 0),
+		Timestamp:        self.SafeInteger(response, "time", 0),
+		Info:             response,
+	}, nil
+}
+
+func (self *FuturesBinance) FetchFundingRateHistory(symbol string, since int64, limit int64, params map[string]interface{}) (result []*FundingRateHistory, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList("publicGetFundingRate", self.Extend(request, params), nil, nil)
+	result = []*FundingRateHistory{}
+	for _, item := range response {
+		result = append(result, &FundingRateHistory{
+			Symbol:      symbol,
+			FundingRate: self.SafeFloat(item, "fundingRate", 0),
+			Timestamp:   self.SafeInteger(item, "fundingTime", 0),
+			Info:        item,
+		})
+	}
+	return result, nil
+}
+
+// 当前持仓量只有张数
+func (self *FuturesBinance) FetchOpenInterest(symbol string, params map[string]interface{}) (openInterest *OpenInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	response := self.ApiFunc("publicGetOpenInterest", self.Extend(request, params), nil, nil)
+	return &OpenInterest{
+		Symbol:    symbol,
+		Amount:    self.SafeFloat(response, "openInterest", 0),
+		Timestamp: self.SafeInteger(response, "time", 0),
+		Info:      response,
+	}, nil
+}
+
+// NOTE: 历史持仓量只能查询最近 30 天, timeframe 支持 5m 15m 30m 1h 2h 4h 6h 12h 1d
+func (self *FuturesBinance) FetchOpenInterestHistory(symbol string, timeframe string, since int64, limit int64, params map[string]interface{}) (result []*OpenInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+		"period": self.Timeframes[timeframe],
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList("publicDataGetOpenInterestHist", self.Extend(request, params), nil, nil)
+	result = []*OpenInterest{}
+	for _, item := range response {
+		result = append(result, &OpenInterest{
+			Symbol:    symbol,
+			Amount:    self.SafeFloat(item, "sumOpenInterest", 0),
+			Value:     self.SafeFloat(item, "sumOpenInterestValue", 0),
+			Timestamp: self.SafeInteger(item, "timestamp", 0),
+			Info:      item,
+		})
+	}
+	return result, nil
+}
+
+func (self *FuturesBinance) SetLeverage(symbol string, leverage float64, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	// NOTE: 杠杆只能为整数, 不截断以免设置的杠杆和预期不一致
+	if leverage != math.Trunc(leverage) {
+		self.RaiseException("BadRequest", fmt.Sprintf("%s setLeverage leverage must be an integer: %v", self.Id, leverage))
+	}
+	request := map[string]interface{}{
+		"symbol":   self.Market(symbol).Id,
+		"leverage": int64(leverage),
+	}
+	return self.ApiFunc("privatePostLeverage", self.Extend(request, params), nil, nil), nil
+}
+
+// NOTE: 已经是目标模式时交易所返回 -4046 错误, 有仓位或挂单时不能切换
+func (self *FuturesBinance) SetMarginMode(symbol string, marginMode string, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	marginType := "CROSSED"
+	if self.CheckMarginMode(marginMode) == "isolated" {
+		marginType = "ISOLATED"
+	}
+	request := map[string]interface{}{
+		"symbol":     self.Market(symbol).Id,
+		"marginType": marginType,
+	}
+	return self.ApiFunc("privatePostMarginType", self.Extend(request, params), nil, nil), nil
+}
+
+// 持仓模式对账户下所有交易对生效
+func (self *FuturesBinance) SetPositionMode(hedged bool, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"dualSidePosition": fmt.Sprintf("%v", hedged),
+	}
+	return self.ApiFunc("privatePostPositionSideDual", self.Extend(request, params), nil, nil), nil
+}
+
+func (self *FuturesBinance) AddMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	return self.modifyMargin(symbol, "add", amount, params)
+}
+
+func (self *FuturesBinance) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	return self.modifyMargin(symbol, "reduce", amount, params)
+}
+
+// 双向持仓时需要在 params 中指定 positionSide 为 LONG 或 SHORT
+func (self *FuturesBinance) modifyMargin(symbol string, type_ string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"symbol":       self.Market(symbol).Id,
+		"positionSide": "BOTH",
+		"amount":       amount,
+		"type":         1,
+	}
+	if type_ == "reduce" {
+		request["type"] = 2
+	}
+	// {"amount": 100.0, "code": 200, "msg": "Successfully modify position margin.", "type": 1}
+	response := self.ApiFunc("privatePostPositionMargin", self.Extend(request, params), nil, nil)
+	return &MarginModification{
+		Symbol: symbol,
+		Type:   type_,
+		Amount: self.SafeFloat(response, "amount", amount),
+		Info:   response,
+	}, nil
+}
+
+// 结果的 key 为 symbol. symbols 为空时返回所有交易对, 因为没有加载市场信息, key 为交易所的 id, 如 BTCUSDT
+func (self *FuturesBinance) FetchLeverageTiers(symbols []string, params map[string]interface{}) (result map[string][]*LeverageTier, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbolsById := map[string]string{}
+	for _, symbol := range symbols {
+		symbolsById[self.Market(symbol).Id] = symbol
+	}
+	// NOTE: 指定 symbol 时返回单个对象, 统一查询所有交易对后过滤
+	response := self.ApiFuncReturnList("privateGetLeverageBracket", params, nil, nil)
+	result = map[string][]*LeverageTier{}
+	for _, item := range response {
+		marketId := self.SafeString(item, "symbol")
+		symbol, ok := symbolsById[marketId]
+		if !ok {
+			if len(symbols) > 0 {
+				continue
+			}
+			symbol = marketId
+		}
+		tiers := []*LeverageTier{}
+		for _, bracket := range self.SafeValue(item, "brackets", []interface{}{}).([]interface{}) {
+			tiers = append(tiers, &LeverageTier{
+				Tier:                  self.SafeInteger(bracket, "bracket", 0),
+				Symbol:                symbol,
+				MinNotional:           self.SafeFloat(bracket, "notionalFloor", 0),
+				MaxNotional:           self.SafeFloat(bracket, "notionalCap", 0),
+				MaintenanceMarginRate: self.SafeFloat(bracket, "maintMarginRatio", 0),
+				MaxLeverage:           self.SafeFloat(bracket, "initialLeverage", 0),
+				Info:                  bracket,
+			})
+		}
+		result[symbol] = tiers
+	}
+	return result, nil
+}
+
+// symbol 为空时返回所有有持仓的交易对, symbol 由交易所 id 按计价货币拆分
 func (self *FuturesBinance) FetchPositions(symbol string, params map[string]interface{}) (result []*Position, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -480,41 +1030,196 @@ func (self *FuturesBinance) FetchPositions(symbol string, params map[string]inte
 		}
 	}()
 	request := map[string]interface{}{}
-	market := self.Market(symbol)
-	request["symbol"] = market.Id
+	marketId := ""
+	if symbol != "" {
+		marketId = self.Market(symbol).Id
+		request["symbol"] = marketId
+	}
 	response := self.ApiFuncReturnList("privateGetV2PositionRisk", self.Extend(request, params), nil, nil)
 
+	result = []*Position{}
 	for _, item := range response {
-		if self.SafeString(item, "symbol") != market.Id {
+		id := self.SafeString(item, "symbol")
+		if symbol != "" && id != marketId {
 			continue
 		}
-		amount := self.SafeFloat(item, "positionAmt")
-		pos := &Position{
-			Symbol:     symbol,
-			Side:       "long",
-			Leverage:   self.SafeFloat(item, "leverage", 0),
-			Amount:     math.Abs(amount),
-			UsedAmount: 0,
-			Price:      self.SafeFloat(item, "entryPrice", 0),
-			RealPnl:    0,
-			UnrealPnl:  self.SafeFloat(item, "unRealizedProfit", 0),
-			Info:       item,
-		}
-		if strings.ToLower(self.SafeString(item, "positionSide")) == "both" {
-			if amount < 0 {
-				pos.Side = "short"
-			}
-		} else {
-			pos.Side = strings.ToLower(self.SafeString(item, "positionSide"))
+		pos := self.parsePosition(item)
+		if symbol != "" {
+			pos.Symbol = symbol
+		} else if pos.Amount == 0 {
+			continue
 		}
 		result = append(result, pos)
 	}
 	return
 }
 
+// NOTE: v2/positionRisk 没有维持保证金, 起始保证金按仓位价值和杠杆计算
+func (self *FuturesBinance) parsePosition(item interface{}) *Position {
+	amount := self.SafeFloat(item, "positionAmt")
+	leverage := self.SafeFloat(item, "leverage", 0)
+	notional := math.Abs(self.SafeFloat(item, "notional", 0))
+	timestamp := self.SafeInteger(item, "updateTime", 0)
+	pos := &Position{
+		Symbol:           self.SymbolFromQuoteSuffix(self.SafeString(item, "symbol")),
+		Side:             "long",
+		Leverage:         leverage,
+		Amount:           math.Abs(amount),
+		UsedAmount:       0,
+		Price:            self.SafeFloat(item, "entryPrice", 0),
+		RealPnl:          0,
+		UnrealPnl:        self.SafeFloat(item, "unRealizedProfit", 0),
+		MarkPrice:        self.SafeFloat(item, "markPrice", 0),
+		LiquidationPrice: self.SafeFloat(item, "liquidationPrice", 0),
+		Notional:         notional,
+		MarginMode:       strings.ToLower(self.SafeString(item, "marginType", "cross")),
+		ContractSize:     1,
+		Timestamp:        timestamp,
+		Datetime:         self.Iso8601(timestamp),
+		Info:             item,
+	}
+	if pos.MarginMode == "crossed" {
+		pos.MarginMode = "cross"
+	}
+	// U 本位合约以 quote 结算
+	if i := strings.Index(pos.Symbol, "/"); i >= 0 {
+		pos.Settle = pos.Symbol[i+1:]
+	}
+	if leverage > 0 {
+		pos.InitialMargin = notional / leverage
+	}
+	if strings.ToLower(self.SafeString(item, "positionSide")) == "both" {
+		if amount < 0 {
+			pos.Side = "short"
+		}
+	} else {
+		pos.Side = strings.ToLower(self.SafeString(item, "positionSide"))
+	}
+	return pos
+}
+
+// Transfer 只支持现货账户和 U 本位合约账户之间划转
+func (self *FuturesBinance) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	from, _ := self.AccountType(map[string]interface{}{"type": fromAccount}, "")
+	to, _ := self.AccountType(map[string]interface{}{"type": toAccount}, "")
+	request := map[string]interface{}{
+		"asset":  code,
+		"amount": self.Float64ToString(amount),
+	}
+	if from == AccountTypeSpot && to == AccountTypeSwap {
+		request["type"] = 1
+	} else if from == AccountTypeSwap && to == AccountTypeSpot {
+		request["type"] = 2
+	} else {
+		self.RaiseException("NotSupported", self.Id+" transfer supports spot and swap accounts only")
+	}
+	response := self.ApiFunc("sapiPostFuturesTransfer", self.Extend(request, params), nil, nil)
+	result = &Transfer{
+		Id:          fmt.Sprint(self.SafeInteger(response, "tranId", 0)),
+		Currency:    code,
+		Amount:      amount,
+		FromAccount: from,
+		ToAccount:   to,
+		Status:      "ok",
+		Timestamp:   self.Milliseconds(),
+		Info:        response,
+	}
+	return
+}
+
+// FetchTransfers 交易所要求给出币种, since 为 0 时查询最近 30 天
+func (self *FuturesBinance) FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) (result []*Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if code == "" {
+		self.RaiseException("ArgumentsRequired", self.Id+" fetchTransfers requires a code argument")
+	}
+	if since <= 0 {
+		since = self.Milliseconds() - 30*24*3600*1000
+	}
+	request := map[string]interface{}{
+		"asset":     code,
+		"startTime": since,
+	}
+	if limit > 0 {
+		request["size"] = limit
+	}
+	response := self.ApiFunc("sapiGetFuturesTransfer", self.Extend(request, params), nil, nil)
+	result = []*Transfer{}
+	for _, row := range self.SafeValue(response, "rows", []interface{}{}).([]interface{}) {
+		transfer := &Transfer{
+			Id:          fmt.Sprint(self.SafeInteger(row, "tranId", 0)),
+			Currency:    self.SafeCurrencyCode(self.SafeString(row, "asset", "")),
+			Amount:      self.SafeFloat(row, "amount", 0),
+			FromAccount: AccountTypeSpot,
+			ToAccount:   AccountTypeSwap,
+			Status:      self.ParseTransferStatus(self.SafeString(row, "status", "")),
+			Timestamp:   self.SafeInteger(row, "timestamp", 0),
+			Info:        row,
+		}
+		if self.SafeString(row, "type", "") == "2" {
+			transfer.FromAccount, transfer.ToAccount = AccountTypeSwap, AccountTypeSpot
+		}
+		result = append(result, transfer)
+	}
+	return
+}
+
+// FetchLedger 资金流水, 包括已实现盈亏, 资金费, 手续费和返佣等. since 为 0 时交易所返回最近 7 天.
+// 交易所不支持按币种查询, code 在本地过滤, LedgerIterator 按 fetchLedgerByCode 选项用未过滤的页翻页
+func (self *FuturesBinance) FetchLedger(code string, since int64, limit int64, params map[string]interface{}) (result []*LedgerEntry, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList("privateGetIncome", self.Extend(request, params), nil, nil)
+	result = []*LedgerEntry{}
+	for _, item := range response {
+		currency := self.SafeCurrencyCode(self.SafeString(item, "asset", ""))
+		if code != "" && currency != code {
+			continue
+		}
+		amount := self.SafeFloat(item, "income", 0)
+		entry := &LedgerEntry{
+			Id:          self.SafeString(item, "tranId", ""),
+			Direction:   "in",
+			Account:     AccountTypeSwap,
+			ReferenceId: self.SafeString(item, "tradeId", ""),
+			Type:        self.ParseLedgerType(self.SafeString(item, "incomeType", "")),
+			Currency:    currency,
+			Symbol:      self.SymbolFromQuoteSuffix(self.SafeString(item, "symbol", "")),
+			Amount:      amount,
+			Timestamp:   self.SafeInteger(item, "time", 0),
+			Info:        item,
+		}
+		if amount < 0 {
+			entry.Direction = "out"
+			entry.Amount = -amount
+		}
+		result = append(result, entry)
+	}
+	return self.SortLedger(result), nil
+}
+
 func (self *FuturesBinance) Sign(path string, api string, method string, params map[string]interface{}, headers interface{}, body interface{}) (ret interface{}) {
 	var url string
-	if strings.HasPrefix(path, "v2/") {
+	if strings.HasPrefix(path, "v2/") || api == "publicData" || api == "sapi" {
 		url = self.Urls["api"].(map[string]interface{})[api].(string) + "/" + path
 	} else {
 		url = self.Urls["api"].(map[string]interface{})[api].(string) + fmt.Sprintf("/%s/%s", self.Version, path)
@@ -525,7 +1230,7 @@ func (self *FuturesBinance) Sign(path string, api string, method string, params
 			"X-MBX-APIKEY": self.ApiKey,
 			"Content-Type": "application/x-www-form-urlencoded",
 		}
-	} else if api == "private" {
+	} else if api == "private" || api == "sapi" {
 		self.CheckRequiredCredentials()
 		query := self.Urlencode(self.Extend(map[string]interface{}{
 			"timestamp":  self.Nonce(),
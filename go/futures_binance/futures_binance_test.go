@@ -62,11 +62,22 @@ func TestAll(t *testing.T) {
 	//testFetchTicker(t)
 	//testFetchOHLCV(t)
 	//testFetchBalance(t)
+	//testFetchLedger(t)
 	//order := testCreateOrder(t); _ = order
+	//testCreateOrders(t)
 	//testFetchOrder(t, "75283408648")
 	//testFetchOpenOrders(t)
 	//testCancelOrder(t, "75281111572")
+	//testCancelOrders(t, []string{"75281111572"})
+	//testCancelAllOrders(t)
 	//testFetchMarkPrice(t)
+	//testSetLeverage(t)
+	//testFetchLeverageTiers(t)
+	//testFetchFundingRate(t)
+	//testFetchFundingRateHistory(t)
+	//testFetchOpenInterest(t)
+	//testFetchOpenInterestHistory(t)
+	//testFetchMarkOHLCV(t)
 	//testFetchPositions(t)
 }
 
@@ -105,6 +116,22 @@ func testFetchBalance(t *testing.T) {
 	log.Println("##### FetchBalance:", ex.Json(balance))
 }
 
+func testFetchLedger(t *testing.T) {
+	// @ FetchLedger
+	ledger, err := ex.FetchLedger("", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchLedger:", ex.JsonIndent(ledger))
+	// @ LedgerRange
+	since := ex.Milliseconds() - 30*24*3600*1000
+	ledger, err = ex.LedgerRange("", since, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### LedgerRange:", len(ledger))
+}
+
 func testCreateOrder(t *testing.T) *base.Order {
 	// @ CreateOrder
 	order, err := ex.CreateOrder(symbol, "limit", "buy", 0.001 /*amount*/, 10000 /*price*/, nil)
@@ -115,6 +142,19 @@ func testCreateOrder(t *testing.T) *base.Order {
 	return order
 }
 
+func testCreateOrders(t *testing.T) []*base.OrderResult {
+	// @ CreateOrders
+	results, err := ex.CreateOrders([]*base.OrderRequest{
+		{Symbol: symbol, Type: "limit", Side: "buy", Amount: 0.001, Price: 10000},
+		{Symbol: symbol, Type: "limit", Side: "buy", Amount: 0.001, Price: 10001},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CreateOrders:", symbol, ex.JsonIndent(results))
+	return results
+}
+
 func testFetchOrder(t *testing.T, orderId string) {
 	// @ FetchOrder
 	o, err := ex.FetchOrder(orderId, symbol, nil)
@@ -152,10 +192,89 @@ func testFetchMarkPrice(t *testing.T) {
 }
 
 func testFetchPositions(t *testing.T) {
-	// @ FetchMarkPrice
-	resp, err := ex.FetchPositions(symbol, nil)
+	// @ FetchPositions, symbol 为空时返回所有仓位
+	for _, s := range []string{symbol, ""} {
+		positions, err := ex.FetchPositions(s, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pos := range positions {
+			log.Println("#####", pos.Symbol, pos.Side, pos.Amount, "liquidation distance:", pos.LiquidationDistance())
+		}
+		log.Println("##### FetchPositions:", ex.JsonIndent(positions))
+	}
+}
+
+func testCancelOrders(t *testing.T, orderIds []string) {
+	// @ CancelOrders
+	results, err := ex.CancelOrders(orderIds, symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelOrders:", ex.JsonIndent(results))
+}
+
+func testCancelAllOrders(t *testing.T) {
+	// @ CancelAllOrders
+	results, err := ex.CancelAllOrders(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelAllOrders:", ex.JsonIndent(results))
+}
+
+func testFetchFundingRate(t *testing.T) {
+	fundingRate, err := ex.FetchFundingRate(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRate:", ex.JsonIndent(fundingRate))
+}
+
+func testFetchFundingRateHistory(t *testing.T) {
+	history, err := ex.FetchFundingRateHistory(symbol, 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRateHistory:", ex.JsonIndent(history))
+}
+
+func testFetchOpenInterest(t *testing.T) {
+	openInterest, err := ex.FetchOpenInterest(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOpenInterest:", ex.JsonIndent(openInterest))
+}
+
+func testFetchOpenInterestHistory(t *testing.T) {
+	history, err := ex.FetchOpenInterestHistory(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOpenInterestHistory:", ex.JsonIndent(history))
+}
+
+func testFetchMarkOHLCV(t *testing.T) {
+	klines, err := ex.FetchMarkOHLCV(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchMarkOHLCV:", symbol, ex.JsonIndent(klines))
+}
+
+func testSetLeverage(t *testing.T) {
+	resp, err := ex.SetLeverage(symbol, 5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### SetLeverage:", ex.JsonIndent(resp))
+}
+
+func testFetchLeverageTiers(t *testing.T) {
+	tiers, err := ex.FetchLeverageTiers([]string{symbol}, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	log.Println("##### FetchPositions:", ex.JsonIndent(resp))
+	log.Println("##### FetchLeverageTiers:", ex.JsonIndent(tiers))
 }
@@ -43,28 +43,41 @@ func (self *FuturesGateio) Describe() []byte {
         "fetchBidsAsks": true,
         "fetchTickers": true,
         "fetchOHLCV": true,
+        "fetchFundingRate": true,
+        "fetchFundingRateHistory": true,
+        "fetchOpenInterest": true,
+        "fetchOpenInterestHistory": true,
+        "fetchMarkOHLCV": true,
+        "fetchIndexOHLCV": true,
+        "setLeverage": true,
+        "setMarginMode": true,
+        "setPositionMode": true,
+        "addMargin": true,
+        "reduceMargin": true,
+        "fetchLeverageTiers": true,
         "fetchMyTrades": true,
         "fetchOrder": true,
         "fetchOrders": true,
         "fetchOpenOrders": true,
-        "fetchClosedOrders": true
+        "fetchClosedOrders": true,
+        "cancelAllOrders": true,
+        "cancelOrders": true,
+        "editOrder": true,
+        "createStopOrder": true,
+        "createAttachedOrders": true
     },
     "timeframes": {
+        "10s": "10s",
         "1m": "1m",
-        "3m": "3m",
         "5m": "5m",
         "15m": "15m",
         "30m": "30m",
         "1h": "1h",
-        "2h": "2h",
         "4h": "4h",
-        "6h": "6h",
         "8h": "8h",
-        "12h": "12h",
         "1d": "1d",
-        "3d": "3d",
-        "1w": "1w",
-        "1M": "1M"
+        "1w": "7d",
+        "1M": "30d"
     },
     "urls": {
         "logo": "",
@@ -79,11 +92,16 @@ func (self *FuturesGateio) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
 				"futures/usdt/contracts",
 				"futures/usdt/contracts/{contract}",
 				"futures/usdt/order_book",
 				"futures/usdt/trades",
+				"futures/usdt/candlesticks",
+				"futures/usdt/funding_rate",
+				"futures/usdt/contract_stats",
+				"futures/usdt/risk_limit_tiers",
             ]
         },
         "private": {
@@ -93,12 +111,23 @@ func (self *FuturesGateio) Describe() []byte {
 				"futures/usdt/orders",
 				"futures/usdt/positions",
 				"futures/usdt/positions/{contract}",
+				"futures/usdt/price_orders",
+				"futures/usdt/price_orders/{order_id}",
             ],
             "post": [
 				"futures/usdt/orders",
+				"futures/usdt/batch_cancel_orders",
+				"futures/usdt/price_orders",
+				"futures/usdt/positions/{contract}/leverage",
+				"futures/usdt/positions/{contract}/margin",
+				"futures/usdt/dual_mode",
+				"wallet/transfers",
             ],
             "delete": [
+				"futures/usdt/orders",
 				"futures/usdt/orders/{order_id}",
+				"futures/usdt/price_orders",
+				"futures/usdt/price_orders/{order_id}",
             ],
             "put": [
 				"futures/usdt/orders/{order_id}",
@@ -117,7 +146,16 @@ func (self *FuturesGateio) Describe() []byte {
         "warnOnFetchOpenOrdersWithoutSymbol": true,
         "recvWindow": 5000,
         "timeDifference": 0,
-        "adjustForTimeDifference": false
+        "adjustForTimeDifference": false,
+        "cancelOrdersBatchSize": 20,
+        "fetchOHLCVLimit": 1000,
+        "clientOrderIdPrefix": "t-",
+        "clientOrderIdMaxLength": 30,
+        "accountTypes": ["swap"],
+        "accountsByType": {
+            "spot": "spot",
+            "swap": "futures"
+        }
     },
     "exceptions": {
 		"exact": {
@@ -182,6 +220,7 @@ func (self *FuturesGateio) FetchMarkets(params map[string]interface{}) ([]*Marke
 			"precision":      precision,
 			"limits":         limits,
 			"baseMultiplier": self.SafeFloat(market, "quanto_multiplier"),
+			"contractSize":   multiplier,
 			"info":           market,
 		})
 	}
@@ -209,10 +248,15 @@ func (self *FuturesGateio) FetchBalance(params map[string]interface{}) (balanceR
 		}
 	}()
 
-	response := self.ApiFunc("privateGetFuturesUsdtAccounts", params, nil, nil)
+	typ, query := self.AccountType(params, AccountTypeSwap)
+	if typ != AccountTypeSwap {
+		self.UnsupportedAccountType(typ)
+	}
+	response := self.ApiFunc("privateGetFuturesUsdtAccounts", query, nil, nil)
 
 	result := map[string]interface{}{
 		"info": response,
+		"type": typ,
 	}
 
 	balance := response
@@ -227,6 +271,13 @@ func (self *FuturesGateio) FetchBalance(params map[string]interface{}) (balanceR
 	account["used"] = used
 	account["total"] = free + used
 	account["unrealPnl"] = unrealPnl
+	walletBalance := self.SafeFloat(balance, "total")
+	account["walletBalance"] = walletBalance
+	account["marginBalance"] = walletBalance + unrealPnl
+	account["availableWithdraw"] = free
+	account["initialMargin"] = self.SafeFloat(balance, "position_initial_margin") + self.SafeFloat(balance, "order_margin")
+	account["maintenanceMargin"] = self.SafeFloat(balance, "maintenance_margin")
+	account["crossUnrealPnl"] = self.SafeFloat(balance, "cross_unrealised_pnl")
 	currency := self.SafeString(balance, "currency")
 	result[currency] = account
 
@@ -247,12 +298,14 @@ func (self *FuturesGateio) FetchOrderBook(symbol string, limit int64, params map
 	market := self.Market(symbol)
 	request := map[string]interface{}{
 		"contract": market.Id,
+		"with_id":  "true",
 	}
 	if limit > 0 {
 		request["limit"] = limit
 	}
 	response := self.ApiFunc("publicGetFuturesUsdtOrderBook", self.Extend(request, params), nil, nil)
 	orderBook = new(OrderBook)
+	orderBook.Nonce = self.SafeInteger(response, "id", 0)
 	orderBook.Timestamp = int64(ToFloat(response["update"]) * 1000)
 	orderBook.Datetime = self.Iso8601(orderBook.Timestamp)
 	for _, one := range response["bids"].([]interface{}) {
@@ -309,42 +362,63 @@ func (self *FuturesGateio) FetchTicker(symbol string, params map[string]interfac
 	return ticker, nil
 }
 
+// {"t": 1539852480, "v": 97151, "c": "1.032", "h": "1.032", "l": "1.032", "o": "1.032"}
+// NOTE: 标记价格和指数价格的 K 线没有 v
 func (self *FuturesGateio) ParseOHLCV(response interface{}) *OHLCV {
-	data := response.([]interface{})
 	return &OHLCV{
-		Timestamp: ToInteger(data[0]),
-		Open:      ToFloat(data[1]),
-		High:      ToFloat(data[2]),
-		Low:       ToFloat(data[3]),
-		Close:     ToFloat(data[4]),
-		Volume:    ToFloat(data[5]),
+		Timestamp: self.SafeInteger(response, "t") * 1000,
+		Open:      self.SafeFloat(response, "o"),
+		High:      self.SafeFloat(response, "h"),
+		Low:       self.SafeFloat(response, "l"),
+		Close:     self.SafeFloat(response, "c"),
+		Volume:    self.SafeFloat(response, "v"),
 		Info:      response,
 	}
 }
 
 func (self *FuturesGateio) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchCandlesticks("", symbol, timeframe, since, limit, params)
+}
+
+func (self *FuturesGateio) FetchMarkOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchCandlesticks("mark_", symbol, timeframe, since, limit, params)
+}
+
+func (self *FuturesGateio) FetchIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchCandlesticks("index_", symbol, timeframe, since, limit, params)
+}
+
+// 合约名加上 mark_ 或 index_ 前缀时返回标记价格或指数价格的 K 线
+func (self *FuturesGateio) fetchCandlesticks(prefix string, symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
 		}
 	}()
-	self.LoadMarkets()
+	interval, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
 	market := self.Market(symbol)
 	request := map[string]interface{}{
-		"symbol":   market.Id,
-		"interval": self.Timeframes[timeframe],
+		"contract": prefix + market.Id,
+		"interval": interval,
 	}
 	if since > 0 {
-		request["startTime"] = since
-	}
-	if limit > 0 {
+		// NOTE: 指定 from 时不能同时指定 limit, 需要给出 to
+		request["from"] = since / 1000
+		if limit > 0 {
+			request["to"] = since/1000 + limit*self.ParseTimeframe(timeframe) - 1
+		}
+	} else if limit > 0 {
 		request["limit"] = limit
 	}
-	response := self.ApiFuncReturnList("publicGetKlines", self.Extend(request, params), nil, nil)
+	response := self.ApiFuncReturnList("publicGetFuturesUsdtCandlesticks", self.Extend(request, params), nil, nil)
+	klines = []*OHLCV{}
 	for _, item := range response {
 		klines = append(klines, self.ParseOHLCV(item))
 	}
-	return klines, nil
+	return self.FilterOHLCVs(klines, since, limit), nil
 }
 
 func (self *FuturesGateio) ParseOrderStatus(status string) string {
@@ -360,7 +434,11 @@ func (self *FuturesGateio) ParseOrder(order interface{}, market interface{}) (re
 	if market != nil {
 		symbol = market.(*Market).Symbol
 	}
-	clientOid := ""
+	// NOTE: 没有自定义 id 时 text 为 api, web 等下单渠道
+	clientOid := self.SafeString(order, "text", "")
+	if !strings.HasPrefix(clientOid, "t-") {
+		clientOid = ""
+	}
 	orderId := fmt.Sprintf("%d", self.SafeInteger(order, "id"))
 	timestamp := int64(self.SafeFloat(order, "create_time", 0) * 1000)
 	datetime := self.Iso8601(timestamp)
@@ -398,25 +476,112 @@ func (self *FuturesGateio) CreateOrder(symbol string, type_ string, side string,
 			err = self.PanicToError(e)
 		}
 	}()
+	trigger, query := self.ParseTriggerOrder(params)
+	if trigger != nil {
+		if trigger.TrailingPercent > 0 {
+			self.RaiseException("NotSupported", self.Id+" createOrder does not support trailing stop orders")
+		}
+		if !trigger.IsAttached() {
+			if trigger.StopLoss != nil || trigger.TakeProfit != nil {
+				self.RaiseException("NotSupported", self.Id+" createOrder does not support stop orders with attached stopLoss/takeProfit")
+			}
+			return self.createPriceOrder(symbol, type_, side, amount, price, trigger, query), nil
+		}
+	}
 	market := self.Market(symbol)
+	text, query := self.PrefixedClientOrderId(query, "text")
 	request := map[string]interface{}{
 		"contract": market.Id,
 		"price":    self.Float64ToString(price),
+		"text":     text,
 	}
 	if side == "buy" {
 		request["size"] = int64(amount)
 	} else {
 		request["size"] = -int64(amount)
 	}
-	response := self.ApiFunc("privatePostFuturesUsdtOrders", self.Extend(request, params), nil, nil)
+	response := self.ApiFunc("privatePostFuturesUsdtOrders", self.Extend(request, query), nil, nil)
+	result = &Order{
+		Id:            fmt.Sprintf("%d", self.SafeInteger(response, "id")),
+		ClientOrderId: text,
+		Symbol:        symbol,
+		Type:          type_,
+		Side:          side,
+		Status:        "open",
+		Info:          response,
+	}
+	// 附带的止损止盈在开仓订单之后单独下条件单
+	return self.CreateAttachedOrders(result, trigger, symbol, side, amount, nil)
+}
+
+// 条件单 (price_orders), 触发后按 initial 下单, price 为 0 时为市价单
+func (self *FuturesGateio) createPriceOrder(symbol string, type_ string, side string, amount float64, price float64, trigger *TriggerOrder, params map[string]interface{}) *Order {
+	market := self.Market(symbol)
+	initial := map[string]interface{}{
+		"contract": market.Id,
+		"price":    self.Float64ToString(price),
+		"tif":      "gtc",
+	}
+	if type_ == "market" {
+		initial["price"] = "0"
+		initial["tif"] = "ioc"
+	}
+	if side == "buy" {
+		initial["size"] = int64(amount)
+	} else {
+		initial["size"] = -int64(amount)
+	}
+	if self.ToBool(self.SafeValue(params, "reduceOnly", false)) {
+		initial["reduce_only"] = true
+	}
+	params = self.Omit(params, "reduceOnly")
+	// rule: 1 表示最新价 >= 触发价, 2 表示最新价 <= 触发价
+	rule := 2
+	if trigger.Direction(side) == "up" {
+		rule = 1
+	}
+	request := map[string]interface{}{
+		"initial": initial,
+		"trigger": map[string]interface{}{
+			"strategy_type": 0,
+			"price_type":    0,
+			"price":         self.Float64ToString(trigger.TriggerPrice()),
+			"rule":          rule,
+		},
+	}
+	response := self.ApiFunc("privatePostFuturesUsdtPriceOrders", self.Extend(request, params), nil, nil)
 	return &Order{
-		Id:     fmt.Sprintf("%d", self.SafeInteger(response, "id")),
-		Symbol: symbol,
-		Type:   type_,
-		Side:   side,
-		Status: "open",
-		Info:   response,
-	}, nil
+		Id:        fmt.Sprintf("%d", self.SafeInteger(response, "id")),
+		Symbol:    symbol,
+		Type:      type_,
+		Side:      side,
+		Status:    "open",
+		StopPrice: trigger.TriggerPrice(),
+		Info:      response,
+	}
+}
+
+// 条件单的订单信息在 initial 中, 触发价在 trigger 中
+func (self *FuturesGateio) parsePriceOrder(order interface{}, market interface{}) map[string]interface{} {
+	initial := self.SafeValue(order, "initial", map[string]interface{}{})
+	flat := self.Extend(initial, map[string]interface{}{
+		"id":          self.SafeValue(order, "id", nil),
+		"create_time": self.SafeValue(order, "create_time", nil),
+		"status":      self.SafeValue(order, "status", nil),
+		"left":        self.SafeValue(initial, "size", nil),
+	})
+	result := self.ParseOrder(flat, market)
+	result["stopPrice"] = self.SafeFloat(self.SafeValue(order, "trigger", nil), "price", 0)
+	result["info"] = order
+	return result
+}
+
+func (self *FuturesGateio) parsePriceOrders(orders []interface{}, market interface{}) []*Order {
+	result := []*Order{}
+	for _, order := range orders {
+		result = append(result, self.ToOrder(self.parsePriceOrder(order, market)))
+	}
+	return result
 }
 
 func (self *FuturesGateio) FetchOrder(id string, symbol string, params map[string]interface{}) (result *Order, err error) {
@@ -426,10 +591,12 @@ func (self *FuturesGateio) FetchOrder(id string, symbol string, params map[strin
 		}
 	}()
 	market := self.Market(symbol)
+	// NOTE: order_id 可以为 text, 但按 text 只能操作未完成的订单
+	orderId, query := self.OrderIdParam(id, params)
 	request := map[string]interface{}{
-		"order_id": id,
+		"order_id": orderId,
 	}
-	response := self.ApiFunc("privateGetFuturesUsdtOrdersOrderId", self.Extend(request, params), nil, nil)
+	response := self.ApiFunc("privateGetFuturesUsdtOrdersOrderId", self.Extend(request, query), nil, nil)
 	return self.ToOrder(self.ParseOrder(response, market)), nil
 }
 
@@ -442,12 +609,18 @@ func (self *FuturesGateio) FetchOpenOrders(symbol string, since int64, limit int
 	request := map[string]interface{}{
 		"status": "open",
 	}
-	var market *Market
+	var market interface{}
 	if symbol != "" {
-		market = self.Market(symbol)
-		request["contract"] = market.Id
+		m := self.Market(symbol)
+		request["contract"] = m.Id
+		market = m
 	}
-	response := self.ApiFuncReturnList("privateGetFuturesUsdtOrders", self.Extend(request, params), nil, nil)
+	stop, query := self.IsStopOrderRequest(params)
+	if stop {
+		response := self.ApiFuncReturnList("privateGetFuturesUsdtPriceOrders", self.Extend(request, query), nil, nil)
+		return self.parsePriceOrders(response, market), nil
+	}
+	response := self.ApiFuncReturnList("privateGetFuturesUsdtOrders", self.Extend(request, query), nil, nil)
 	return self.ToOrders(self.ParseOrders(response, market, since, limit)), nil
 }
 
@@ -500,13 +673,119 @@ func (self *FuturesGateio) CancelOrder(id string, symbol string, params map[stri
 			err = self.PanicToError(e)
 		}
 	}()
+	stop, query := self.IsStopOrderRequest(params)
+	orderId, query := self.OrderIdParam(id, query)
 	request := map[string]interface{}{
-		"order_id": id,
+		"order_id": orderId,
+	}
+	if stop {
+		response = self.ApiFunc("privateDeleteFuturesUsdtPriceOrdersOrderId", self.Extend(request, query), nil, nil)
+		return response, nil
 	}
-	response = self.ApiFunc("privateDeleteFuturesUsdtOrdersOrderId", self.Extend(request, params), nil, nil)
+	response = self.ApiFunc("privateDeleteFuturesUsdtOrdersOrderId", self.Extend(request, query), nil, nil)
 	return response, nil
 }
 
+func (self *FuturesGateio) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"contract": market.Id,
+	}
+	var orders []*Order
+	stop, query := self.IsStopOrderRequest(params)
+	if stop {
+		response := self.ApiFuncReturnList("privateDeleteFuturesUsdtPriceOrders", self.Extend(request, query), nil, nil)
+		orders = self.parsePriceOrders(response, market)
+	} else {
+		response := self.ApiFuncReturnList("privateDeleteFuturesUsdtOrders", self.Extend(request, query), nil, nil)
+		orders = self.ToOrders(self.ParseOrders(response, market, 0, 0))
+	}
+	result = []*OrderResult{}
+	for _, order := range orders {
+		result = append(result, &OrderResult{
+			Id:            order.Id,
+			ClientOrderId: order.ClientOrderId,
+			Order:         order,
+		})
+	}
+	return result, nil
+}
+
+// 每次最多撤销 20 个订单, 超过的分批请求
+func (self *FuturesGateio) CancelOrders(ids []string, symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	batchSize := int(self.SafeInteger(self.Options, "cancelOrdersBatchSize", 20))
+	result = []*OrderResult{}
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		orderIds := []interface{}{}
+		for _, id := range ids[start:end] {
+			orderIds = append(orderIds, id)
+		}
+		response := self.ApiFuncReturnList("privatePostFuturesUsdtBatchCancelOrders", params, nil, orderIds)
+		// NOTE: 返回 [{"id": "...", "succeeded": true, "message": ""}, ...]
+		for _, item := range response {
+			id := self.SafeString(item, "id")
+			one := &OrderResult{
+				Id: id,
+			}
+			if self.SafeValue(item, "succeeded", false) == true {
+				one.Order = self.CanceledOrder(id, symbol, item)
+			} else {
+				message := self.SafeString(item, "message")
+				one.Error = self.ExactlyMatchedError(self.Exceptions["exact"], message, self.Id+" "+message)
+			}
+			result = append(result, one)
+		}
+	}
+	return result, nil
+}
+
+// 只能修改价格和数量, 数量包括已成交的部分且方向必须和原订单一致
+func (self *FuturesGateio) EditOrder(id string, symbol string, type_ string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	orderId, query := self.OrderIdParam(id, params)
+	request := map[string]interface{}{
+		"order_id": orderId,
+	}
+	if amount > 0 {
+		// NOTE: 数量为合约张数, 不能截断, 否则改单后的数量和预期不一致
+		if amount != math.Trunc(amount) {
+			self.RaiseException("InvalidOrder", fmt.Sprintf("%s editOrder amount must be a whole number of contracts: %v", self.Id, amount))
+		}
+		if side == "buy" {
+			request["size"] = int64(amount)
+		} else {
+			request["size"] = -int64(amount)
+		}
+	}
+	if price > 0 {
+		request["price"] = self.Float64ToString(price)
+	}
+	response := self.ApiFunc("privatePutFuturesUsdtOrdersOrderId", self.Extend(request, query), nil, nil)
+	return self.ToOrder(self.ParseOrder(response, market)), nil
+}
+
 func (self *FuturesGateio) FetchMarkPrice(symbol string, params map[string]interface{}) (markPrice *MarkPrice, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -528,38 +807,387 @@ func (self *FuturesGateio) FetchMarkPrice(symbol string, params map[string]inter
 	}, nil
 }
 
-func (self *FuturesGateio) FetchPositions(symbol string, params map[string]interface{}) (result []*Position, err error) {
+// funding_rate 为下一次结算(funding_next_apply)的费率, funding_rate_indicative 为再下一次的预测费率
+func (self *FuturesGateio) FetchFundingRate(symbol string, params map[string]interface{}) (fundingRate *FundingRate, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"contract": market.Id,
+	}
+	response := self.ApiFunc("publicGetFuturesUsdtContractsContract", self.Extend(request, params), nil, nil)
+	fundingTimestamp := self.SafeInteger(response, "funding_next_apply", 0) * 1000
+	fundingRate = &FundingRate{
+		Symbol:           symbol,
+		FundingRate:      self.SafeFloat(response, "funding_rate", 0),
+		FundingTimestamp: fundingTimestamp,
+		NextFundingRate:  self.SafeFloat(response, "funding_rate_indicative", 0),
+		MarkPrice:        self.SafeFloat(response, "mark_price", 0),
+		IndexPrice:       self.SafeFloat(response, "index_price", 0),
+		Timestamp:        self.Milliseconds(),
+		Info:             response,
+	}
+	if interval := self.SafeInteger(response, "funding_interval", 0); interval > 0 && fundingTimestamp > 0 {
+		fundingRate.NextFundingTimestamp = fundingTimestamp + interval*1000
+	}
+	return fundingRate, nil
+}
+
+func (self *FuturesGateio) FetchFundingRateHistory(symbol string, since int64, limit int64, params map[string]interface{}) (result []*FundingRateHistory, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
 		}
 	}()
-	request := map[string]interface{}{}
 	market := self.Market(symbol)
-	request["contract"] = market.Id
+	request := map[string]interface{}{
+		"contract": market.Id,
+	}
+	if since > 0 {
+		request["from"] = since / 1000
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	// [{"t": 1543968000, "r": "0.000157"}], 按时间降序
+	response := self.ApiFuncReturnList("publicGetFuturesUsdtFundingRate", self.Extend(request, params), nil, nil)
+	result = []*FundingRateHistory{}
+	for i := len(response) - 1; i >= 0; i-- {
+		item := response[i]
+		result = append(result, &FundingRateHistory{
+			Symbol:      symbol,
+			FundingRate: self.SafeFloat(item, "r", 0),
+			Timestamp:   self.SafeInteger(item, "t", 0) * 1000,
+			Info:        item,
+		})
+	}
+	return result, nil
+}
+
+// position_size 为合约的总持仓张数, 价值按标记价格计算
+func (self *FuturesGateio) FetchOpenInterest(symbol string, params map[string]interface{}) (openInterest *OpenInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"contract": market.Id,
+	}
+	response := self.ApiFunc("publicGetFuturesUsdtContractsContract", self.Extend(request, params), nil, nil)
+	amount := self.SafeFloat(response, "position_size", 0)
+	return &OpenInterest{
+		Symbol:    symbol,
+		Amount:    amount,
+		Value:     amount * self.SafeFloat(response, "quanto_multiplier", 0) * self.SafeFloat(response, "mark_price", 0),
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}, nil
+}
+
+// CalculateFee 数量为张数, 合约乘数和默认费率从合约信息中获取
+func (self *FuturesGateio) CalculateFee(symbol, otype, side string, amount, price float64, takerOrMaker string, params map[string]interface{}) (result *Fee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"contract": market.Id,
+	}
+	response := self.ApiFunc("publicGetFuturesUsdtContractsContract", self.Extend(request, params), nil, nil)
+	market.ContractSize = self.SafeFloat(response, "quanto_multiplier", 0)
+	market.Taker = self.SafeFloat(response, "taker_fee_rate", 0)
+	market.Maker = self.SafeFloat(response, "maker_fee_rate", 0)
+	return self.CalculateMarketFee(market, otype, side, amount, price, takerOrMaker), nil
+}
+
+// timeframe 支持 5m 15m 30m 1h 4h 1d
+func (self *FuturesGateio) FetchOpenInterestHistory(symbol string, timeframe string, since int64, limit int64, params map[string]interface{}) (result []*OpenInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"contract": market.Id,
+		"interval": self.Timeframes[timeframe],
+	}
+	if since > 0 {
+		request["from"] = since / 1000
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList("publicGetFuturesUsdtContractStats", self.Extend(request, params), nil, nil)
+	result = []*OpenInterest{}
+	for _, item := range response {
+		result = append(result, &OpenInterest{
+			Symbol:    symbol,
+			Amount:    self.SafeFloat(item, "open_interest", 0),
+			Value:     self.SafeFloat(item, "open_interest_usd", 0),
+			Timestamp: self.SafeInteger(item, "time", 0) * 1000,
+			Info:      item,
+		})
+	}
+	return result, nil
+}
+
+// symbol 为空时返回所有有持仓的合约
+func (self *FuturesGateio) FetchPositions(symbol string, params map[string]interface{}) (result []*Position, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	result = []*Position{}
+	if symbol == "" {
+		request := map[string]interface{}{
+			"holding": "true",
+		}
+		response := self.ApiFuncReturnList("privateGetFuturesUsdtPositions", self.Extend(request, params), nil, nil)
+		// NOTE: 仓位接口不返回合约乘数, 从合约列表中获取
+		contractSizes := map[string]float64{}
+		if len(response) > 0 {
+			markets, err := self.FetchMarkets(nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, market := range markets {
+				contractSizes[market.Id] = market.ContractSize
+			}
+		}
+		for _, item := range response {
+			result = append(result, self.parsePosition(item, contractSizes[self.SafeString(item, "contract")]))
+		}
+		return result, nil
+	}
+	request := map[string]interface{}{
+		"contract": self.Market(symbol).Id,
+	}
 	response := self.ApiFunc("privateGetFuturesUsdtPositionsContract", self.Extend(request, params), nil, nil)
+	contract := self.ApiFunc("publicGetFuturesUsdtContractsContract", map[string]interface{}{"contract": request["contract"]}, nil, nil)
+	pos := self.parsePosition(response, self.SafeFloat(contract, "quanto_multiplier", 0))
+	pos.Symbol = symbol
+	result = append(result, pos)
+	return result, nil
+}
 
-	item := response
+// 杠杆为 0 表示全仓, 全仓杠杆为 cross_leverage_limit. 数量为张数
+func (self *FuturesGateio) parsePosition(item interface{}, contractSize float64) *Position {
 	amount := self.SafeFloat(item, "size")
+	leverage := self.SafeFloat(item, "leverage", 0)
+	marginMode := "isolated"
+	if leverage == 0 {
+		marginMode = "cross"
+		leverage = self.SafeFloat(item, "cross_leverage_limit", 0)
+	}
+	timestamp := self.SafeInteger(item, "update_time", 0) * 1000
 	pos := &Position{
-		Symbol:     symbol,
-		Side:       "long",
-		Leverage:   self.SafeFloat(item, "cross_leverage_limit", 0),
-		Amount:     math.Abs(amount),
-		UsedAmount: 0,
-		Price:      self.SafeFloat(item, "entry_price", 0),
-		RealPnl:    self.SafeFloat(item, "realised_pnl"),
-		UnrealPnl:  self.SafeFloat(item, "unrealised_pnl", 0),
-		Info:       item,
+		Symbol:            strings.Replace(self.SafeString(item, "contract"), "_", "/", 1),
+		Side:              "long",
+		Leverage:          leverage,
+		Amount:            math.Abs(amount),
+		UsedAmount:        0,
+		Price:             self.SafeFloat(item, "entry_price", 0),
+		RealPnl:           self.SafeFloat(item, "realised_pnl"),
+		UnrealPnl:         self.SafeFloat(item, "unrealised_pnl", 0),
+		MarkPrice:         self.SafeFloat(item, "mark_price", 0),
+		LiquidationPrice:  self.SafeFloat(item, "liq_price", 0),
+		Notional:          self.SafeFloat(item, "value", 0),
+		ContractSize:      contractSize,
+		Settle:            "USDT",
+		MarginMode:        marginMode,
+		InitialMargin:     self.SafeFloat(item, "initial_margin", 0),
+		MaintenanceMargin: self.SafeFloat(item, "maintenance_margin", 0),
+		Timestamp:         timestamp,
+		Datetime:          self.Iso8601(timestamp),
+		Info:              item,
 	}
 	if amount < 0 {
 		pos.Side = "short"
 	}
-	result = append(result, pos)
+	return pos
+}
+
+// NOTE: 以下接口只适用于单向持仓模式. 杠杆为 0 表示全仓, 此时全仓杠杆由 cross_leverage_limit 指定
+func (self *FuturesGateio) SetLeverage(symbol string, leverage float64, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"contract": market.Id,
+		"leverage": self.Float64ToString(leverage),
+	}
+	// 保持当前的保证金模式
+	position := self.ApiFunc("privateGetFuturesUsdtPositionsContract", map[string]interface{}{"contract": market.Id}, nil, nil)
+	if self.SafeFloat(position, "leverage", 0) == 0 {
+		request["leverage"] = "0"
+		request["cross_leverage_limit"] = self.Float64ToString(leverage)
+	}
+	return self.ApiFunc("privatePostFuturesUsdtPositionsContractLeverage", self.Extend(request, params), nil, nil), nil
+}
 
+// 切换保证金模式时沿用当前的杠杆倍数
+func (self *FuturesGateio) SetMarginMode(symbol string, marginMode string, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	marginMode = self.CheckMarginMode(marginMode)
+	market := self.Market(symbol)
+	position := self.ApiFunc("privateGetFuturesUsdtPositionsContract", map[string]interface{}{"contract": market.Id}, nil, nil)
+	leverage := self.SafeFloat(position, "leverage", 0)
+	if leverage == 0 {
+		leverage = self.SafeFloat(position, "cross_leverage_limit", 0)
+	}
+	request := map[string]interface{}{
+		"contract": market.Id,
+		"leverage": self.Float64ToString(leverage),
+	}
+	if marginMode == "cross" {
+		request["leverage"] = "0"
+		request["cross_leverage_limit"] = self.Float64ToString(leverage)
+	}
+	return self.ApiFunc("privatePostFuturesUsdtPositionsContractLeverage", self.Extend(request, params), nil, nil), nil
+}
+
+// 有仓位或挂单时不能切换
+func (self *FuturesGateio) SetPositionMode(hedged bool, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"dual_mode": fmt.Sprintf("%v", hedged),
+	}
+	return self.ApiFunc("privatePostFuturesUsdtDualMode", self.Extend(request, params), nil, nil), nil
+}
+
+func (self *FuturesGateio) AddMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	return self.modifyMargin(symbol, "add", amount, params)
+}
+
+func (self *FuturesGateio) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	return self.modifyMargin(symbol, "reduce", amount, params)
+}
+
+// change 为正数表示追加, 负数表示减少, 返回调整后的仓位
+func (self *FuturesGateio) modifyMargin(symbol string, type_ string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	change := amount
+	if type_ == "reduce" {
+		change = -amount
+	}
+	request := map[string]interface{}{
+		"contract": self.Market(symbol).Id,
+		"change":   self.Float64ToString(change),
+	}
+	response := self.ApiFunc("privatePostFuturesUsdtPositionsContractMargin", self.Extend(request, params), nil, nil)
+	return &MarginModification{
+		Symbol: symbol,
+		Type:   type_,
+		Amount: amount,
+		Total:  self.SafeFloat(response, "margin", 0),
+		Info:   response,
+	}, nil
+}
+
+// 每个交易对单独查询, 必须指定 symbols. 第 n 档的下限为第 n-1 档的 risk_limit
+func (self *FuturesGateio) FetchLeverageTiers(symbols []string, params map[string]interface{}) (result map[string][]*LeverageTier, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if len(symbols) == 0 {
+		self.RaiseException("ArgumentsRequired", self.Id+" fetchLeverageTiers requires symbols")
+	}
+	result = map[string][]*LeverageTier{}
+	for _, symbol := range symbols {
+		request := map[string]interface{}{
+			"contract": self.Market(symbol).Id,
+		}
+		// [{"tier": 1, "risk_limit": "20000", "initial_rate": "0.02", "maintenance_rate": "0.01", "leverage_max": "50"}]
+		response := self.ApiFuncReturnList("publicGetFuturesUsdtRiskLimitTiers", self.Extend(request, params), nil, nil)
+		tiers := []*LeverageTier{}
+		floor := 0.0
+		for _, item := range response {
+			riskLimit := self.SafeFloat(item, "risk_limit", 0)
+			tiers = append(tiers, &LeverageTier{
+				Tier:                  self.SafeInteger(item, "tier", 0),
+				Symbol:                symbol,
+				MinNotional:           floor,
+				MaxNotional:           riskLimit,
+				MaintenanceMarginRate: self.SafeFloat(item, "maintenance_rate", 0),
+				MaxLeverage:           self.SafeFloat(item, "leverage_max", 0),
+				Info:                  item,
+			})
+			floor = riskLimit
+		}
+		result[symbol] = tiers
+	}
+	return result, nil
+}
+
+// Transfer 只支持现货账户和 USDT 永续合约账户之间划转, 交易所没有划转记录的接口
+func (self *FuturesGateio) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	from, _ := self.AccountType(map[string]interface{}{"type": fromAccount}, "")
+	to, _ := self.AccountType(map[string]interface{}{"type": toAccount}, "")
+	if !(from == AccountTypeSpot && to == AccountTypeSwap) && !(from == AccountTypeSwap && to == AccountTypeSpot) {
+		self.RaiseException("NotSupported", self.Id+" transfer supports spot and swap accounts only")
+	}
+	request := map[string]interface{}{
+		"currency": code,
+		"from":     self.TransferAccountId(from),
+		"to":       self.TransferAccountId(to),
+		"amount":   self.Float64ToString(amount),
+		"settle":   "usdt",
+	}
+	response := self.ApiFunc("privatePostWalletTransfers", self.Extend(request, params), nil, nil)
+	result = &Transfer{
+		Id:          self.SafeString(response, "tx_id", ""),
+		Currency:    code,
+		Amount:      amount,
+		FromAccount: from,
+		ToAccount:   to,
+		Status:      "ok",
+		Timestamp:   self.Milliseconds(),
+		Info:        response,
+	}
 	return
 }
 
+// 调整杠杆, 保证金和持仓模式的 POST 接口参数放在 query string 中
+func (self *FuturesGateio) isQueryPost(path string) bool {
+	for _, suffix := range []string{"/leverage", "/margin", "/dual_mode"} {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (self *FuturesGateio) genSign(method, url, query, body string) map[string]interface{} {
 	timestamp := self.Milliseconds() / 1000
 	m := sha512.New()
@@ -588,14 +1216,19 @@ func (self *FuturesGateio) Sign(path string, api string, method string, params m
 	} else {
 		self.CheckRequiredCredentials()
 		u, _ := urllib.Parse(url)
-		if method == "GET" || method == "DELETE" {
+		if method == "GET" || method == "DELETE" || self.isQueryPost(path) {
 			queryString := self.Urlencode(query)
 			headers = self.genSign(method, u.Path, queryString, "")
 			if len(query) > 0 {
 				url += "?" + self.Urlencode(query)
 			}
 		} else {
-			body = self.Json(query)
+			// NOTE: 批量接口的请求体为数组, 由调用者通过 body 参数直接传入
+			if body == nil {
+				body = self.Json(query)
+			} else if _, ok := body.(string); !ok {
+				body = self.Json(body)
+			}
 			headers = self.genSign(method, u.Path, "", body.(string))
 		}
 		headers.(map[string]interface{})["Content-Type"] = "application/json"
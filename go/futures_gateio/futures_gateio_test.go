@@ -73,6 +73,13 @@ func TestAll(t *testing.T) {
 	//testFetchOpenOrders(t)
 	//testCancelOrder(t, "75281111572")
 	//testFetchMarkPrice(t)
+	//testSetLeverage(t)
+	//testFetchLeverageTiers(t)
+	//testFetchFundingRate(t)
+	//testFetchFundingRateHistory(t)
+	//testFetchOpenInterest(t)
+	//testFetchOpenInterestHistory(t)
+	//testFetchMarkOHLCV(t)
 	//testFetchPositions(t)
 }
 
@@ -175,10 +182,71 @@ func testFetchMarkPrice(t *testing.T) {
 }
 
 func testFetchPositions(t *testing.T) {
-	// @ FetchMarkPrice
-	resp, err := ex.FetchPositions(symbol, nil)
+	// @ FetchPositions, symbol 为空时返回所有仓位
+	for _, s := range []string{symbol, ""} {
+		positions, err := ex.FetchPositions(s, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pos := range positions {
+			log.Println("#####", pos.Symbol, pos.Side, pos.Amount, "liquidation distance:", pos.LiquidationDistance())
+		}
+		log.Println("##### FetchPositions:", ex.JsonIndent(positions))
+	}
+}
+
+func testFetchFundingRate(t *testing.T) {
+	fundingRate, err := ex.FetchFundingRate(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRate:", ex.JsonIndent(fundingRate))
+}
+
+func testFetchFundingRateHistory(t *testing.T) {
+	history, err := ex.FetchFundingRateHistory(symbol, 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRateHistory:", ex.JsonIndent(history))
+}
+
+func testFetchOpenInterest(t *testing.T) {
+	openInterest, err := ex.FetchOpenInterest(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOpenInterest:", ex.JsonIndent(openInterest))
+}
+
+func testFetchOpenInterestHistory(t *testing.T) {
+	history, err := ex.FetchOpenInterestHistory(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOpenInterestHistory:", ex.JsonIndent(history))
+}
+
+func testFetchMarkOHLCV(t *testing.T) {
+	klines, err := ex.FetchMarkOHLCV(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchMarkOHLCV:", symbol, ex.JsonIndent(klines))
+}
+
+func testSetLeverage(t *testing.T) {
+	resp, err := ex.SetLeverage(symbol, 5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### SetLeverage:", ex.JsonIndent(resp))
+}
+
+func testFetchLeverageTiers(t *testing.T) {
+	tiers, err := ex.FetchLeverageTiers([]string{symbol}, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	log.Println("##### FetchPositions:", ex.JsonIndent(resp))
+	log.Println("##### FetchLeverageTiers:", ex.JsonIndent(tiers))
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	. "github.com/epheien/ccxt/go/base"
 	"math"
+	"sort"
 	"strings"
 )
 
@@ -50,9 +51,22 @@ func (self *FuturesKucoin) Describe() []byte {
         "fetchMyTrades": true,
         "createOrder": true,
         "cancelOrder": true,
+        "cancelAllOrders": true,
+        "createStopOrder": true,
+        "createAttachedOrders": true,
         "fetchAccounts": true,
         "fetchFundingFee": true,
         "fetchOHLCV": true,
+        "fetchFundingRate": true,
+        "fetchFundingRateHistory": true,
+        "fetchOpenInterest": true,
+        "fetchIndexOHLCV": true,
+        "fetchPremiumIndexOHLCV": true,
+        "setLeverage": true,
+        "setMarginMode": true,
+        "addMargin": true,
+        "reduceMargin": true,
+        "fetchLeverageTiers": true,
     },
     "urls": {
         "logo": "https://user-images.githubusercontent.com/1294454/51909432-b0a72780-23dd-11e9-99ba-73d23c8d4eed.jpg",
@@ -76,6 +90,7 @@ func (self *FuturesKucoin) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "contracts/active",
                 "contracts/{symbol}",
@@ -88,6 +103,9 @@ func (self *FuturesKucoin) Describe() []byte {
                 "v2/level3/snapshot",
                 "mark-price/{symbol}/current",
                 "funding-rate/{symbol}/current",
+                "contract/funding-rates",
+                "kline/query",
+                "contracts/risk-limit/{symbol}",
             ],
             "post": [
                 "bullet-public",
@@ -101,6 +119,7 @@ func (self *FuturesKucoin) Describe() []byte {
                 "stopOrders",
                 "recentDoneOrders",
                 "orders/{orderId}",
+                "orders/byClientOid",
                 "position",
                 "positions",
                 "funding-history",
@@ -108,13 +127,20 @@ func (self *FuturesKucoin) Describe() []byte {
             ],
             "post": [
                 "orders",
+                "st-orders",
                 "position/margin/auto-deposit-status",
                 "position/margin/deposit-margin",
+                "margin/withdrawMargin",
+                "v2/changeCrossUserLeverage",
+                "v2/position/changeMarginMode",
                 "bullet-private",
                 "transfer-out",
+                "v3/transfer-out",
+                "transfer-in",
             ],
             "delete": [
                 "orders/{orderId}",
+                "orders/client-order/{clientOid}",
                 "orders",
                 "stopOrders",
                 "cancel/transfer-out",
@@ -122,19 +148,17 @@ func (self *FuturesKucoin) Describe() []byte {
         },
     },
     "timeframes": {
-        "1m": "1min",
-        "3m": "3min",
-        "5m": "5min",
-        "15m": "15min",
-        "30m": "30min",
-        "1h": "1hour",
-        "2h": "2hour",
-        "4h": "4hour",
-        "6h": "6hour",
-        "8h": "8hour",
-        "12h": "12hour",
-        "1d": "1day",
-        "1w": "1week",
+        "1m": "1",
+        "5m": "5",
+        "15m": "15",
+        "30m": "30",
+        "1h": "60",
+        "2h": "120",
+        "4h": "240",
+        "8h": "480",
+        "12h": "720",
+        "1d": "1440",
+        "1w": "10080",
     },
     "exceptions": {
         "400": "BadRequest",
@@ -178,6 +202,12 @@ func (self *FuturesKucoin) Describe() []byte {
     "options": {
         "version": "v1",
         "symbolSeparator": "-",
+        "fetchOHLCVLimit": 200,
+        "accountTypes": ["swap"],
+        "accountsByType": {
+            "spot": "TRADE",
+            "funding": "MAIN",
+        },
     },
     "markets_by_id": {},
 }`)
@@ -197,6 +227,8 @@ func (self *FuturesKucoin) Market(symbol string) *Market {
 		Symbol: symbol,
 		Base:   li[0],
 		Quote:  li[1],
+		// NOTE: quote 为 USDM 的是反向合约, 以 base 结算
+		Inverse: li[1] == "USDM",
 	}
 }
 
@@ -207,19 +239,24 @@ func (self *FuturesKucoin) FetchBalance(params map[string]interface{}) (balanceR
 		}
 	}()
 
-	if params != nil && params["symbol"] != nil {
-		params["currency"] = params["symbol"]
-		if params["currency"] == "USDTM" {
-			params["currency"] = "USDT"
+	typ, query := self.AccountType(params, AccountTypeSwap)
+	if typ != AccountTypeSwap && typ != AccountTypeFutures {
+		self.UnsupportedAccountType(typ)
+	}
+	if query["symbol"] != nil {
+		query["currency"] = query["symbol"]
+		if query["currency"] == "USDTM" {
+			query["currency"] = "USDT"
 		}
-		delete(params, "symbol")
+		delete(query, "symbol")
 	}
 
-	response := self.ApiFunc("privateGetAccountOverview", params, nil, nil)
+	response := self.ApiFunc("privateGetAccountOverview", query, nil, nil)
 	responseData := response["data"]
 
 	result := map[string]interface{}{
 		"info": response,
+		"type": typ,
 	}
 	entry := responseData
 	account := self.Account()
@@ -232,6 +269,11 @@ func (self *FuturesKucoin) FetchBalance(params map[string]interface{}) (balanceR
 	account["used"] = account["total"].(float64) - account["free"].(float64)
 	account["realPnl"] = 0.0
 	account["unrealPnl"] = self.SafeFloat(entry, "unrealisedPNL", 0.0)
+	// kucoin 不返回维持保证金, accountEquity 包含未实现盈亏
+	account["walletBalance"] = self.SafeFloat(entry, "accountEquity", 0) - account["unrealPnl"].(float64)
+	account["marginBalance"] = account["total"]
+	account["availableWithdraw"] = account["free"]
+	account["initialMargin"] = self.SafeFloat(entry, "positionMargin", 0) + self.SafeFloat(entry, "orderMargin", 0)
 	result[code] = account
 
 	return self.ParseBalance(result), nil
@@ -251,6 +293,7 @@ func (self *FuturesKucoin) FetchOrderBook(symbol string, limit int64, params map
 	orderBook = self.ParseOrderBook(response["data"], 0, "bids", "asks", 0, 1)
 	orderBook.Timestamp = int64(response["data"].(map[string]interface{})["ts"].(float64) / 1000000)
 	orderBook.Datetime = self.Iso8601(orderBook.Timestamp)
+	orderBook.Nonce = self.SafeInteger(response["data"], "sequence", 0)
 	return orderBook, nil
 }
 
@@ -298,6 +341,7 @@ func (self *FuturesKucoin) ParseOrder(order interface{}, market interface{}) (re
 		"timestamp":     timestamp,
 		"datetime":      datetime,
 		"status":        status,
+		"stopPrice":     self.SafeFloat(order, "stopPrice", 0),
 		"info":          order,
 	}
 }
@@ -309,7 +353,7 @@ func (self *FuturesKucoin) CreateOrder(symbol string, typ string, side string, a
 		}
 	}()
 	market := self.Market(symbol)
-	clientOid := self.Uuid()
+	clientOid, params := self.ParseClientOrderId(params, "clientOid")
 	request := map[string]interface{}{
 		"clientOid": clientOid,
 		"price":     fmt.Sprint(price),
@@ -319,7 +363,34 @@ func (self *FuturesKucoin) CreateOrder(symbol string, typ string, side string, a
 		"type":      typ,
 		"leverage":  5,
 	}
-	response := self.ApiFunc("privatePostOrders", self.Extend(request, params), nil, nil)
+	method := "privatePostOrders"
+	trigger, query := self.ParseTriggerOrder(params)
+	if trigger != nil {
+		if trigger.TrailingPercent > 0 {
+			self.RaiseException("NotSupported", self.Id+" createOrder does not support trailing stop orders")
+		}
+		if trigger.IsAttached() {
+			// NOTE: st-orders 接口下单的同时附带止盈止损, 止盈止损触发后下市价单
+			method = "privatePostStOrders"
+			up, down := trigger.TakeProfit, trigger.StopLoss
+			if side == "sell" {
+				up, down = down, up
+			}
+			if up != nil {
+				request["triggerStopUpPrice"] = fmt.Sprint(up.TriggerPrice)
+			}
+			if down != nil {
+				request["triggerStopDownPrice"] = fmt.Sprint(down.TriggerPrice)
+			}
+		} else if trigger.StopLoss != nil || trigger.TakeProfit != nil {
+			self.RaiseException("NotSupported", self.Id+" createOrder does not support stop orders with attached stopLoss/takeProfit")
+		} else {
+			request["stop"] = trigger.Direction(side)
+			request["stopPrice"] = fmt.Sprint(trigger.TriggerPrice())
+		}
+		request["stopPriceType"] = "TP"
+	}
+	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
 	responseData := response["data"]
 	return &Order{
 		Id:            responseData.(map[string]interface{})["orderId"].(string),
@@ -328,6 +399,7 @@ func (self *FuturesKucoin) CreateOrder(symbol string, typ string, side string, a
 		Side:          side,
 		Status:        "open",
 		ClientOrderId: clientOid,
+		StopPrice:     self.SafeFloat(request, "stopPrice", 0),
 		Info:          responseData,
 	}, nil
 }
@@ -339,10 +411,20 @@ func (self *FuturesKucoin) FetchOrder(id string, symbol string, params map[strin
 		}
 	}()
 	market := self.Market(symbol)
-	request := map[string]interface{}{
-		"orderId": id,
+	clientOid := self.SafeString(params, "clientOrderId", "")
+	query := self.Omit(self.Extend(params).(map[string]interface{}), "clientOrderId")
+	var response map[string]interface{}
+	if clientOid != "" {
+		request := map[string]interface{}{
+			"clientOid": clientOid,
+		}
+		response = self.ApiFunc("privateGetOrdersByClientOid", self.Extend(request, query), nil, nil)
+	} else {
+		request := map[string]interface{}{
+			"orderId": id,
+		}
+		response = self.ApiFunc("privateGetOrdersOrderId", self.Extend(request, query), nil, nil)
 	}
-	response := self.ApiFunc("privateGetOrdersOrderId", self.Extend(request, params), nil, nil)
 	return self.ToOrder(self.ParseOrder(response["data"], market)), nil
 }
 
@@ -355,10 +437,11 @@ func (self *FuturesKucoin) FetchOpenOrders(symbol string, since int64, limit int
 	request := map[string]interface{}{
 		"status": "active",
 	}
-	var market *Market
+	var market interface{}
 	if symbol != "" {
-		market = self.Market(symbol)
-		request["symbol"] = market.Id
+		m := self.Market(symbol)
+		request["symbol"] = m.Id
+		market = m
 	}
 	if since > 0 {
 		request["startAt"] = since
@@ -366,7 +449,14 @@ func (self *FuturesKucoin) FetchOpenOrders(symbol string, since int64, limit int
 			request["endAt"] = since + limit
 		}
 	}
-	response := self.ApiFunc("privateGetOrders", self.Extend(request, params), nil, nil)
+	method := "privateGetOrders"
+	stop, query := self.IsStopOrderRequest(params)
+	if stop {
+		// 未触发的条件单
+		method = "privateGetStopOrders"
+		delete(request, "status")
+	}
+	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
 	return self.ToOrders(self.ParseOrders(response["data"].(map[string]interface{})["items"], market, since, limit)), nil
 }
 
@@ -376,13 +466,54 @@ func (self *FuturesKucoin) CancelOrder(id string, symbol string, params map[stri
 			err = self.PanicToError(e)
 		}
 	}()
+	// NOTE: 条件单也使用这个接口撤销
+	_, query := self.IsStopOrderRequest(params)
+	if clientOid := self.SafeString(query, "clientOrderId", ""); clientOid != "" {
+		// 按 clientOid 撤单时必须指定 symbol
+		request := map[string]interface{}{
+			"clientOid": clientOid,
+			"symbol":    self.Market(symbol).Id,
+		}
+		response = self.ApiFunc("privateDeleteOrdersClientOrderClientOid", self.Extend(request, self.Omit(query, "clientOrderId")), nil, nil)
+		return response, nil
+	}
 	request := map[string]interface{}{
 		"orderId": id,
 	}
-	response = self.ApiFunc("privateDeleteOrdersOrderId", self.Extend(request, params), nil, nil)
+	response = self.ApiFunc("privateDeleteOrdersOrderId", self.Extend(request, query), nil, nil)
 	return response, nil
 }
 
+// symbol 为空时撤销所有合约的挂单, 不包括止盈止损单, params 中 stop 为 true 时只撤销止盈止损单
+func (self *FuturesKucoin) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{}
+	if symbol != "" {
+		request["symbol"] = self.Market(symbol).Id
+	}
+	method := "privateDeleteOrders"
+	stop, query := self.IsStopOrderRequest(params)
+	if stop {
+		method = "privateDeleteStopOrders"
+	}
+	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	cancelledOrderIds := self.SafeValue(data, "cancelledOrderIds", []interface{}{})
+	result = []*OrderResult{}
+	for _, id := range cancelledOrderIds.([]interface{}) {
+		orderId := fmt.Sprintf("%v", id)
+		result = append(result, &OrderResult{
+			Id:    orderId,
+			Order: self.CanceledOrder(orderId, symbol, nil),
+		})
+	}
+	return result, nil
+}
+
 func (self *FuturesKucoin) FetchMarkPrice(symbol string, params map[string]interface{}) (markPrice *MarkPrice, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -404,36 +535,467 @@ func (self *FuturesKucoin) FetchMarkPrice(symbol string, params map[string]inter
 	}, nil
 }
 
+func (self *FuturesKucoin) ParseOHLCV(ohlcv interface{}) *OHLCV {
+	data := ohlcv.([]interface{})
+	return &OHLCV{
+		Timestamp: ToInteger(data[0]),
+		Open:      ToFloat(data[1]),
+		High:      ToFloat(data[2]),
+		Low:       ToFloat(data[3]),
+		Close:     ToFloat(data[4]),
+		Volume:    ToFloat(data[5]),
+		Info:      ohlcv,
+	}
+}
+
+func (self *FuturesKucoin) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	return self.fetchKlines(self.Market(symbol).Id, timeframe, since, limit, params)
+}
+
+// 指数价格 K 线, 如 XBTUSDTM 的指数为 .KXBTUSDT
+func (self *FuturesKucoin) FetchIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	contract := self.fetchContract(symbol)
+	return self.fetchKlines(self.SafeString(contract, "indexSymbol"), timeframe, since, limit, params)
+}
+
+// 溢价指数 K 线, 如 XBTUSDTM 的溢价指数为 .XBTUSDTMPI
+func (self *FuturesKucoin) FetchPremiumIndexOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	contract := self.fetchContract(symbol)
+	return self.fetchKlines(self.SafeString(contract, "premiumsSymbol1M"), timeframe, since, limit, params)
+}
+
+// NOTE: granularity 的单位为分钟, 必须同时给出 from 和 to, 否则只返回最近的 K 线
+func (self *FuturesKucoin) fetchKlines(id string, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	granularity, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
+	request := map[string]interface{}{
+		"symbol":      id,
+		"granularity": granularity,
+	}
+	if since > 0 {
+		count := limit
+		if count <= 0 {
+			count = self.SafeInteger(self.Options, "fetchOHLCVLimit", 200)
+		}
+		request["from"] = since
+		request["to"] = since + count*self.ParseTimeframe(timeframe)*1000 - 1
+	}
+	response := self.ApiFunc("publicGetKlineQuery", self.Extend(request, params), nil, nil)
+	data, _ := response["data"].([]interface{})
+	klines = []*OHLCV{}
+	for _, item := range data {
+		klines = append(klines, self.ParseOHLCV(item))
+	}
+	return self.FilterOHLCVs(klines, since, limit), nil
+}
+
+// 合约详情, 包含指数代码, 持仓量等
+func (self *FuturesKucoin) fetchContract(symbol string) interface{} {
+	request := map[string]interface{}{
+		"symbol": self.Market(symbol).Id,
+	}
+	response := self.ApiFunc("publicGetContractsSymbol", request, nil, nil)
+	return response["data"]
+}
+
+// contractSizes 所有合约的乘数, 按合约 id 索引. 仓位接口不返回合约乘数
+func (self *FuturesKucoin) contractSizes() map[string]float64 {
+	response := self.ApiFunc("publicGetContractsActive", nil, nil, nil)
+	data, _ := response["data"].([]interface{})
+	result := map[string]float64{}
+	for _, item := range data {
+		result[self.SafeString(item, "symbol")] = math.Abs(self.SafeFloat(item, "multiplier", 0))
+	}
+	return result
+}
+
+// CalculateFee 数量为张数, 合约乘数和默认费率从合约信息中获取. 反向合约的 multiplier 为负数
+func (self *FuturesKucoin) CalculateFee(symbol, otype, side string, amount, price float64, takerOrMaker string, params map[string]interface{}) (result *Fee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	contract := self.fetchContract(symbol)
+	market.ContractSize = math.Abs(self.SafeFloat(contract, "multiplier", 0))
+	market.Inverse = self.SafeBool(contract, "isInverse")
+	market.Taker = self.SafeFloat(contract, "takerFeeRate", 0)
+	market.Maker = self.SafeFloat(contract, "makerFeeRate", 0)
+	return self.CalculateMarketFee(market, otype, side, amount, price, takerOrMaker), nil
+}
+
+// value 为当前周期的资金费率, predictedValue 为下一周期的预测费率
+func (self *FuturesKucoin) FetchFundingRate(symbol string, params map[string]interface{}) (fundingRate *FundingRate, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	response := self.ApiFunc("publicGetFundingRateSymbolCurrent", self.Extend(request, params), nil, nil)
+	data := response["data"]
+	timePoint := self.SafeInteger(data, "timePoint", 0)
+	fundingRate = &FundingRate{
+		Symbol:           symbol,
+		FundingRate:      self.SafeFloat(data, "value", 0),
+		FundingTimestamp: timePoint,
+		NextFundingRate:  self.SafeFloat(data, "predictedValue", 0),
+		Timestamp:        self.Milliseconds(),
+		Info:             data,
+	}
+	if granularity := self.SafeInteger(data, "granularity", 0); granularity > 0 && timePoint > 0 {
+		fundingRate.NextFundingTimestamp = timePoint + granularity
+	}
+	return fundingRate, nil
+}
+
+// NOTE: 接口必须给出 from 和 to, since 为 0 时返回最近 limit 个周期(8 小时)的费率
+func (self *FuturesKucoin) FetchFundingRateHistory(symbol string, since int64, limit int64, params map[string]interface{}) (result []*FundingRateHistory, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	market := self.Market(symbol)
+	to := self.Milliseconds()
+	if since <= 0 {
+		count := limit
+		if count <= 0 {
+			count = 100
+		}
+		since = to - count*8*3600*1000
+	}
+	request := map[string]interface{}{
+		"symbol": market.Id,
+		"from":   since,
+		"to":     to,
+	}
+	response := self.ApiFunc("publicGetContractFundingRates", self.Extend(request, params), nil, nil)
+	data, _ := response["data"].([]interface{})
+	result = []*FundingRateHistory{}
+	for _, item := range data {
+		result = append(result, &FundingRateHistory{
+			Symbol:      symbol,
+			FundingRate: self.SafeFloat(item, "fundingRate", 0),
+			Timestamp:   self.SafeInteger(item, "timepoint", 0),
+			Info:        item,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp < result[j].Timestamp
+	})
+	if limit > 0 && int64(len(result)) > limit {
+		result = result[int64(len(result))-limit:]
+	}
+	return result, nil
+}
+
+// openInterest 为持仓张数, 价值按合约乘数和标记价格计算. 交易所没有历史持仓量接口
+func (self *FuturesKucoin) FetchOpenInterest(symbol string, params map[string]interface{}) (openInterest *OpenInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	contract := self.fetchContract(symbol)
+	amount := self.SafeFloat(contract, "openInterest", 0)
+	return &OpenInterest{
+		Symbol:    symbol,
+		Amount:    amount,
+		Value:     amount * math.Abs(self.SafeFloat(contract, "multiplier", 0)) * self.SafeFloat(contract, "markPrice", 0),
+		Timestamp: self.Milliseconds(),
+		Info:      contract,
+	}, nil
+}
+
+// NOTE: 只能修改全仓模式的杠杆, 逐仓模式的杠杆在下单时通过 leverage 参数指定
+func (self *FuturesKucoin) SetLeverage(symbol string, leverage float64, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"symbol":   self.Market(symbol).Id,
+		"leverage": self.Float64ToString(leverage),
+	}
+	return self.ApiFunc("privatePostV2ChangeCrossUserLeverage", self.Extend(request, params), nil, nil), nil
+}
+
+func (self *FuturesKucoin) SetMarginMode(symbol string, marginMode string, params map[string]interface{}) (response interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"symbol":     self.Market(symbol).Id,
+		"marginMode": strings.ToUpper(self.CheckMarginMode(marginMode)),
+	}
+	return self.ApiFunc("privatePostV2PositionChangeMarginMode", self.Extend(request, params), nil, nil), nil
+}
+
+// 只支持单向持仓
+func (self *FuturesKucoin) SetPositionMode(hedged bool, params map[string]interface{}) (response interface{}, err error) {
+	if hedged {
+		return nil, TypedError("NotSupported", self.Id+" setPositionMode supports one-way mode only")
+	}
+	return nil, nil
+}
+
+func (self *FuturesKucoin) AddMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"symbol": self.Market(symbol).Id,
+		"margin": amount,
+		"bizNo":  self.Uuid(),
+	}
+	// 返回追加后的仓位
+	response := self.ApiFunc("privatePostPositionMarginDepositMargin", self.Extend(request, params), nil, nil)
+	data := response["data"]
+	return &MarginModification{
+		Symbol: symbol,
+		Type:   "add",
+		Amount: amount,
+		Total:  self.SafeFloat(data, "posMargin", 0),
+		Info:   data,
+	}, nil
+}
+
+func (self *FuturesKucoin) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (result *MarginModification, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"symbol":         self.Market(symbol).Id,
+		"withdrawAmount": amount,
+	}
+	// {"code": "200000", "data": 0.1}, data 为实际减少的保证金
+	response := self.ApiFunc("privatePostMarginWithdrawMargin", self.Extend(request, params), nil, nil)
+	return &MarginModification{
+		Symbol: symbol,
+		Type:   "reduce",
+		Amount: self.SafeFloat(response, "data", amount),
+		Info:   response,
+	}, nil
+}
+
+// 每个交易对单独查询, 必须指定 symbols. 风险限额按仓位价值计算
+func (self *FuturesKucoin) FetchLeverageTiers(symbols []string, params map[string]interface{}) (result map[string][]*LeverageTier, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if len(symbols) == 0 {
+		self.RaiseException("ArgumentsRequired", self.Id+" fetchLeverageTiers requires symbols")
+	}
+	result = map[string][]*LeverageTier{}
+	for _, symbol := range symbols {
+		request := map[string]interface{}{
+			"symbol": self.Market(symbol).Id,
+		}
+		response := self.ApiFunc("publicGetContractsRiskLimitSymbol", self.Extend(request, params), nil, nil)
+		data, _ := response["data"].([]interface{})
+		tiers := []*LeverageTier{}
+		for _, item := range data {
+			tiers = append(tiers, &LeverageTier{
+				Tier:                  self.SafeInteger(item, "level", 0),
+				Symbol:                symbol,
+				MinNotional:           self.SafeFloat(item, "minRiskLimit", 0),
+				MaxNotional:           self.SafeFloat(item, "maxRiskLimit", 0),
+				MaintenanceMarginRate: self.SafeFloat(item, "maintainMargin", 0),
+				MaxLeverage:           self.SafeFloat(item, "maxLeverage", 0),
+				Info:                  item,
+			})
+		}
+		result[symbol] = tiers
+	}
+	return result, nil
+}
+
+// 只返回有持仓的交易对, symbol 为空时返回所有交易对的仓位
 func (self *FuturesKucoin) FetchPositions(symbol string, params map[string]interface{}) (result []*Position, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
 		}
 	}()
+	result = []*Position{}
+	if symbol == "" {
+		response := self.ApiFunc("privateGetPositions", params, nil, nil)
+		data, _ := response["data"].([]interface{})
+		var contractSizes map[string]float64
+		for _, item := range data {
+			if self.SafeFloat(item, "currentQty", 0) == 0 {
+				continue
+			}
+			if contractSizes == nil {
+				contractSizes = self.contractSizes()
+			}
+			result = append(result, self.parsePosition(item, contractSizes[self.SafeString(item, "symbol")]))
+		}
+		return result, nil
+	}
 	market := self.Market(symbol)
 	request := map[string]interface{}{
 		"symbol": market.Id,
 	}
 	response := self.ApiFunc("privateGetPosition", self.Extend(request, params), nil, nil)
 	data := response["data"].(map[string]interface{})
-	amount := self.SafeFloat(data, "currentQty", 0)
-	if amount == 0 {
-		return
+	if self.SafeFloat(data, "currentQty", 0) == 0 {
+		return result, nil
 	}
+	pos := self.parsePosition(data, math.Abs(self.SafeFloat(self.fetchContract(symbol), "multiplier", 0)))
+	pos.Symbol = symbol
+	result = append(result, pos)
+	return result, nil
+}
+
+// 数量为张数, markValue 有正负号
+func (self *FuturesKucoin) parsePosition(item interface{}, contractSize float64) *Position {
+	amount := self.SafeFloat(item, "currentQty", 0)
+	marginMode := strings.ToLower(self.SafeString(item, "marginMode", ""))
+	if marginMode == "" {
+		marginMode = "isolated"
+		if self.SafeBool(item, "crossMode") {
+			marginMode = "cross"
+		}
+	}
+	timestamp := self.SafeInteger(item, "currentTimestamp", 0)
 	pos := &Position{
-		Side:       "long",
-		Leverage:   self.SafeFloat(data, "realLeverage", 0),
-		Amount:     math.Abs(amount),
-		UsedAmount: 0,
-		Price:      self.SafeFloat(data, "avgEntryPrice", 0),
-		RealPnl:    self.SafeFloat(data, "realisedPnl", 0),
-		UnrealPnl:  self.SafeFloat(data, "unrealisedPnl", 0),
-		Info:       data,
+		Symbol:            self.symbolFromId(self.SafeString(item, "symbol")),
+		Side:              "long",
+		Leverage:          self.SafeFloat(item, "realLeverage", 0),
+		Amount:            math.Abs(amount),
+		UsedAmount:        0,
+		Price:             self.SafeFloat(item, "avgEntryPrice", 0),
+		RealPnl:           self.SafeFloat(item, "realisedPnl", 0),
+		UnrealPnl:         self.SafeFloat(item, "unrealisedPnl", 0),
+		MarkPrice:         self.SafeFloat(item, "markPrice", 0),
+		LiquidationPrice:  self.SafeFloat(item, "liquidationPrice", 0),
+		Notional:          math.Abs(self.SafeFloat(item, "markValue", 0)),
+		ContractSize:      contractSize,
+		Settle:            self.SafeCurrencyCode(self.SafeString(item, "settleCurrency", "")),
+		MarginMode:        marginMode,
+		InitialMargin:     self.SafeFloat(item, "posInit", 0),
+		MaintenanceMargin: self.SafeFloat(item, "posMaint", 0),
+		Timestamp:         timestamp,
+		Datetime:          self.Iso8601(timestamp),
+		Info:              item,
 	}
 	if amount < 0 {
 		pos.Side = "short"
 	}
-	result = append(result, pos)
+	return pos
+}
+
+// XBTUSDTM => XBT/USDTM
+func (self *FuturesKucoin) symbolFromId(id string) string {
+	for _, quote := range []string{"USDTM", "USDCM", "USDM"} {
+		if strings.HasSuffix(id, quote) && len(id) > len(quote) {
+			return id[:len(id)-len(quote)] + "/" + quote
+		}
+	}
+	return id
+}
+
+// Transfer 只支持合约账户和资金账户或现货账户之间划转
+func (self *FuturesKucoin) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	from, _ := self.AccountType(map[string]interface{}{"type": fromAccount}, "")
+	to, _ := self.AccountType(map[string]interface{}{"type": toAccount}, "")
+	request := map[string]interface{}{
+		"currency": code,
+		"amount":   self.Float64ToString(amount),
+	}
+	var response map[string]interface{}
+	if from == AccountTypeSwap && to != AccountTypeSwap {
+		request["recAccountType"] = self.TransferAccountId(to)
+		response = self.ApiFunc("privatePostV3TransferOut", self.Extend(request, params), nil, nil)
+	} else if to == AccountTypeSwap && from != AccountTypeSwap {
+		request["payAccountType"] = self.TransferAccountId(from)
+		response = self.ApiFunc("privatePostTransferIn", self.Extend(request, params), nil, nil)
+	} else {
+		self.RaiseException("NotSupported", self.Id+" transfer requires the swap account on one side")
+	}
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	result = &Transfer{
+		Id:          self.SafeString(data, "applyId", ""),
+		Currency:    code,
+		Amount:      amount,
+		FromAccount: from,
+		ToAccount:   to,
+		Status:      self.ParseTransferStatus(self.SafeString(data, "status", "ok")),
+		Timestamp:   self.SafeInteger(data, "createdAt", self.Milliseconds()),
+		Info:        response,
+	}
+	return
+}
+
+// FetchTransfers 只返回从合约账户转出的记录
+func (self *FuturesKucoin) FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) (result []*Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{}
+	if code != "" {
+		request["currency"] = code
+	}
+	if since > 0 {
+		request["startAt"] = since
+	}
+	if limit > 0 {
+		request["pageSize"] = limit
+	}
+	response := self.ApiFunc("privateGetTransferList", self.Extend(request, params), nil, nil)
+	items := self.SafeValue(self.SafeValue(response, "data", map[string]interface{}{}), "items", []interface{}{})
+	result = []*Transfer{}
+	for i := 0; i < self.Length(items); i++ {
+		item := self.Member(items, i)
+		result = append(result, &Transfer{
+			Id:          self.SafeString(item, "applyId", ""),
+			Currency:    self.SafeCurrencyCode(self.SafeString(item, "currency", "")),
+			Amount:      self.SafeFloat(item, "amount", 0),
+			FromAccount: AccountTypeSwap,
+			Status:      self.ParseTransferStatus(self.SafeString(item, "status", "")),
+			Timestamp:   self.SafeInteger(item, "createdAt", 0),
+			Info:        item,
+		})
+	}
 	return
 }
 
@@ -443,7 +1005,7 @@ func (self *FuturesKucoin) Sign(path string, api string, method string, params m
 	//                                †                 ↑
 	//
 	var endpoint string
-	if strings.HasPrefix(path, "v2/") {
+	if strings.HasPrefix(path, "v2/") || strings.HasPrefix(path, "v3/") {
 		endpoint = "/api/" + self.ImplodeParams(path, params)
 	} else {
 		endpoint = "/api/" + self.Options["version"].(string) + "/" + self.ImplodeParams(path, params)
@@ -454,7 +1016,7 @@ func (self *FuturesKucoin) Sign(path string, api string, method string, params m
 		headers = map[string]interface{}{}
 	}
 	if len(query) > 0 {
-		if method == "GET" {
+		if method == "GET" || method == "DELETE" {
 			endpoint += "?" + self.Urlencode(query)
 		} else {
 			endpart = self.Json(query)
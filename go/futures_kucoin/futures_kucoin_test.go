@@ -61,10 +61,18 @@ func TestAll(t *testing.T) {
 	testFetchOrderBook(t)
 	//testFetchBalance(t)
 	//order := testCreateOrder(t); _ = order
+	//order := testCreateOrderWithStopLoss(t); _ = order
 	//testFetchOrder(t, "62db8da5e97a730001c02fc5")
 	//testFetchOpenOrders(t)
+	//testFetchStopOrders(t)
 	//testCancelOrder(t, "62db8da5e97a730001c02fc5")
 	//testFetchMarkPrice(t)
+	//testSetLeverage(t)
+	//testFetchLeverageTiers(t)
+	//testFetchFundingRate(t)
+	//testFetchFundingRateHistory(t)
+	//testFetchOpenInterest(t)
+	//testFetchIndexOHLCV(t)
 	//testFetchPositions(t)
 }
 
@@ -96,6 +104,20 @@ func testCreateOrder(t *testing.T) *base.Order {
 	return order
 }
 
+func testCreateOrderWithStopLoss(t *testing.T) *base.Order {
+	// @ CreateOrder
+	params := map[string]interface{}{
+		"stopLoss":   9000,
+		"takeProfit": 11000,
+	}
+	order, err := ex.CreateOrder(symbol, "limit", "buy", 1 /*amount*/, 10000 /*price*/, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CreateOrderWithStopLoss:", symbol, order.Id)
+	return order
+}
+
 func testFetchOrder(t *testing.T, orderId string) {
 	// @ FetchOrder
 	o, err := ex.FetchOrder(orderId, symbol, nil)
@@ -114,6 +136,15 @@ func testFetchOpenOrders(t *testing.T) {
 	log.Println("##### FetchOpenOrders:", ex.Json(openOrders))
 }
 
+func testFetchStopOrders(t *testing.T) {
+	// @ FetchOpenOrders
+	stopOrders, err := ex.FetchOpenOrders(symbol, 0, 0, map[string]interface{}{"stop": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchStopOrders:", ex.Json(stopOrders))
+}
+
 func testCancelOrder(t *testing.T, orderId string) {
 	// @ CancelOrder
 	resp, err := ex.CancelOrder(orderId, symbol, nil)
@@ -133,10 +164,63 @@ func testFetchMarkPrice(t *testing.T) {
 }
 
 func testFetchPositions(t *testing.T) {
-	// @ FetchMarkPrice
-	resp, err := ex.FetchPositions(symbol, nil)
+	// @ FetchPositions, symbol 为空时返回所有仓位
+	for _, s := range []string{symbol, ""} {
+		positions, err := ex.FetchPositions(s, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pos := range positions {
+			log.Println("#####", pos.Symbol, pos.Side, pos.Amount, "liquidation distance:", pos.LiquidationDistance())
+		}
+		log.Println("##### FetchPositions:", ex.JsonIndent(positions))
+	}
+}
+
+func testFetchFundingRate(t *testing.T) {
+	fundingRate, err := ex.FetchFundingRate(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRate:", ex.JsonIndent(fundingRate))
+}
+
+func testFetchFundingRateHistory(t *testing.T) {
+	history, err := ex.FetchFundingRateHistory(symbol, 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchFundingRateHistory:", ex.JsonIndent(history))
+}
+
+func testFetchOpenInterest(t *testing.T) {
+	openInterest, err := ex.FetchOpenInterest(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOpenInterest:", ex.JsonIndent(openInterest))
+}
+
+func testFetchIndexOHLCV(t *testing.T) {
+	klines, err := ex.FetchIndexOHLCV(symbol, "1h", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchIndexOHLCV:", symbol, ex.JsonIndent(klines))
+}
+
+func testSetLeverage(t *testing.T) {
+	resp, err := ex.SetLeverage(symbol, 5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### SetLeverage:", ex.JsonIndent(resp))
+}
+
+func testFetchLeverageTiers(t *testing.T) {
+	tiers, err := ex.FetchLeverageTiers([]string{symbol}, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	log.Println("##### FetchPositions:", ex.Json(resp))
+	log.Println("##### FetchLeverageTiers:", ex.JsonIndent(tiers))
 }
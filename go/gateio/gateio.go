@@ -1,12 +1,15 @@
 package gateio
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha512"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	. "github.com/epheien/ccxt/go/base"
 	urllib "net/url"
+	"sort"
 	"strings"
 )
 
@@ -43,6 +46,8 @@ func (self *Gateio) Describe() []byte {
         "CORS": false,
         "createMarketOrder": false,
         "fetchCurrencies": true,
+        "fetchStatus": false,
+        "fetchTicker": true,
         "fetchTickers": true,
         "withdraw": true,
         "fetchDeposits": true,
@@ -56,22 +61,25 @@ func (self *Gateio) Describe() []byte {
         "fetchOrderTrades": true,
         "fetchOrders": true,
         "fetchOrder": true,
-        "fetchMyTrades": true
+        "fetchMyTrades": true,
+        "cancelAllOrders": true,
+        "cancelOrders": true,
+        "createOrders": true,
+        "editOrder": true
     },
     // 值只支持字符串形式
     "timeframes": {
-    //     "1m": 60,
-    //     "5m": 300,
-    //     "10m": 600,
-    //     "15m": 900,
-    //     "30m": 1800,
-    //     "1h": 3600,
-    //     "2h": 7200,
-    //     "4h": 14400,
-    //     "6h": 21600,
-    //     "12h": 43200,
-    //     "1d": 86400,
-    //     "1w": 604800
+        "10s": "10s",
+        "1m": "1m",
+        "5m": "5m",
+        "15m": "15m",
+        "30m": "30m",
+        "1h": "1h",
+        "4h": "4h",
+        "8h": "8h",
+        "1d": "1d",
+        "1w": "7d",
+        "1M": "30d"
     },
     "urls": {
         "logo": "https://user-images.githubusercontent.com/1294454/31784029-0313c702-b509-11e7-9ccc-bc0da6a0e435.jpg",
@@ -84,11 +92,14 @@ func (self *Gateio) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "spot/order_book",
                 "spot/currencies",
                 "spot/currency_pairs",
                 "spot/trades",
+                "spot/tickers",
+                "spot/candlesticks",
             ]
         },
         "private": {
@@ -96,14 +107,33 @@ func (self *Gateio) Describe() []byte {
                 "spot/accounts",
                 "spot/orders",
                 "spot/orders/{order_id}",
-                "margin/accounts"
+                "margin/accounts",
+                "futures/usdt/accounts",
+                "margin/cross/accounts",
+                "margin/cross/borrowable",
+                "margin/cross/loans",
+                "wallet/sub_account_transfers",
+                "wallet/deposit_address",
+                "wallet/deposits",
+                "wallet/withdrawals",
+                "wallet/withdraw_status",
+                "wallet/fee"
             ],
             "post": [
                 "spot/orders",
+                "spot/batch_orders",
+                "spot/cancel_batch_orders",
                 "wallet/transfers",
-                "wallet/sub_account_transfers"
+                "wallet/sub_account_transfers",
+                "margin/cross/loans",
+                "margin/cross/repayments",
+                "withdrawals"
             ],
             "delete": [
+                "spot/orders",
+                "spot/orders/{order_id}"
+            ],
+            "patch": [
                 "spot/orders/{order_id}"
             ]
         }
@@ -126,6 +156,8 @@ func (self *Gateio) Describe() []byte {
     },
     "options": {
         "fetchTradesMethod": "public_get_tradehistory_id",
+        "fetchOHLCVLimit": 1000,
+        "fetchTradesLimit": 1000,
         "limits": {
             "cost": {
                 "min": {
@@ -135,7 +167,25 @@ func (self *Gateio) Describe() []byte {
                 }
             }
         },
-        "account": "spot"
+        "account": "spot",
+        "accountTypes": ["spot", "margin", "isolated", "swap"],
+        "cancelOrdersBatchSize": 20,
+        "createOrdersBatchSize": 10,
+        "clientOrderIdPrefix": "t-",
+        "clientOrderIdMaxLength": 30,
+        "liquidationLevel": 1.1,
+        "accountsByType": {
+            "spot": "spot",
+            "margin": "cross_margin",
+            "isolated": "margin",
+            "futures": "delivery",
+            "swap": "futures"
+        },
+        "networks": {
+            "ERC20": "ETH",
+            "TRC20": "TRX",
+            "BEP20": "BSC"
+        }
     },
 }
 `)
@@ -148,6 +198,8 @@ func (self *Gateio) Market(symbol string) *Market {
 		Symbol: symbol,
 		Base:   li[0],
 		Quote:  li[1],
+		Type:   "spot",
+		Spot:   true,
 	}
 }
 
@@ -190,6 +242,8 @@ func (self *Gateio) FetchMarkets(params map[string]interface{}) ([]*Market, erro
 				"min": self.SafeFloat(market, "min_quote_amount"),
 			},
 		}
+		// NOTE: fee 为百分比, 是交易所的默认费率, 不含 VIP 等级
+		fee := self.SafeFloat(market, "fee", 0) / 100
 		result = append(result, map[string]interface{}{
 			"id":        id,
 			"symbol":    symbol,
@@ -197,7 +251,11 @@ func (self *Gateio) FetchMarkets(params map[string]interface{}) ([]*Market, erro
 			"quoteId":   quoteId,
 			"base":      base,
 			"quote":     quote,
+			"type":      "spot",
+			"spot":      true,
 			"active":    active,
+			"taker":     fee,
+			"maker":     fee,
 			"precision": precision,
 			"limits":    limits,
 			"info":      market,
@@ -215,6 +273,7 @@ func (self *Gateio) FetchOrderBook(symbol string, limit int64, params map[string
 	marketId := self.MarketId(symbol)
 	request := map[string]interface{}{
 		"currency_pair": marketId,
+		"with_id":       "true",
 	}
 	if limit > 0 {
 		request["limit"] = limit
@@ -222,28 +281,192 @@ func (self *Gateio) FetchOrderBook(symbol string, limit int64, params map[string
 	response := self.ApiFunc("publicGetSpotOrderBook", self.Extend(request, params), nil, nil)
 	timestamp := self.SafeInteger(response, "update")
 	orderbook := self.ParseOrderBook(response, timestamp, "bids", "asks", 0, 1)
+	orderbook.Nonce = self.SafeInteger(response, "id", 0)
 	return orderbook, nil
 }
 
+// NOTE: spot/tickers 不传 currency_pair 时返回所有交易对
+func (self *Gateio) ParseTicker(ticker interface{}) *Ticker {
+	marketId := self.SafeString(ticker, "currency_pair", "")
+	baseId, quoteId := self.Unpack2(strings.Split(marketId, "_"))
+	last := self.SafeFloat(ticker, "last", 0)
+	percentage := self.SafeFloat(ticker, "change_percentage", 0)
+	// 涨跌幅是百分比, 反推开盘价
+	open := last / (1 + percentage/100)
+	return &Ticker{
+		Symbol:      self.SafeCurrencyCode(baseId) + "/" + self.SafeCurrencyCode(quoteId),
+		Last:        last,
+		Bid:         self.SafeFloat(ticker, "highest_bid", 0),
+		BidQty:      self.SafeFloat(ticker, "highest_size", 0),
+		Ask:         self.SafeFloat(ticker, "lowest_ask", 0),
+		AskQty:      self.SafeFloat(ticker, "lowest_size", 0),
+		Open:        open,
+		High:        self.SafeFloat(ticker, "high_24h", 0),
+		Low:         self.SafeFloat(ticker, "low_24h", 0),
+		Close:       last,
+		BaseVolume:  self.SafeFloat(ticker, "base_volume", 0),
+		QuoteVolume: self.SafeFloat(ticker, "quote_volume", 0),
+		Change:      last - open,
+		Percentage:  percentage,
+		Info:        ticker,
+	}
+}
+
+func (self *Gateio) FetchTicker(symbol string, params map[string]interface{}) (ticker *Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"currency_pair": self.MarketId(symbol),
+	}
+	response := self.ApiFuncReturnList("publicGetSpotTickers", self.Extend(request, params), nil, nil)
+	if len(response) == 0 {
+		self.RaiseException("BadSymbol", self.Id+" fetchTicker got empty response for "+symbol)
+	}
+	ticker = self.ParseTicker(response[0])
+	ticker.Symbol = symbol
+	return ticker, nil
+}
+
+func (self *Gateio) FetchTickers(symbols []string, params map[string]interface{}) (tickers []*Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFuncReturnList("publicGetSpotTickers", params, nil, nil)
+	tickers = []*Ticker{}
+	for _, item := range response {
+		tickers = append(tickers, self.ParseTicker(item))
+	}
+	return self.FilterTickers(tickers, symbols), nil
+}
+
+// [t(秒), 成交额, close, high, low, open, 成交量]
+func (self *Gateio) ParseOHLCV(ohlcv interface{}) *OHLCV {
+	data := ohlcv.([]interface{})
+	return &OHLCV{
+		Timestamp: ToInteger(data[0]) * 1000,
+		Open:      ToFloat(data[5]),
+		High:      ToFloat(data[3]),
+		Low:       ToFloat(data[4]),
+		Close:     ToFloat(data[2]),
+		Volume:    ToFloat(self.SafeValue(data, 6, data[1])),
+		Info:      ohlcv,
+	}
+}
+
+func (self *Gateio) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
+	request := map[string]interface{}{
+		"currency_pair": self.MarketId(symbol),
+		"interval":      interval,
+	}
+	if since > 0 {
+		// NOTE: 指定 from 时不能同时指定 limit, 需要给出 to
+		request["from"] = since / 1000
+		if limit > 0 {
+			request["to"] = since/1000 + limit*self.ParseTimeframe(timeframe) - 1
+		}
+	} else if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList("publicGetSpotCandlesticks", self.Extend(request, params), nil, nil)
+	klines = []*OHLCV{}
+	for _, item := range response {
+		klines = append(klines, self.ParseOHLCV(item))
+	}
+	return self.FilterOHLCVs(klines, since, limit), nil
+}
+
+// 下单的账户 Options["account"] 为 margin 时表示逐仓杠杆, cross_margin 表示全仓杠杆
+func (self *Gateio) defaultAccountType() string {
+	switch self.SafeString(self.Options, "account", "spot") {
+	case "margin":
+		return AccountTypeIsolated
+	case "cross_margin":
+		return AccountTypeMargin
+	}
+	return AccountTypeSpot
+}
+
 func (self *Gateio) FetchBalance(params map[string]interface{}) (balanceResult *Account, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
 		}
 	}()
-	response := self.ApiFuncReturnList("privateGetSpotAccounts", params, nil, nil)
+	typ, query := self.AccountType(params, self.defaultAccountType())
 	result := map[string]interface{}{
-		"info": response,
+		"type": typ,
 	}
-	for _, one := range response {
+	switch typ {
+	case AccountTypeSpot:
+		response := self.ApiFuncReturnList("privateGetSpotAccounts", query, nil, nil)
+		result["info"] = response
+		for _, one := range response {
+			account := self.Account()
+			free := self.SafeFloat(one, "available")
+			used := self.SafeFloat(one, "locked")
+			cc := self.SafeString(one, "currency")
+			account["free"] = free
+			account["used"] = used
+			account["total"] = free + used
+			result[cc] = account
+		}
+	case AccountTypeMargin:
+		response := self.ApiFunc("privateGetMarginCrossAccounts", query, nil, nil)
+		result["info"] = response
+		balances := self.SafeValue(response, "balances", map[string]interface{}{}).(map[string]interface{})
+		for cc, one := range balances {
+			account := self.Account()
+			free := self.SafeFloat(one, "available")
+			used := self.SafeFloat(one, "freeze")
+			account["free"] = free
+			account["used"] = used
+			account["total"] = free + used
+			result[cc] = account
+		}
+	case AccountTypeIsolated:
+		// 多个交易对的同一币种合并
+		response := self.ApiFuncReturnList("privateGetMarginAccounts", query, nil, nil)
+		result["info"] = response
+		for _, pair := range response {
+			for _, key := range []string{"base", "quote"} {
+				one := self.SafeValue(pair, key, map[string]interface{}{})
+				cc := self.SafeString(one, "currency")
+				account := self.SafeValue(result, cc, self.Account()).(map[string]interface{})
+				free := self.SafeFloat(account, "free", 0) + self.SafeFloat(one, "available")
+				used := self.SafeFloat(account, "used", 0) + self.SafeFloat(one, "locked")
+				account["free"] = free
+				account["used"] = used
+				account["total"] = free + used
+				result[cc] = account
+			}
+		}
+	case AccountTypeSwap:
+		response := self.ApiFunc("privateGetFuturesUsdtAccounts", query, nil, nil)
+		result["info"] = response
 		account := self.Account()
-		free := self.SafeFloat(one, "available")
-		used := self.SafeFloat(one, "locked")
-		cc := self.SafeString(one, "currency")
+		free := self.SafeFloat(response, "available")
+		used := self.SafeFloat(response, "order_margin") + self.SafeFloat(response, "position_margin")
 		account["free"] = free
 		account["used"] = used
 		account["total"] = free + used
-		result[cc] = account
+		account["unrealPnl"] = self.SafeFloat(response, "unrealised_pnl")
+		result[self.SafeString(response, "currency")] = account
+	default:
+		self.UnsupportedAccountType(typ)
 	}
 	return self.ParseBalance(result), nil
 }
@@ -254,37 +477,85 @@ func (self *Gateio) CreateOrder(symbol string, _type string, side string, amount
 			err = self.PanicToError(e)
 		}
 	}()
+	text, query := self.PrefixedClientOrderId(params, "text")
+	request := self.createOrderRequest(symbol, _type, side, amount, price)
+	request["text"] = text
+	response := self.ApiFunc("privatePostSpotOrders", self.Extend(request, query), nil, nil)
+	data := response
+	timestamp := self.SafeInteger(response, "create_time_ms")
+	order := map[string]interface{}{
+		"id":            self.SafeString(data, "id"),
+		"clientOrderId": text,
+		"symbol":        symbol,
+		"type":          _type,
+		"side":          side,
+		"price":         price,
+		"amount":        amount,
+		"cost":          nil,
+		"filled":        nil,
+		"remaining":     nil,
+		"timestamp":     timestamp,
+		"datetime":      self.Iso8601(timestamp),
+		"fee":           nil,
+		"status":        "open",
+		"info":          data,
+	}
+	return self.ToOrder(order), nil
+}
+
+func (self *Gateio) createOrderRequest(symbol string, _type string, side string, amount float64, price float64) map[string]interface{} {
 	if _type != "limit" {
 		self.RaiseException("ExchangeError", self.Id+" allows limit orders only")
 	}
-	marketId := self.MarketId(symbol)
-	request := map[string]interface{}{
+	return map[string]interface{}{
 		"account":       self.Options["account"],
-		"currency_pair": marketId,
+		"currency_pair": self.MarketId(symbol),
 		"side":          side,
 		"price":         self.Float64ToString(price),
 		"amount":        self.Float64ToString(amount),
 	}
-	response := self.ApiFunc("privatePostSpotOrders", self.Extend(request, params), nil, nil)
-	data := response
-	timestamp := self.SafeInteger(response, "create_time_ms")
-	order := map[string]interface{}{
-		"id":        self.SafeString(data, "id"),
-		"symbol":    symbol,
-		"type":      _type,
-		"side":      side,
-		"price":     price,
-		"amount":    amount,
-		"cost":      nil,
-		"filled":    nil,
-		"remaining": nil,
-		"timestamp": timestamp,
-		"datetime":  self.Iso8601(timestamp),
-		"fee":       nil,
-		"status":    "open",
-		"info":      data,
-	}
-	return self.ToOrder(order), nil
+}
+
+// 每次最多 10 个订单, 超过的分批请求
+func (self *Gateio) CreateOrders(orders []*OrderRequest, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	batchSize := int(self.SafeInteger(self.Options, "createOrdersBatchSize", 10))
+	markets := map[string]*Market{}
+	result = self.CreateOrdersInBatches(orders, batchSize, func(order *OrderRequest) interface{} {
+		market := self.Market(order.Symbol)
+		markets[market.Id] = market
+		text, query := self.PrefixedClientOrderId(self.Extend(params, order.Params).(map[string]interface{}), "text")
+		request := self.createOrderRequest(order.Symbol, order.Type, order.Side, order.Amount, order.Price)
+		request["text"] = text
+		return self.Extend(request, query)
+	}, func(requests []interface{}) []*OrderResult {
+		response := self.ApiFuncReturnList("privatePostSpotBatchOrders", nil, nil, requests)
+		// NOTE: 返回的列表和请求顺序一致, 每个订单带有 succeeded, label, message 字段
+		batch := []*OrderResult{}
+		for _, item := range response {
+			one := &OrderResult{
+				Id:            self.SafeString(item, "id"),
+				ClientOrderId: self.SafeString(item, "text"),
+			}
+			if self.SafeValue(item, "succeeded", true) == false {
+				label := self.SafeString(item, "label")
+				one.Error = self.ExactlyMatchedError(self.Member(self.Exceptions, "exact"), label, self.Id+" "+label+" "+self.SafeString(item, "message"))
+			} else {
+				var market interface{}
+				if m, ok := markets[self.SafeString(item, "currency_pair")]; ok {
+					market = m
+				}
+				one.Order = self.ToOrder(self.ParseOrder(item, market))
+			}
+			batch = append(batch, one)
+		}
+		return batch
+	})
+	return result, nil
 }
 
 func (self *Gateio) ParseOrderStatus(status string) string {
@@ -311,8 +582,15 @@ func (self *Gateio) ParseOrder(order interface{}, market interface{}) (result ma
 	remaining := self.SafeFloat(order, "left")
 	filled := amount - remaining
 
+	// NOTE: 没有自定义 id 时 text 为 api, web 等下单渠道
+	clientOrderId := self.SafeString(order, "text")
+	if !strings.HasPrefix(clientOrderId, "t-") {
+		clientOrderId = ""
+	}
+
 	return map[string]interface{}{
 		"id":                 orderId,
+		"clientOrderId":      clientOrderId,
 		"symbol":             symbol,
 		"side":               side,
 		"amount":             amount,
@@ -371,7 +649,7 @@ func (self *Gateio) FetchTrades(symbol string, since int64, limit int64, params
 		request["limit"] = limit
 	}
 	if since > 0 {
-		request["from"] = since
+		request["from"] = since / 1000
 	}
 	response := self.ApiFuncReturnList("publicGetSpotTrades", self.Extend(request, params), nil, nil)
 	trades = self.ParseTrades(response, market, since, limit)
@@ -405,11 +683,13 @@ func (self *Gateio) FetchOrder(id string, symbol string, params map[string]inter
 		self.RaiseException("ArgumentsRequired", "symbol")
 	}
 	market := self.Market(symbol)
+	// NOTE: order_id 可以为 text, 但按 text 只能操作未完成的订单
+	orderId, query := self.OrderIdParam(id, params)
 	request := map[string]interface{}{
-		"order_id":      id,
+		"order_id":      orderId,
 		"currency_pair": market.Id,
 	}
-	response := self.ApiFunc("privateGetSpotOrdersOrderId", self.Extend(request, params), nil, nil)
+	response := self.ApiFunc("privateGetSpotOrdersOrderId", self.Extend(request, query), nil, nil)
 	return self.ToOrder(self.ParseOrder(response, market)), nil
 }
 
@@ -424,15 +704,636 @@ func (self *Gateio) CancelOrder(id string, symbol string, params map[string]inte
 	}
 
 	market := self.Market(symbol)
+	orderId, query := self.OrderIdParam(id, params)
 	request := map[string]interface{}{
-		"order_id":      id,
+		"order_id":      orderId,
 		"currency_pair": market.Id,
 	}
 	// NOTE: 撤掉的返回类型有时候是 []interface{} 有时候是 map[string]interface{}, 暂时不管
-	response = self.ApiFuncRaw("privateDeleteSpotOrdersOrderId", self.Extend(request, params).(map[string]interface{}), nil, nil)
+	response = self.ApiFuncRaw("privateDeleteSpotOrdersOrderId", self.Extend(request, query).(map[string]interface{}), nil, nil)
 	return response, nil
 }
 
+func (self *Gateio) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"currency_pair": market.Id,
+		"account":       self.Options["account"],
+	}
+	response := self.ApiFuncReturnList("privateDeleteSpotOrders", self.Extend(request, params), nil, nil)
+	return self.parseCancelResults(response, market), nil
+}
+
+// 每次最多撤销 20 个订单, 超过的分批请求
+func (self *Gateio) CancelOrders(ids []string, symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	market := self.Market(symbol)
+	batchSize := int(self.SafeInteger(self.Options, "cancelOrdersBatchSize", 20))
+	result = []*OrderResult{}
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		orders := []interface{}{}
+		for _, id := range ids[start:end] {
+			orders = append(orders, map[string]interface{}{
+				"currency_pair": market.Id,
+				"id":            id,
+				"account":       self.Options["account"],
+			})
+		}
+		response := self.ApiFuncReturnList("privatePostSpotCancelBatchOrders", params, nil, orders)
+		result = append(result, self.parseCancelResults(response, market)...)
+	}
+	return result, nil
+}
+
+// 批量撤单的返回中每个订单带有 succeeded, label, message 字段
+func (self *Gateio) parseCancelResults(response []interface{}, market *Market) []*OrderResult {
+	result := []*OrderResult{}
+	for _, item := range response {
+		one := &OrderResult{
+			Id:            self.SafeString(item, "id"),
+			ClientOrderId: self.SafeString(item, "text"),
+		}
+		succeeded := self.SafeValue(item, "succeeded", true)
+		if succeeded == false {
+			label := self.SafeString(item, "label")
+			one.Error = self.ExactlyMatchedError(self.Member(self.Exceptions, "exact"), label, self.Id+" "+label+" "+self.SafeString(item, "message"))
+		} else {
+			one.Order = self.ToOrder(self.ParseOrder(item, market))
+			if one.Order.Status == "" {
+				one.Order.Status = "canceled"
+			}
+		}
+		result = append(result, one)
+	}
+	return result
+}
+
+// 只支持修改限价单的价格和数量
+func (self *Gateio) EditOrder(id string, symbol string, _type string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	if _type != "limit" {
+		self.RaiseException("InvalidOrder", self.Id+" editOrder allows limit orders only")
+	}
+	market := self.Market(symbol)
+	orderId, params := self.OrderIdParam(id, params)
+	request := map[string]interface{}{
+		"order_id":      orderId,
+		"currency_pair": market.Id,
+		"account":       self.Options["account"],
+	}
+	if amount > 0 {
+		request["amount"] = self.Float64ToString(amount)
+	}
+	if price > 0 {
+		request["price"] = self.Float64ToString(price)
+	}
+	response := self.ApiFunc("privatePatchSpotOrdersOrderId", self.Extend(request, params), nil, nil)
+	return self.ToOrder(self.ParseOrder(response, market)), nil
+}
+
+// 目前只支持全仓杠杆, params 中给出 symbol 时返回 NotSupported
+func (self *Gateio) crossMarginParams(params map[string]interface{}) map[string]interface{} {
+	symbol, query := self.MarginSymbol(params)
+	if symbol != "" {
+		self.RaiseException("NotSupported", self.Id+" supports cross margin only")
+	}
+	return query
+}
+
+func (self *Gateio) Borrow(code string, amount float64, params map[string]interface{}) (result *MarginLoan, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	query := self.crossMarginParams(params)
+	request := map[string]interface{}{
+		"currency": code,
+		"amount":   self.Float64ToString(amount),
+	}
+	response := self.ApiFunc("privatePostMarginCrossLoans", self.Extend(request, query), nil, nil)
+	result = &MarginLoan{
+		Id:        self.SafeString(response, "id"),
+		Currency:  code,
+		Amount:    self.SafeFloat(response, "amount", amount),
+		Timestamp: self.SafeInteger(response, "create_time", self.Milliseconds()),
+		Info:      response,
+	}
+	return
+}
+
+// Repay 返回的 Id 为空, Info 为还款涉及的借款记录
+func (self *Gateio) Repay(code string, amount float64, params map[string]interface{}) (result *MarginLoan, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	query := self.crossMarginParams(params)
+	request := map[string]interface{}{
+		"currency": code,
+		"amount":   self.Float64ToString(amount),
+	}
+	response := self.ApiFuncReturnList("privatePostMarginCrossRepayments", self.Extend(request, query), nil, nil)
+	result = &MarginLoan{
+		Currency:  code,
+		Amount:    amount,
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+	return
+}
+
+// FetchBorrowInterest 账户接口不返回利率, InterestRate 为 0
+func (self *Gateio) FetchBorrowInterest(code string, params map[string]interface{}) (result []*BorrowInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	query := self.crossMarginParams(params)
+	response := self.ApiFunc("privateGetMarginCrossAccounts", query, nil, nil)
+	balances := self.SafeValue(response, "balances", map[string]interface{}{}).(map[string]interface{})
+	currencies := make([]string, 0, len(balances))
+	for currency := range balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	result = []*BorrowInterest{}
+	for _, currencyId := range currencies {
+		balance := balances[currencyId]
+		currency := self.SafeCurrencyCode(currencyId)
+		borrowed := self.SafeFloat(balance, "borrowed", 0)
+		interest := self.SafeFloat(balance, "interest", 0)
+		if (code != "" && currency != code) || (code == "" && borrowed == 0 && interest == 0) {
+			continue
+		}
+		result = append(result, &BorrowInterest{
+			Currency:  currency,
+			Borrowed:  borrowed,
+			Interest:  interest,
+			Timestamp: self.Milliseconds(),
+			Info:      balance,
+		})
+	}
+	return
+}
+
+func (self *Gateio) FetchMaxBorrowable(code string, params map[string]interface{}) (result *MaxBorrowable, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	query := self.crossMarginParams(params)
+	request := map[string]interface{}{
+		"currency": code,
+	}
+	response := self.ApiFunc("privateGetMarginCrossBorrowable", self.Extend(request, query), nil, nil)
+	result = &MaxBorrowable{
+		Currency: code,
+		Amount:   self.SafeFloat(response, "amount", 0),
+		Info:     response,
+	}
+	return
+}
+
+// FetchMarginRisk 资产和负债按 USDT 计价, gate 不返回强平的风险率, LiquidationLevel 取 Options["liquidationLevel"]
+func (self *Gateio) FetchMarginRisk(params map[string]interface{}) (result *MarginRisk, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	query := self.crossMarginParams(params)
+	response := self.ApiFunc("privateGetMarginCrossAccounts", query, nil, nil)
+	result = &MarginRisk{
+		MarginLevel:      self.SafeFloat(response, "risk", 0),
+		LiquidationLevel: self.SafeFloat(self.Options, "liquidationLevel", 0),
+		TotalAsset:       self.SafeFloat(response, "total", 0),
+		TotalDebt:        self.SafeFloat(response, "borrowed", 0) + self.SafeFloat(response, "interest", 0),
+		Currency:         "USDT",
+		Info:             response,
+	}
+	return
+}
+
+// 划转接口成功时可能不返回内容, 不能使用 ApiFunc
+func (self *Gateio) apiFuncNoContent(function string, params map[string]interface{}) map[string]interface{} {
+	body := self.ApiFuncRaw(function, params, nil, nil)
+	response := map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &response); err != nil {
+			self.RaiseException("BadResponse", self.Id+" "+function+" invalid response: "+string(body))
+		}
+	}
+	self.HandleErrors(0, "", "", "", nil, string(body), response, nil, nil)
+	return response
+}
+
+// Transfer 逐仓杠杆账户需要在 params 中给出 symbol, 合约账户默认 usdt 结算, 可通过 params 中的 settle 修改
+func (self *Gateio) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbol, query := self.MarginSymbol(params)
+	fromId := self.TransferAccountId(fromAccount)
+	toId := self.TransferAccountId(toAccount)
+	request := map[string]interface{}{
+		"currency": code,
+		"from":     fromId,
+		"to":       toId,
+		"amount":   self.Float64ToString(amount),
+	}
+	if fromId == "margin" || toId == "margin" {
+		if symbol == "" {
+			self.RaiseException("ArgumentsRequired", self.Id+" transfer requires a symbol param for the isolated account")
+		}
+		request["currency_pair"] = self.Market(symbol).Id
+	}
+	for _, id := range []string{fromId, toId} {
+		if id == "futures" || id == "delivery" {
+			request["settle"] = "usdt"
+		}
+	}
+	response := self.apiFuncNoContent("privatePostWalletTransfers", self.Extend(request, query).(map[string]interface{}))
+	result = &Transfer{
+		Id:          self.SafeString(response, "tx_id", ""),
+		Currency:    code,
+		Amount:      amount,
+		FromAccount: self.TransferAccountType(fromId),
+		ToAccount:   self.TransferAccountType(toId),
+		Status:      "ok",
+		Timestamp:   self.Milliseconds(),
+		Info:        response,
+	}
+	return
+}
+
+// FetchTransfers 交易所没有账户之间的划转记录, 只返回母子账户之间的划转
+func (self *Gateio) FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) (result []*Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{}
+	if since > 0 {
+		request["from"] = since / 1000
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList("privateGetWalletSubAccountTransfers", self.Extend(request, params), nil, nil)
+	result = []*Transfer{}
+	for _, item := range response {
+		currency := self.SafeCurrencyCode(self.SafeString(item, "currency", ""))
+		if code != "" && currency != code {
+			continue
+		}
+		subAccount := self.SafeString(item, "sub_account", "")
+		subAccountType := self.TransferAccountType(self.SafeString(item, "sub_account_type", "spot"))
+		transfer := &Transfer{
+			Currency:  currency,
+			Amount:    self.SafeFloat(item, "amount", 0),
+			Status:    "ok",
+			Timestamp: self.SafeInteger(item, "timest", 0) * 1000,
+			Info:      item,
+		}
+		if self.SafeString(item, "direction", "") == "to" {
+			transfer.FromAccount = AccountTypeSpot
+			transfer.ToAccount = subAccountType
+			transfer.ToSubAccount = subAccount
+		} else {
+			transfer.FromAccount = subAccountType
+			transfer.ToAccount = AccountTypeSpot
+			transfer.FromSubAccount = subAccount
+		}
+		result = append(result, transfer)
+	}
+	return
+}
+
+// SubAccountTransfer 只支持母账户的现货账户和子账户之间划转, 子账户 id 为 uid
+func (self *Gateio) SubAccountTransfer(code string, amount float64, fromSubAccount, toSubAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if (fromSubAccount == "") == (toSubAccount == "") {
+		self.RaiseException("NotSupported", self.Id+" subAccountTransfer only supports transfers between the master account and a sub-account")
+	}
+	fromAccount := self.SafeString(params, "fromAccount", AccountTypeSpot)
+	toAccount := self.SafeString(params, "toAccount", AccountTypeSpot)
+	query := self.Omit(self.Extend(params).(map[string]interface{}), []string{"fromAccount", "toAccount"})
+	request := map[string]interface{}{
+		"currency": code,
+		"amount":   self.Float64ToString(amount),
+	}
+	masterAccount, subAccountType := fromAccount, toAccount
+	if fromSubAccount == "" {
+		request["direction"] = "to"
+		request["sub_account"] = toSubAccount
+	} else {
+		request["direction"] = "from"
+		request["sub_account"] = fromSubAccount
+		masterAccount, subAccountType = toAccount, fromAccount
+	}
+	if self.TransferAccountId(masterAccount) != "spot" {
+		self.RaiseException("NotSupported", self.Id+" subAccountTransfer only supports the spot account of the master account")
+	}
+	request["sub_account_type"] = self.TransferAccountId(subAccountType)
+	response := self.apiFuncNoContent("privatePostWalletSubAccountTransfers", self.Extend(request, query).(map[string]interface{}))
+	result = &Transfer{
+		Id:             self.SafeString(response, "tx_id", ""),
+		Currency:       code,
+		Amount:         amount,
+		FromAccount:    self.TransferAccountType(self.TransferAccountId(fromAccount)),
+		ToAccount:      self.TransferAccountType(self.TransferAccountId(toAccount)),
+		FromSubAccount: fromSubAccount,
+		ToSubAccount:   toSubAccount,
+		Status:         "ok",
+		Timestamp:      self.Milliseconds(),
+		Info:           response,
+	}
+	return
+}
+
+func (self *Gateio) FetchTradingFee(symbol string, params map[string]interface{}) (result *TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"currency_pair": market.Id,
+	}
+	response := self.ApiFunc("privateGetWalletFee", self.Extend(request, params), nil, nil)
+	return self.parseTradingFee(response, symbol), nil
+}
+
+// FetchTradingFees 交易所只返回账户的费率, 所有交易对相同
+func (self *Gateio) FetchTradingFees(params map[string]interface{}) (result map[string]*TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	markets, err := self.FetchMarkets(nil)
+	if err != nil {
+		return nil, err
+	}
+	response := self.ApiFunc("privateGetWalletFee", params, nil, nil)
+	result = map[string]*TradingFee{}
+	for _, market := range markets {
+		result[market.Symbol] = self.parseTradingFee(response, market.Symbol)
+	}
+	return
+}
+
+// parseTradingFee 开启点卡或 GT 抵扣时 gt_discount 为 true, 抵扣比例由 gt_taker_fee 计算
+func (self *Gateio) parseTradingFee(response interface{}, symbol string) *TradingFee {
+	fee := &TradingFee{
+		Symbol: symbol,
+		Maker:  self.SafeFloat(response, "maker_fee", 0),
+		Taker:  self.SafeFloat(response, "taker_fee", 0),
+		Info:   response,
+	}
+	if self.ToBool(self.SafeValue(response, "gt_discount", false)) && fee.Taker > 0 {
+		fee.DiscountCurrency = "GT"
+		fee.Discount = self.SafeFloat(response, "gt_taker_fee", fee.Taker) / fee.Taker
+	}
+	return fee
+}
+
+// FetchCurrencies 有 apiKey 时才能获取各条链的提现手续费
+func (self *Gateio) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFuncReturnList("publicGetSpotCurrencies", params, nil, nil)
+	feesById := map[string]interface{}{}
+	if self.ApiKey != "" {
+		withdrawStatus := self.ApiFuncReturnList("privateGetWalletWithdrawStatus", nil, nil, nil)
+		feesById = self.IndexBy(withdrawStatus, "currency")
+	}
+	result = map[string]*Currency{}
+	for _, entry := range response {
+		id := self.SafeString(entry, "currency", "")
+		code := self.SafeCurrencyCode(id)
+		currency := &Currency{
+			Id:   id,
+			Code: code,
+			Name: self.SafeString(entry, "name", ""),
+			Info: entry,
+		}
+		status := self.SafeValue(feesById, id, nil)
+		fees := self.SafeValue(status, "withdraw_fix_on_chains", nil)
+		withdrawMin := self.SafeFloat(status, "withdraw_amount_mini", 0)
+		chains, _ := self.SafeValue(entry, "chains", nil).([]interface{})
+		for _, chain := range chains {
+			networkId := self.SafeString(chain, "name", "")
+			self.AddCurrencyNetwork(currency, &CurrencyNetwork{
+				Id:          networkId,
+				Name:        networkId,
+				Deposit:     !self.ToBool(self.SafeValue(chain, "deposit_disabled", false)),
+				Withdraw:    !self.ToBool(self.SafeValue(chain, "withdraw_disabled", false)),
+				Fee:         self.SafeFloat(fees, networkId, 0),
+				FeeKnown:    self.SafeValue(fees, networkId, nil) != nil,
+				WithdrawMin: withdrawMin,
+				Info:        chain,
+			})
+		}
+		result[code] = currency
+	}
+	return result, nil
+}
+
+// FetchStatus 没有系统状态接口, 总是返回 ok, 只根据交易对和币种列表给出暂停交易和充提的情况.
+// NOTE: 不能反映维护状态, 所以 has.fetchStatus 为 false
+func (self *Gateio) FetchStatus(params map[string]interface{}) (result *ExchangeStatus, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	result = &ExchangeStatus{
+		Status:  StatusOk,
+		Updated: self.Milliseconds(),
+	}
+	err = self.FetchSuspensions(result, true)
+	return result, err
+}
+
+// FetchDepositAddress network 为空时返回默认地址, 否则从 multichain_addresses 中选择
+func (self *Gateio) FetchDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"currency": code,
+	}
+	response := self.ApiFunc("privateGetWalletDepositAddress", self.Extend(request, params), nil, nil)
+	result = &DepositAddress{
+		Currency: code,
+		Address:  self.SafeString(response, "address", ""),
+		Status:   "ok",
+		Info:     response,
+	}
+	if network == "" {
+		return
+	}
+	chain := self.NetworkId(network)
+	for _, item := range self.SafeValue(response, "multichain_addresses", []interface{}{}).([]interface{}) {
+		if self.SafeString(item, "chain", "") == chain {
+			result.Address = self.SafeString(item, "address", "")
+			result.Tag = self.SafeString(item, "payment_id", "")
+			result.Network = self.NetworkCode(chain)
+			return
+		}
+	}
+	self.RaiseException("InvalidAddress", self.Id+" fetchDepositAddress no "+network+" address for "+code)
+	return
+}
+
+// CreateDepositAddress gateio 在获取充值地址时自动生成
+func (self *Gateio) CreateDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	return self.FetchDepositAddress(code, network, params)
+}
+
+func (self *Gateio) Withdraw(code string, amount float64, address string, tag string, network string, params map[string]interface{}) (result *Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"currency": code,
+		"address":  address,
+		"amount":   self.Float64ToString(amount),
+	}
+	if tag != "" {
+		request["memo"] = tag
+	}
+	if network != "" {
+		request["chain"] = self.NetworkId(network)
+	}
+	response := self.ApiFunc("privatePostWithdrawals", self.Extend(request, params), nil, nil)
+	result = self.parseTransaction(response, "withdrawal")
+	if result.Timestamp == 0 {
+		result.Timestamp = self.Milliseconds()
+	}
+	return
+}
+
+func (self *Gateio) FetchDeposits(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateGetWalletDeposits", "deposit", code, since, limit, params), nil
+}
+
+func (self *Gateio) FetchWithdrawals(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateGetWalletWithdrawals", "withdrawal", code, since, limit, params), nil
+}
+
+func (self *Gateio) fetchTransactions(method string, typ string, code string, since int64, limit int64, params map[string]interface{}) []*Transaction {
+	request := map[string]interface{}{}
+	if code != "" {
+		request["currency"] = code
+	}
+	if since > 0 {
+		request["from"] = since / 1000
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList(method, self.Extend(request, params), nil, nil)
+	result := []*Transaction{}
+	for _, item := range response {
+		result = append(result, self.parseTransaction(item, typ))
+	}
+	return result
+}
+
+func (self *Gateio) parseTransactionStatus(status string) string {
+	statuses := map[string]interface{}{
+		"DONE":    "ok",
+		"BCODE":   "ok",
+		"PEND":    "pending",
+		"REQUEST": "pending",
+		"MANUAL":  "pending",
+		"DMOVE":   "pending",
+		"VERIFY":  "pending",
+		"PROCES":  "pending",
+		"EXTPEND": "pending",
+		"CANCEL":  "canceled",
+		"FAIL":    "failed",
+		"INVALID": "failed",
+	}
+	return self.SafeString(statuses, status, status)
+}
+
+// 时间为秒
+func (self *Gateio) parseTransaction(item interface{}, typ string) *Transaction {
+	return &Transaction{
+		Id:        self.SafeString(item, "id", ""),
+		Txid:      self.SafeString(item, "txid", ""),
+		Type:      typ,
+		Currency:  self.SafeCurrencyCode(self.SafeString(item, "currency", "")),
+		Network:   self.NetworkCode(self.SafeString(item, "chain", "")),
+		Amount:    self.SafeFloat(item, "amount", 0),
+		Fee:       self.SafeFloat(item, "fee", 0),
+		Address:   self.SafeString(item, "address", ""),
+		Tag:       self.SafeString(item, "memo", ""),
+		Status:    self.parseTransactionStatus(self.SafeString(item, "status", "")),
+		Timestamp: self.SafeInteger(item, "timestamp", 0) * 1000,
+		Info:      item,
+	}
+}
+
 func (self *Gateio) genSign(method, url, query, body string) map[string]interface{} {
 	timestamp := self.Milliseconds() / 1000
 	m := sha512.New()
@@ -467,8 +1368,28 @@ func (self *Gateio) Sign(path string, api string, method string, params map[stri
 			if len(query) > 0 {
 				url += "?" + self.Urlencode(query)
 			}
-		} else {
+		} else if method == "PATCH" {
+			// NOTE: 改单接口的 currency_pair 和 account 放在查询字符串中, 其余参数放在请求体中
+			urlQuery := map[string]interface{}{}
+			for _, key := range []string{"currency_pair", "account"} {
+				if v, ok := query[key]; ok {
+					urlQuery[key] = v
+					delete(query, key)
+				}
+			}
+			queryString := self.Urlencode(urlQuery)
 			body = self.Json(query)
+			headers = self.genSign(method, u.Path, queryString, body.(string))
+			if len(urlQuery) > 0 {
+				url += "?" + queryString
+			}
+		} else {
+			// NOTE: 批量接口的请求体为数组, 由调用者通过 body 参数直接传入
+			if body == nil {
+				body = self.Json(query)
+			} else if _, ok := body.(string); !ok {
+				body = self.Json(body)
+			}
 			headers = self.genSign(method, u.Path, "", body.(string))
 		}
 		headers.(map[string]interface{})["Content-Type"] = "application/json"
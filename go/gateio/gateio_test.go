@@ -62,12 +62,23 @@ func TestAll(t *testing.T) {
 	testFetchOrderBook(t)
 	testFetchTrades(t)
 	//testFetchTicker(t)
+	//testFetchTickers(t)
 	//testFetchOHLCV(t)
 	//testFetchBalance(t)
+	//testFetchBalances(t)
+	//testTransfer(t)
+	//testFetchTradingFees(t)
+	//testFetchCurrencies(t)
+	//testTransactions(t)
 	//order := testCreateOrder(t); _ = order
+	//testCreateOrders(t)
 	//testFetchOrder(t, "11555864984")
+	//testFetchOrderByClientId(t, "t-3f2a9c0d5e6b4a1c8d7e9f0a1b2c")
 	//testFetchOpenOrders(t)
+	//testEditOrder(t, "11555864984")
 	//testCancelOrder(t, "11555864984")
+	//testCancelOrders(t, []string{"11555864984"})
+	//testCancelAllOrders(t)
 }
 
 func testFetchMarkets(t *testing.T) {
@@ -126,6 +137,14 @@ func testFetchTicker(t *testing.T) {
 	log.Println("##### FetchTicker:", symbol, ex.JsonIndent(ticker))
 }
 
+func testFetchTickers(t *testing.T) {
+	tickers, err := ex.FetchTickers([]string{symbol}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTickers:", ex.JsonIndent(tickers))
+}
+
 func testFetchOHLCV(t *testing.T) {
 	klines, err := ex.FetchOHLCV(symbol, "1h", 0, 10, nil)
 	if err != nil {
@@ -144,6 +163,82 @@ func testFetchBalance(t *testing.T) {
 	log.Println("##### FetchBalance:", ex.JsonIndent(balance))
 }
 
+func testFetchBalances(t *testing.T) {
+	// @ FetchBalances
+	balances, err := ex.FetchBalances(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchBalances:", ex.JsonIndent(balances))
+}
+
+func testTransfer(t *testing.T) {
+	// @ Transfer
+	transfer, err := ex.Transfer("USDT", 1, "spot", "swap", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### Transfer:", ex.JsonIndent(transfer))
+	// @ FetchTransfers
+	transfers, err := ex.FetchTransfers("USDT", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTransfers:", ex.JsonIndent(transfers))
+}
+
+func testFetchTradingFees(t *testing.T) {
+	// @ FetchTradingFee
+	fee, err := ex.FetchTradingFee(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTradingFee:", ex.JsonIndent(fee))
+	// @ LoadTradingFees
+	fees, err := ex.LoadTradingFees(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### LoadTradingFees:", len(fees))
+	// @ CalculateFee
+	estimate, err := ex.CalculateFee(symbol, "limit", "sell", 1, 100, "maker", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CalculateFee:", ex.JsonIndent(estimate))
+}
+
+func testFetchCurrencies(t *testing.T) {
+	// @ FetchCurrencies
+	currencies, err := ex.FetchCurrencies(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchCurrencies:", ex.JsonIndent(currencies["USDT"]))
+	log.Println("##### CheapestNetwork:", ex.JsonIndent(currencies["USDT"].CheapestNetwork()))
+}
+
+func testTransactions(t *testing.T) {
+	// @ FetchDepositAddress
+	address, err := ex.FetchDepositAddress("USDT", "TRC20", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchDepositAddress:", ex.JsonIndent(address))
+	// @ FetchDeposits
+	deposits, err := ex.FetchDeposits("USDT", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchDeposits:", ex.JsonIndent(deposits))
+	// @ FetchWithdrawals
+	withdrawals, err := ex.FetchWithdrawals("USDT", 0, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchWithdrawals:", ex.JsonIndent(withdrawals))
+}
+
 func testCreateOrder(t *testing.T) *base.Order {
 	// @ CreateOrder
 	order, err := ex.CreateOrder(symbol, "limit", "buy", 0.001 /*amount*/, 10000 /*price*/, nil)
@@ -154,6 +249,19 @@ func testCreateOrder(t *testing.T) *base.Order {
 	return order
 }
 
+func testCreateOrders(t *testing.T) []*base.OrderResult {
+	// @ CreateOrders
+	results, err := ex.CreateOrders([]*base.OrderRequest{
+		{Symbol: symbol, Type: "limit", Side: "buy", Amount: 0.001, Price: 10000},
+		{Symbol: symbol, Type: "limit", Side: "buy", Amount: 0.001, Price: 10001},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CreateOrders:", symbol, ex.JsonIndent(results))
+	return results
+}
+
 func testFetchOrder(t *testing.T, orderId string) {
 	// @ FetchOrder
 	o, err := ex.FetchOrder(orderId, symbol, nil)
@@ -163,6 +271,25 @@ func testFetchOrder(t *testing.T, orderId string) {
 	log.Println("##### FetchOrder:", ex.JsonIndent(o))
 }
 
+func testFetchOrderByClientId(t *testing.T, clientOrderId string) {
+	// @ FetchOrder
+	o, err := ex.FetchOrder("", symbol, map[string]interface{}{"clientOrderId": clientOrderId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchOrderByClientId:", ex.JsonIndent(o))
+}
+
+func testEditOrder(t *testing.T, orderId string) *base.Order {
+	// @ EditOrder
+	order, err := ex.EditOrder(orderId, symbol, "limit", "buy", 0.001 /*amount*/, 10001 /*price*/, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### EditOrder:", symbol, ex.JsonIndent(order))
+	return order
+}
+
 func testFetchOpenOrders(t *testing.T) []*base.Order {
 	// @ FetchOpenOrders
 	openOrders, err := ex.FetchOpenOrders(symbol, 0, 0, nil)
@@ -181,3 +308,21 @@ func testCancelOrder(t *testing.T, orderId string) {
 	}
 	log.Println("##### CancelOrder:", string(resp.([]byte)))
 }
+
+func testCancelOrders(t *testing.T, orderIds []string) {
+	// @ CancelOrders
+	results, err := ex.CancelOrders(orderIds, symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelOrders:", ex.JsonIndent(results))
+}
+
+func testCancelAllOrders(t *testing.T) {
+	// @ CancelAllOrders
+	results, err := ex.CancelAllOrders(symbol, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CancelAllOrders:", ex.JsonIndent(results))
+}
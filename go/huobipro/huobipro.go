@@ -88,6 +88,7 @@ func (self *Huobipro) Describe() []byte {
     },
     "api": {
         "v2Public": {
+            "cacheable": true,
             "get": [
                 "reference/currencies"
             ]
@@ -115,6 +116,7 @@ func (self *Huobipro) Describe() []byte {
             ]
         },
         "public": {
+            "cacheable": true,
             "get": [
                 "common/symbols",
                 "common/currencys",
@@ -210,6 +212,7 @@ func (self *Huobipro) Describe() []byte {
         "createMarketBuyOrderRequiresPrice": true,
         "fetchMarketsMethod": "publicGetCommonSymbols",
         "fetchBalanceMethod": "privateGetAccountAccountsIdBalance",
+        "accountTypes": ["spot"],
         "createOrderMethod": "privatePostOrderOrdersPlace",
         "language": "en-US"
     },
@@ -306,10 +309,10 @@ func (self *Huobipro) FetchOrderBook(symbol string, limit int64, params map[stri
 	return
 }
 
-func (self *Huobipro) FetchCurrencies(params map[string]interface{}) map[string]interface{} {
+func (self *Huobipro) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
 	defer func() {
 		if e := recover(); e != nil {
-			fmt.Println(e)
+			err = self.PanicToError(e)
 		}
 	}()
 	request := map[string]interface{}{
@@ -317,48 +320,33 @@ func (self *Huobipro) FetchCurrencies(params map[string]interface{}) map[string]
 	}
 	response := self.ApiFunc("publicGetSettingsCurrencys", self.Extend(request, params), nil, nil)
 	currencies := self.SafeValue(response, "data", nil)
-	result := map[string]interface{}{}
+	result = map[string]*Currency{}
 	for i := 0; i < self.Length(currencies); i++ {
 		currency := self.Member(currencies, i)
-		id := self.SafeValue(currency, "name", nil)
+		id := self.SafeString(currency, "name", "")
 		precision := self.SafeInteger(currency, "withdraw-precision", 0)
 		code := self.SafeCurrencyCode(id)
-		active := self.Member(currency, "visible").(bool) && self.Member(currency, "deposit-enabled").(bool) && self.Member(currency, "withdraw-enabled").(bool)
-		name := self.SafeString(currency, "display-name", "")
-		self.SetValue(result, code, map[string]interface{}{
-			"id":        id,
-			"code":      code,
-			"type":      "crypto",
-			"name":      name,
-			"active":    active,
-			"fee":       nil,
-			"precision": precision,
-			"limits": map[string]interface{}{
-				"amount": map[string]interface{}{
-					"min": math.Pow10(-int(precision)),
-					"max": math.Pow10(int(precision)),
-				},
-				"price": map[string]interface{}{
-					"min": math.Pow10(-int(precision)),
-					"max": math.Pow10(int(precision)),
-				},
-				"cost": map[string]interface{}{
-					"min": nil,
-					"max": nil,
-				},
-				"deposit": map[string]interface{}{
-					"min": self.SafeFloat(currency, "deposit-min-amount", 0),
-					"max": math.Pow10(int(precision)),
-				},
-				"withdraw": map[string]interface{}{
-					"min": self.SafeFloat(currency, "withdraw-min-amount", 0),
-					"max": math.Pow10(int(precision)),
-				},
-			},
-			"info": currency,
+		result[code] = &Currency{
+			Id:        id,
+			Code:      code,
+			Name:      self.SafeString(currency, "display-name", ""),
+			Precision: int(precision),
+			Info:      currency,
+		}
+		visible, _ := self.SafeValue(currency, "visible", false).(bool)
+		deposit, _ := self.SafeValue(currency, "deposit-enabled", false).(bool)
+		withdraw, _ := self.SafeValue(currency, "withdraw-enabled", false).(bool)
+		self.AddCurrencyNetwork(result[code], &CurrencyNetwork{
+			Id:          id,
+			Deposit:     visible && deposit,
+			Withdraw:    visible && withdraw,
+			DepositMin:  self.SafeFloat(currency, "deposit-min-amount", 0),
+			WithdrawMin: self.SafeFloat(currency, "withdraw-min-amount", 0),
+			Precision:   int(precision),
+			Info:        currency,
 		})
 	}
-	return result
+	return result, nil
 }
 
 func (self *Huobipro) FetchAccounts(params map[string]interface{}) []interface{} {
@@ -373,6 +361,10 @@ func (self *Huobipro) FetchBalance(params map[string]interface{}) (balanceResult
 			err = self.PanicToError(e)
 		}
 	}()
+	typ, _ := self.AccountType(params, AccountTypeSpot)
+	if typ != AccountTypeSpot {
+		self.UnsupportedAccountType(typ)
+	}
 	self.LoadMarkets()
 	self.LoadAccounts()
 	method := self.Member(self.Options, "fetchBalanceMethod").(string)
@@ -383,6 +375,7 @@ func (self *Huobipro) FetchBalance(params map[string]interface{}) (balanceResult
 	balances := self.SafeValue(self.Member(response, "data"), "list", []interface{}{})
 	result := map[string]interface{}{
 		"info": response,
+		"type": typ,
 	}
 	for i := 0; i < self.Length(balances); i++ {
 		balance := self.Member(balances, i)
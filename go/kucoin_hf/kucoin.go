@@ -77,6 +77,9 @@ func (self *Kucoin) Describe() []byte {
         "fetchMyTrades": true,
         "createOrder": true,
         "cancelOrder": true,
+        "cancelAllOrders": true,
+        "createOrders": true,
+        "editOrder": true,
         "fetchAccounts": true,
         "fetchFundingFee": true,
         "fetchOHLCV": true,
@@ -105,6 +108,7 @@ func (self *Kucoin) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "timestamp",
                 "status",
@@ -122,6 +126,7 @@ func (self *Kucoin) Describe() []byte {
                 "market/stats",
                 "currencies",
                 "currencies/{currency}",
+                "v3/currencies",
                 "prices",
                 "mark-price/{symbol}/current",
                 "margin/config"
@@ -132,8 +137,12 @@ func (self *Kucoin) Describe() []byte {
         },
         "private": {
             "get": [
+                "base-fee",
+                "trade-fees",
+                "user-info",
                 "accounts",
                 "accounts/{accountId}",
+                "accounts/ledgers",
                 "accounts/{accountId}/ledgers",
                 "accounts/{accountId}/holds",
                 "accounts/transferable",
@@ -150,11 +159,14 @@ func (self *Kucoin) Describe() []byte {
                 "orders",
                 "orders/{orderId}",
                 "hf/orders/{orderId}",
+                "hf/orders/client-order/{clientOid}",
                 "limit/orders",
                 "hf/orders/active",
+                "hf/accounts/ledgers",
                 "fills",
                 "limit/fills",
                 "margin/account",
+                "isolated/accounts",
                 "margin/borrow",
                 "margin/borrow/outstanding",
                 "margin/borrow/borrow/repaid",
@@ -178,6 +190,8 @@ func (self *Kucoin) Describe() []byte {
                 "orders",
                 "hf/orders",
                 "orders/multi",
+                "hf/orders/multi",
+                "hf/orders/alter",
                 "margin/borrow",
                 "margin/repay/all",
                 "margin/repay/single",
@@ -192,6 +206,7 @@ func (self *Kucoin) Describe() []byte {
                 "hf/orders",
                 "orders/{orderId}",
                 "hf/orders/{orderId}",
+                "hf/orders/client-order/{clientOid}",
                 "margin/lend/{orderId}"
             ]
         }
@@ -273,9 +288,37 @@ func (self *Kucoin) Describe() []byte {
         "version": "v1",
         "symbolSeparator": "-",
         "tradeType": "TRADE_HF",
+        "fetchOHLCVLimit": 1500,
+        "fetchTradesLimit": 100,
+        "fetchTradesSince": false,
         "fetchMyTradesMethod": "private_get_fills",
-        "fetchBalance": {
-            "type": "trade"
+        "accountTypes": ["spot", "margin", "isolated", "funding"],
+        "accountsByType": {
+            "spot": "trade_hf",
+            "margin": "margin",
+            "isolated": "isolated",
+            "funding": "main"
+        },
+        "kcsPayFees": false,
+        "kcsDiscount": 0.8,
+        "fetchLedgerWindow": 86400000,
+        "ledgerTypes": {
+            "Exchange": "trade",
+            "Trade_Exchange": "trade",
+            "TRADE_EXCHANGE": "trade",
+            "KCS Pay Fees": "fee",
+            "Refunded Fees": "rebate",
+            "Rebate": "rebate",
+            "Deposit": "deposit",
+            "Withdrawal": "withdrawal",
+            "Transfer": "transfer",
+            "TRANSFER": "transfer",
+            "Sub-account transfer": "transfer"
+        },
+        "networks": {
+            "ERC20": "eth",
+            "TRC20": "trx",
+            "BEP20": "bsc"
         },
         "versions": {
             "public": {
@@ -288,6 +331,9 @@ func (self *Kucoin) Describe() []byte {
                 }
             },
             "private": {
+                "GET": {
+                    "user-info": "v2"
+                },
                 "POST": {
                     "accounts/inner-transfer": "v2",
                     "accounts/sub-transfer": "v2"
@@ -341,6 +387,8 @@ func (self *Kucoin) FetchMarkets(params map[string]interface{}) ([]*Market, erro
 			"quoteId":   quoteId,
 			"base":      base,
 			"quote":     quote,
+			"type":      "spot",
+			"spot":      true,
 			"active":    active,
 			"precision": precision,
 			"limits":    limits,
@@ -350,28 +398,70 @@ func (self *Kucoin) FetchMarkets(params map[string]interface{}) ([]*Market, erro
 	return self.ToMarkets(result), nil
 }
 
-func (self *Kucoin) FetchCurrencies(params map[string]interface{}) map[string]interface{} {
-	response := self.ApiFunc("publicGetCurrencies", params, nil, nil)
+func (self *Kucoin) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFunc("publicGetV3Currencies", params, nil, nil)
 	responseData := self.Member(response, "data")
-	result := map[string]interface{}{}
+	result = map[string]*Currency{}
 	for i := 0; i < self.Length(responseData); i++ {
 		entry := self.Member(responseData, i)
 		id := self.SafeString(entry, "currency", "")
-		name := self.SafeString(entry, "fullName", "")
 		code := self.SafeCurrencyCode(id)
-		precision := self.SafeInteger(entry, "precision", 0)
-		self.SetValue(result, code, map[string]interface{}{
-			"id":        id,
-			"name":      name,
-			"code":      code,
-			"precision": precision,
-			"info":      entry,
-			"active":    nil,
-			"fee":       nil,
-			"limits":    self.Limits,
-		})
+		currency := &Currency{
+			Id:        id,
+			Code:      code,
+			Name:      self.SafeString(entry, "fullName", ""),
+			Precision: int(self.SafeInteger(entry, "precision", 0)),
+			Info:      entry,
+		}
+		// NOTE: 没有链的币种 chains 为 null
+		chains, _ := self.SafeValue(entry, "chains", nil).([]interface{})
+		for _, chain := range chains {
+			self.AddCurrencyNetwork(currency, &CurrencyNetwork{
+				Id:            self.SafeString(chain, "chainId", ""),
+				Name:          self.SafeString(chain, "chainName", ""),
+				Deposit:       self.ToBool(self.SafeValue(chain, "isDepositEnabled", false)),
+				Withdraw:      self.ToBool(self.SafeValue(chain, "isWithdrawEnabled", false)),
+				Fee:           self.SafeFloat(chain, "withdrawalMinFee", 0),
+				FeeKnown:      self.SafeValue(chain, "withdrawalMinFee", nil) != nil,
+				WithdrawMin:   self.SafeFloat(chain, "withdrawalMinSize", 0),
+				WithdrawMax:   self.SafeFloat(chain, "maxWithdraw", 0),
+				DepositMin:    self.SafeFloat(chain, "depositMinSize", 0),
+				Confirmations: self.SafeInteger(chain, "confirms", 0),
+				Precision:     int(self.SafeInteger(chain, "withdrawPrecision", 0)),
+				Info:          chain,
+			})
+		}
+		result[code] = currency
 	}
-	return result
+	return result, nil
+}
+
+// FetchStatus status 为 open, close 或 cancelonly(只能撤单), 后两者都视为维护中
+func (self *Kucoin) FetchStatus(params map[string]interface{}) (result *ExchangeStatus, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFunc("publicGetStatus", params, nil, nil)
+	data := self.SafeValue(response, "data", nil)
+	result = &ExchangeStatus{
+		Status:  StatusOk,
+		Updated: self.Milliseconds(),
+		Message: self.SafeString(data, "msg", ""),
+		Info:    response,
+	}
+	if self.SafeString(data, "status", "") != "open" {
+		result.Status = StatusMaintenance
+		return result, nil
+	}
+	err = self.FetchSuspensions(result, true)
+	return result, err
 }
 
 func (self *Kucoin) FetchOrderBook(symbol string, limit int64, params map[string]interface{}) (orderBook *OrderBook, err error) {
@@ -396,38 +486,137 @@ func (self *Kucoin) FetchOrderBook(symbol string, limit int64, params map[string
 	return orderbook, nil
 }
 
-func (self *Kucoin) CreateOrder(symbol string, _type string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {
+// market/stats 和 market/allTickers 中的字段基本相同, 后者多了买一卖一的数量
+func (self *Kucoin) ParseTicker(ticker interface{}, timestamp int64) *Ticker {
+	marketId := self.SafeString(ticker, "symbol", "")
+	baseId, quoteId := self.Unpack2(strings.Split(marketId, "-"))
+	last := self.SafeFloat(ticker, "last", 0)
+	change := self.SafeFloat(ticker, "changePrice", 0)
+	return &Ticker{
+		Symbol:      self.SafeCurrencyCode(baseId) + "/" + self.SafeCurrencyCode(quoteId),
+		Timestamp:   timestamp,
+		Datetime:    self.Iso8601(timestamp),
+		Last:        last,
+		Bid:         self.SafeFloat(ticker, "buy", 0),
+		BidQty:      self.SafeFloat(ticker, "bestBidSize", 0),
+		Ask:         self.SafeFloat(ticker, "sell", 0),
+		AskQty:      self.SafeFloat(ticker, "bestAskSize", 0),
+		Open:        last - change,
+		High:        self.SafeFloat(ticker, "high", 0),
+		Low:         self.SafeFloat(ticker, "low", 0),
+		Close:       last,
+		BaseVolume:  self.SafeFloat(ticker, "vol", 0),
+		QuoteVolume: self.SafeFloat(ticker, "volValue", 0),
+		Vwap:        self.SafeFloat(ticker, "averagePrice", 0),
+		Change:      change,
+		Percentage:  self.SafeFloat(ticker, "changeRate", 0) * 100,
+		Info:        ticker,
+	}
+}
+
+// NOTE: market/stats 没有买一卖一的数量, 需要再请求 level1 行情
+func (self *Kucoin) FetchTicker(symbol string, params map[string]interface{}) (ticker *Ticker, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
 		}
 	}()
-	self.LoadMarkets()
-	marketId := self.MarketId(symbol)
-	clientOrderId := self.SafeString2(params, "clientOid", "clientOrderId", self.Uuid())
-	params = self.Omit(params, []interface{}{"clientOid", "clientOrderId"})
 	request := map[string]interface{}{
-		"clientOid": clientOrderId,
-		"side":      side,
-		"symbol":    marketId,
-		"type":      _type,
-		"tradeType": self.Options["tradeType"],
+		"symbol": self.MarketId(symbol),
 	}
-	if _type != "market" {
-		self.SetValue(request, "price", self.Float64ToString(price))
-		self.SetValue(request, "size", self.Float64ToString(amount))
-	} else {
-		if params["quoteAmount"] != nil {
-			self.SetValue(request, "funds", self.Float64ToString(amount))
-		} else {
-			self.SetValue(request, "size", self.Float64ToString(amount))
+	response := self.ApiFunc("publicGetMarketStats", self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	ticker = self.ParseTicker(data, self.SafeInteger(data, "time", 0))
+	ticker.Symbol = symbol
+	request["level"] = "1"
+	response = self.ApiFunc("publicGetMarketOrderbookLevelLevel", request, nil, nil)
+	level1 := self.SafeValue(response, "data", map[string]interface{}{})
+	ticker.Bid = self.SafeFloat(level1, "bestBid", ticker.Bid)
+	ticker.BidQty = self.SafeFloat(level1, "bestBidSize", 0)
+	ticker.Ask = self.SafeFloat(level1, "bestAsk", ticker.Ask)
+	ticker.AskQty = self.SafeFloat(level1, "bestAskSize", 0)
+	return ticker, nil
+}
+
+// 一次请求返回所有交易对, symbols 为空时返回全部
+func (self *Kucoin) FetchTickers(symbols []string, params map[string]interface{}) (tickers []*Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
 		}
+	}()
+	response := self.ApiFunc("publicGetMarketAllTickers", params, nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	timestamp := self.SafeInteger(data, "time", 0)
+	tickers = []*Ticker{}
+	for _, item := range self.SafeValue(data, "ticker", []interface{}{}).([]interface{}) {
+		tickers = append(tickers, self.ParseTicker(item, timestamp))
+	}
+	return self.FilterTickers(tickers, symbols), nil
+}
+
+// [time(秒), open, close, high, low, volume, turnover]
+func (self *Kucoin) ParseOHLCV(ohlcv interface{}) *OHLCV {
+	data := ohlcv.([]interface{})
+	return &OHLCV{
+		Timestamp: ToInteger(data[0]) * 1000,
+		Open:      ToFloat(data[1]),
+		High:      ToFloat(data[3]),
+		Low:       ToFloat(data[4]),
+		Close:     ToFloat(data[2]),
+		Volume:    ToFloat(data[5]),
+		Info:      ohlcv,
+	}
+}
+
+// 每次最多返回 1500 根 K 线, 接口没有 limit 参数, 返回后再截取.
+// NOTE: 只指定 startAt 时返回的是最新的 K 线, 需要同时指定 endAt
+func (self *Kucoin) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
 	}
+	request := map[string]interface{}{
+		"symbol": self.MarketId(symbol),
+		"type":   interval,
+	}
+	if since > 0 {
+		count := limit
+		if count <= 0 || count > 1500 {
+			count = 1500
+		}
+		request["startAt"] = since / 1000
+		request["endAt"] = since/1000 + count*self.ParseTimeframe(timeframe)
+	}
+	response := self.ApiFunc("publicGetMarketCandles", self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data", []interface{}{}).([]interface{})
+	// NOTE: 返回的 K 线按时间降序
+	klines = []*OHLCV{}
+	for i := len(data) - 1; i >= 0; i-- {
+		klines = append(klines, self.ParseOHLCV(data[i]))
+	}
+	return self.FilterOHLCVs(klines, since, limit), nil
+}
+
+func (self *Kucoin) CreateOrder(symbol string, _type string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	request := self.createOrderRequest(symbol, _type, side, amount, price, params)
+	clientOrderId := request["clientOid"]
 	var response map[string]interface{}
 	if self.Options["tradeType"].(string) == "TRADE_HF" {
-		response = self.ApiFunc("privatePostHfOrders", self.Extend(request, params), nil, nil)
+		response = self.ApiFunc("privatePostHfOrders", request, nil, nil)
 	} else {
-		response = self.ApiFunc("privatePostMarginOrder", self.Extend(request, params), nil, nil)
+		response = self.ApiFunc("privatePostMarginOrder", request, nil, nil)
 	}
 	data := self.SafeValue(response, "data")
 	timestamp := self.Milliseconds()
@@ -467,10 +656,168 @@ func (self *Kucoin) CancelOrder(id string, symbol string, params map[string]inte
 		"orderId": id,
 		"symbol":  market.Id,
 	}
-	response = self.ApiFunc("privateDeleteHfOrdersOrderId", self.Extend(request, params), nil, nil)
+	method := "privateDeleteHfOrdersOrderId"
+	query := self.Extend(params).(map[string]interface{})
+	if clientOid := self.SafeString(query, "clientOrderId", ""); clientOid != "" {
+		method = "privateDeleteHfOrdersClientOrderClientOid"
+		request = map[string]interface{}{
+			"clientOid": clientOid,
+			"symbol":    market.Id,
+		}
+		delete(query, "clientOrderId")
+	}
+	response = self.ApiFunc(method, self.Extend(request, query), nil, nil)
 	return response, nil
 }
 
+func (self *Kucoin) createOrderRequest(symbol string, _type string, side string, amount float64, price float64, params map[string]interface{}) map[string]interface{} {
+	marketId := self.MarketId(symbol)
+	clientOrderId, params := self.ParseClientOrderId(params, "clientOid")
+	request := map[string]interface{}{
+		"clientOid": clientOrderId,
+		"side":      side,
+		"symbol":    marketId,
+		"type":      _type,
+		"tradeType": self.Options["tradeType"],
+	}
+	if _type != "market" {
+		self.SetValue(request, "price", self.Float64ToString(price))
+		self.SetValue(request, "size", self.Float64ToString(amount))
+	} else {
+		if params["quoteAmount"] != nil {
+			self.SetValue(request, "funds", self.Float64ToString(amount))
+		} else {
+			self.SetValue(request, "size", self.Float64ToString(amount))
+		}
+	}
+	return self.Extend(request, params).(map[string]interface{})
+}
+
+// NOTE: hf/orders/multi 只支持限价单, 每次最多 5 个
+func (self *Kucoin) CreateOrders(orders []*OrderRequest, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if self.Options["tradeType"].(string) != "TRADE_HF" {
+		return self.Exchange.CreateOrders(orders, params)
+	}
+	for _, order := range orders {
+		if order.Type != "limit" {
+			return self.Exchange.CreateOrders(orders, params)
+		}
+	}
+	batchSize := int(self.SafeInteger(self.Options, "createOrdersBatchSize", 5))
+	symbols := map[string]string{}
+	result = self.CreateOrdersInBatches(orders, batchSize, func(order *OrderRequest) interface{} {
+		symbols[self.MarketId(order.Symbol)] = order.Symbol
+		orderParams := self.Extend(params, order.Params).(map[string]interface{})
+		request := self.createOrderRequest(order.Symbol, order.Type, order.Side, order.Amount, order.Price, orderParams)
+		return self.Omit(request, "tradeType")
+	}, func(requests []interface{}) []*OrderResult {
+		request := map[string]interface{}{
+			"orderList": requests,
+		}
+		response := self.ApiFunc("privatePostHfOrdersMulti", request, nil, nil)
+		items := self.SafeValue(response, "data", []interface{}{})
+		batch := []*OrderResult{}
+		// NOTE: 返回的 data 和 orderList 顺序一致, 每个订单带有 success 和 failMsg 字段
+		for i, item := range items.([]interface{}) {
+			one := &OrderResult{
+				Id:            self.SafeString(item, "orderId"),
+				ClientOrderId: self.SafeString(requests[i], "clientOid"),
+			}
+			if self.SafeValue(item, "success", false) != true {
+				message := self.SafeString(item, "failMsg")
+				one.Error = self.ExactlyMatchedError(self.Member(self.Exceptions, "exact"), message, self.Id+" "+message)
+			} else {
+				timestamp := self.Milliseconds()
+				one.Order = self.ToOrder(map[string]interface{}{
+					"id":            one.Id,
+					"clientOrderId": one.ClientOrderId,
+					"symbol":        symbols[self.SafeString(requests[i], "symbol")],
+					"type":          self.SafeString(requests[i], "type"),
+					"side":          self.SafeString(requests[i], "side"),
+					"price":         self.SafeFloat(requests[i], "price"),
+					"amount":        self.SafeFloat(requests[i], "size"),
+					"timestamp":     timestamp,
+					"datetime":      self.Iso8601(timestamp),
+					"status":        "open",
+					"info":          item,
+				})
+			}
+			batch = append(batch, one)
+		}
+		return batch
+	})
+	return result, nil
+}
+
+// NOTE: alter 接口由交易所撤单后重新下单, 所以返回的是新订单的 id.
+// params["clientOid"] 不为空时按 clientOid 改单
+func (self *Kucoin) EditOrder(id string, symbol string, _type string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	if self.Options["tradeType"].(string) != "TRADE_HF" {
+		return self.Exchange.EditOrder(id, symbol, _type, side, amount, price, params)
+	}
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	if self.SafeString(params, "clientOid") == "" {
+		request["orderId"] = id
+	}
+	if amount > 0 {
+		request["newSize"] = self.Float64ToString(amount)
+	}
+	if price > 0 {
+		request["newPrice"] = self.Float64ToString(price)
+	}
+	response := self.ApiFunc("privatePostHfOrdersAlter", self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data")
+	timestamp := self.Milliseconds()
+	order := map[string]interface{}{
+		"id":            self.SafeString(data, "newOrderId", ""),
+		"clientOrderId": self.SafeString(data, "clientOid", ""),
+		"symbol":        symbol,
+		"type":          _type,
+		"side":          side,
+		"price":         price,
+		"amount":        amount,
+		"timestamp":     timestamp,
+		"datetime":      self.Iso8601(timestamp),
+		"status":        "open",
+		"info":          data,
+	}
+	return self.ToOrder(order), nil
+}
+
+// NOTE: 高频账户的撤销全部挂单接口只返回交易对, 没有订单明细, 所以结果为 nil
+func (self *Kucoin) CancelAllOrders(symbol string, params map[string]interface{}) (result []*OrderResult, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if symbol == "" {
+		self.RaiseException("ArgumentsRequired", "symbol")
+	}
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbol": market.Id,
+	}
+	self.ApiFunc("privateDeleteHfOrders", self.Extend(request, params), nil, nil)
+	return nil, nil
+}
+
 func (self *Kucoin) FetchOpenOrders(symbol string, since int64, limit int64, params map[string]interface{}) (result []*Order, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -522,7 +869,17 @@ func (self *Kucoin) FetchOrder(id string, symbol string, params map[string]inter
 		"orderId": id,
 		"symbol":  market.Id,
 	}
-	response := self.ApiFunc("privateGetHfOrdersOrderId", self.Extend(request, params), nil, nil)
+	method := "privateGetHfOrdersOrderId"
+	query := self.Extend(params).(map[string]interface{})
+	if clientOid := self.SafeString(query, "clientOrderId", ""); clientOid != "" {
+		method = "privateGetHfOrdersClientOrderClientOid"
+		request = map[string]interface{}{
+			"clientOid": clientOid,
+			"symbol":    market.Id,
+		}
+		delete(query, "clientOrderId")
+	}
+	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
 	responseData := self.Member(response, "data")
 	return self.ToOrder(self.ParseOrder(responseData, market)), nil
 }
@@ -621,28 +978,69 @@ func (self *Kucoin) FetchBalance(params map[string]interface{}) (balanceResult *
 		}
 	}()
 	self.LoadMarkets()
-	var _type interface{}
-	request := map[string]interface{}{
-		"type": strings.ToLower(self.Options["tradeType"].(string)),
-	}
+	defaultType := AccountTypeSpot
 	if self.Options["tradeType"].(string) != "TRADE_HF" {
-		request["type"] = "margin"
+		defaultType = AccountTypeMargin
 	}
-	if self.ToBool(self.InMap("type", params)) {
-		_type = self.Member(params, "type")
-		if self.ToBool(!self.TestNil(_type)) {
-			self.SetValue(request, "type", _type)
+	typ, query := self.AccountType(params, defaultType)
+	result := map[string]interface{}{
+		"type": typ,
+	}
+	if typ == AccountTypeIsolated {
+		// 多个交易对的同一币种合并
+		response := self.ApiFunc("privateGetIsolatedAccounts", query, nil, nil)
+		result["info"] = response
+		assets := self.SafeValue(self.SafeValue(response, "data", map[string]interface{}{}), "assets", []interface{}{})
+		for i := 0; i < self.Length(assets); i++ {
+			pair := self.Member(assets, i)
+			for _, key := range []string{"baseAsset", "quoteAsset"} {
+				balance := self.SafeValue(pair, key, map[string]interface{}{})
+				code := self.SafeCurrencyCode(self.SafeString(balance, "currency", ""))
+				account := self.SafeValue(result, code, self.Account()).(map[string]interface{})
+				account["total"] = self.SafeFloat(account, "total", 0) + self.SafeFloat(balance, "totalBalance", 0)
+				account["free"] = self.SafeFloat(account, "free", 0) + self.SafeFloat(balance, "availableBalance", 0)
+				account["used"] = self.SafeFloat(account, "used", 0) + self.SafeFloat(balance, "holdBalance", 0)
+				account["borrowed"] = self.SafeFloat(account, "borrowed", 0) + self.SafeFloat(balance, "liability", 0)
+				account["interest"] = self.SafeFloat(account, "interest", 0) + self.SafeFloat(balance, "interest", 0)
+				account["netEquity"] = account["total"].(float64) - account["borrowed"].(float64) - account["interest"].(float64)
+				result[code] = account
+			}
 		}
-		params = self.Omit(params, "type")
-	} else {
-		options := self.SafeValue(self.Options, "fetchBalance", map[string]interface{}{})
-		_type = self.SafeString(options, "type", "trade")
+		return self.ParseBalance(result), nil
 	}
-	response := self.ApiFunc("privateGetAccounts", self.Extend(request, params), nil, nil)
-	data := self.SafeValue(response, "data", []interface{}{})
-	result := map[string]interface{}{
-		"info": response,
+	if typ == AccountTypeMargin {
+		// liability 为本金加利息
+		response := self.ApiFunc("privateGetMarginAccount", query, nil, nil)
+		result["info"] = response
+		accounts := self.SafeValue(self.SafeValue(response, "data", map[string]interface{}{}), "accounts", []interface{}{})
+		for i := 0; i < self.Length(accounts); i++ {
+			balance := self.Member(accounts, i)
+			code := self.SafeCurrencyCode(self.SafeString(balance, "currency", ""))
+			account := self.Account()
+			total := self.SafeFloat(balance, "totalBalance", 0)
+			borrowed := self.SafeFloat(balance, "liability", 0)
+			account["total"] = total
+			account["free"] = self.SafeFloat(balance, "availableBalance", 0)
+			account["used"] = self.SafeFloat(balance, "holdBalance", 0)
+			account["borrowed"] = borrowed
+			account["netEquity"] = total - borrowed
+			result[code] = account
+		}
+		return self.ParseBalance(result), nil
 	}
+	// kucoin 的账户类型: main 为资金账户, trade_hf 为币币账户
+	request := map[string]interface{}{}
+	switch typ {
+	case AccountTypeSpot:
+		request["type"] = "trade_hf"
+	case AccountTypeFunding:
+		request["type"] = "main"
+	default:
+		self.UnsupportedAccountType(typ)
+	}
+	response := self.ApiFunc("privateGetAccounts", self.Extend(request, query), nil, nil)
+	result["info"] = response
+	data := self.SafeValue(response, "data", []interface{}{})
 	for i := 0; i < self.Length(data); i++ {
 		balance := self.Member(data, i)
 		currencyId := self.SafeString(balance, "currency", "")
@@ -656,6 +1054,420 @@ func (self *Kucoin) FetchBalance(params map[string]interface{}) (balanceResult *
 	return self.ParseBalance(result), nil
 }
 
+// Transfer 账户之间划转, 逐仓杠杆账户需要在 params 中给出 symbol
+func (self *Kucoin) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbol, query := self.MarginSymbol(params)
+	fromId := self.TransferAccountId(fromAccount)
+	toId := self.TransferAccountId(toAccount)
+	request := map[string]interface{}{
+		"clientOid": self.Uuid(),
+		"currency":  code,
+		"from":      fromId,
+		"to":        toId,
+		"amount":    self.Float64ToString(amount),
+	}
+	if symbol != "" {
+		if fromId == "isolated" {
+			request["fromTag"] = self.Market(symbol).Id
+		}
+		if toId == "isolated" {
+			request["toTag"] = self.Market(symbol).Id
+		}
+	}
+	response := self.ApiFunc("privatePostAccountsInnerTransfer", self.Extend(request, query), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	result = &Transfer{
+		Id:          self.SafeString(data, "orderId", ""),
+		Currency:    code,
+		Amount:      amount,
+		FromAccount: self.TransferAccountType(fromId),
+		ToAccount:   self.TransferAccountType(toId),
+		Status:      "ok",
+		Timestamp:   self.Milliseconds(),
+		Info:        response,
+	}
+	return
+}
+
+// FetchTransfers 从账户流水中查询划转记录, 每条记录只有转入或转出的一方
+func (self *Kucoin) FetchTransfers(code string, since int64, limit int64, params map[string]interface{}) (result []*Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"bizType": "TRANSFER",
+	}
+	if code != "" {
+		request["currency"] = code
+	}
+	if since > 0 {
+		request["startAt"] = since
+	}
+	if limit > 0 {
+		request["pageSize"] = limit
+	}
+	response := self.ApiFunc("privateGetAccountsLedgers", self.Extend(request, params), nil, nil)
+	items := self.SafeValue(self.SafeValue(response, "data", map[string]interface{}{}), "items", []interface{}{})
+	result = []*Transfer{}
+	for i := 0; i < self.Length(items); i++ {
+		item := self.Member(items, i)
+		transfer := &Transfer{
+			Id:        self.SafeString(item, "id", ""),
+			Currency:  self.SafeCurrencyCode(self.SafeString(item, "currency", "")),
+			Amount:    self.SafeFloat(item, "amount", 0),
+			Status:    "ok",
+			Timestamp: self.SafeInteger(item, "createdAt", 0),
+			Info:      item,
+		}
+		account := self.TransferAccountType(self.SafeString(item, "accountType", ""))
+		if self.SafeString(item, "direction", "") == "in" {
+			transfer.ToAccount = account
+		} else {
+			transfer.FromAccount = account
+		}
+		result = append(result, transfer)
+	}
+	return
+}
+
+// FetchTradingFee 交易所返回的费率已计入 VIP 等级, 开启 KCS 抵扣需设置 Options["kcsPayFees"] 为 true
+func (self *Kucoin) FetchTradingFee(symbol string, params map[string]interface{}) (result *TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	market := self.Market(symbol)
+	request := map[string]interface{}{
+		"symbols": market.Id,
+	}
+	response := self.ApiFunc("privateGetTradeFees", self.Extend(request, params), nil, nil)
+	data, _ := self.SafeValue(response, "data", nil).([]interface{})
+	if len(data) == 0 {
+		self.RaiseException("BadSymbol", self.Id+" fetchTradingFee no fee for "+symbol)
+	}
+	result = self.newTradingFee(symbol, self.SafeFloat(data[0], "makerFeeRate", 0), self.SafeFloat(data[0], "takerFeeRate", 0), data[0])
+	result.Tier = self.tradingFeeTier()
+	return
+}
+
+// FetchTradingFees 交易对的费率为账户的基础费率乘以交易对的费率系数, 见 /api/v2/symbols 的 makerFeeCoefficient
+func (self *Kucoin) FetchTradingFees(params map[string]interface{}) (result map[string]*TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	markets, err := self.FetchMarkets(nil)
+	if err != nil {
+		return nil, err
+	}
+	response := self.ApiFunc("privateGetBaseFee", params, nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	maker := self.SafeFloat(data, "makerFeeRate", 0)
+	taker := self.SafeFloat(data, "takerFeeRate", 0)
+	tier := self.tradingFeeTier()
+	result = map[string]*TradingFee{}
+	for _, market := range markets {
+		fee := self.newTradingFee(market.Symbol, maker*self.SafeFloat(market.Info, "makerFeeCoefficient", 1), taker*self.SafeFloat(market.Info, "takerFeeCoefficient", 1), data)
+		fee.Tier = tier
+		result[market.Symbol] = fee
+	}
+	return
+}
+
+func (self *Kucoin) tradingFeeTier() string {
+	response := self.ApiFunc("privateGetUserInfo", nil, nil, nil)
+	return fmt.Sprint(self.SafeInteger(self.SafeValue(response, "data", nil), "level", 0))
+}
+
+func (self *Kucoin) newTradingFee(symbol string, maker, taker float64, info interface{}) *TradingFee {
+	fee := &TradingFee{
+		Symbol: symbol,
+		Maker:  maker,
+		Taker:  taker,
+		Info:   info,
+	}
+	if self.ToBool(self.SafeValue(self.Options, "kcsPayFees", false)) {
+		fee.DiscountCurrency = "KCS"
+		fee.Discount = self.SafeFloat(self.Options, "kcsDiscount", 0.8)
+	}
+	return fee
+}
+
+// FetchLedger params 中的 type 为账户类型, 默认 spot 即高频交易账户, 其他账户使用普通的流水接口.
+// 交易所限制查询范围, 有 since 时取回 since 之后 24 小时内的所有流水, 否则只取最新的一页
+func (self *Kucoin) FetchLedger(code string, since int64, limit int64, params map[string]interface{}) (result []*LedgerEntry, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	typ, query := self.AccountType(params, AccountTypeSpot)
+	request := map[string]interface{}{}
+	if code != "" {
+		request["currency"] = self.CurrencyId(code)
+	}
+	if since > 0 {
+		request["startAt"] = since
+		request["endAt"] = since + self.SafeInteger(self.Options, "fetchLedgerWindow", 86400000) - 1
+	}
+	result = []*LedgerEntry{}
+	if typ != AccountTypeSpot {
+		request["pageSize"] = 500
+		for page := int64(1); ; page++ {
+			request["currentPage"] = page
+			response := self.ApiFunc("privateGetAccountsLedgers", self.Extend(request, query), nil, nil)
+			data := self.SafeValue(response, "data", map[string]interface{}{})
+			items, _ := self.SafeValue(data, "items", nil).([]interface{})
+			for _, item := range items {
+				result = append(result, self.parseLedgerEntry(item))
+			}
+			if since <= 0 || page >= self.SafeInteger(data, "totalPage", 0) {
+				break
+			}
+		}
+		return self.LimitLedger(result, since, limit), nil
+	}
+	// NOTE: 高频账户的流水按时间倒序, 用 lastId 翻页, 每页最多 200 条
+	request["limit"] = 200
+	for {
+		response := self.ApiFunc("privateGetHfAccountsLedgers", self.Extend(request, query), nil, nil)
+		items, _ := self.SafeValue(response, "data", nil).([]interface{})
+		for _, item := range items {
+			result = append(result, self.parseLedgerEntry(item))
+		}
+		if since <= 0 || len(items) < 200 {
+			break
+		}
+		request["lastId"] = self.SafeString(items[len(items)-1], "id", "")
+	}
+	return self.LimitLedger(result, since, limit), nil
+}
+
+func (self *Kucoin) parseLedgerEntry(item interface{}) *LedgerEntry {
+	amount := self.SafeFloat(item, "amount", 0)
+	after := self.SafeFloat(item, "balance", 0)
+	direction := self.SafeString(item, "direction", "")
+	before := after - amount
+	if direction == "out" {
+		before = after + amount
+	}
+	entry := &LedgerEntry{
+		Id:        self.SafeString(item, "id", ""),
+		Direction: direction,
+		Account:   self.TransferAccountType(self.SafeString(item, "accountType", "")),
+		Type:      self.ParseLedgerType(self.SafeString(item, "bizType", "")),
+		Currency:  self.SafeCurrencyCode(self.SafeString(item, "currency", "")),
+		Amount:    amount,
+		Before:    before,
+		After:     after,
+		Fee:       self.SafeFloat(item, "fee", 0),
+		Timestamp: self.SafeInteger(item, "createdAt", 0),
+		Info:      item,
+	}
+	// NOTE: context 是 JSON 字符串, 交易相关的流水包含 symbol, orderId 和 tradeId
+	context := map[string]interface{}{}
+	if json.Unmarshal([]byte(self.SafeString(item, "context", "")), &context) == nil {
+		entry.ReferenceId = self.SafeString(context, "tradeId", self.SafeString(context, "orderId", ""))
+		// NOTE: 不加载交易对, 和 Market 相反, 把 BTC-USDT 转换为 BTC/USDT
+		entry.Symbol = strings.Replace(self.SafeString(context, "symbol", ""), "-", "/", 1)
+	}
+	return entry
+}
+
+// SubAccountTransfer 只支持母账户和子账户之间划转, 子账户 id 为 subUserId
+func (self *Kucoin) SubAccountTransfer(code string, amount float64, fromSubAccount, toSubAccount string, params map[string]interface{}) (result *Transfer, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if (fromSubAccount == "") == (toSubAccount == "") {
+		self.RaiseException("NotSupported", self.Id+" subAccountTransfer only supports transfers between the master account and a sub-account")
+	}
+	fromAccount := self.SafeString(params, "fromAccount", AccountTypeSpot)
+	toAccount := self.SafeString(params, "toAccount", AccountTypeSpot)
+	query := self.Omit(self.Extend(params).(map[string]interface{}), []string{"fromAccount", "toAccount"})
+	fromId := strings.ToUpper(self.TransferAccountId(fromAccount))
+	toId := strings.ToUpper(self.TransferAccountId(toAccount))
+	request := map[string]interface{}{
+		"clientOid": self.Uuid(),
+		"currency":  code,
+		"amount":    self.Float64ToString(amount),
+	}
+	if fromSubAccount == "" {
+		request["direction"] = "OUT"
+		request["accountType"] = fromId
+		request["subAccountType"] = toId
+		request["subUserId"] = toSubAccount
+	} else {
+		request["direction"] = "IN"
+		request["accountType"] = toId
+		request["subAccountType"] = fromId
+		request["subUserId"] = fromSubAccount
+	}
+	response := self.ApiFunc("privatePostAccountsSubTransfer", self.Extend(request, query), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	result = &Transfer{
+		Id:             self.SafeString(data, "orderId", ""),
+		Currency:       code,
+		Amount:         amount,
+		FromAccount:    self.TransferAccountType(fromId),
+		ToAccount:      self.TransferAccountType(toId),
+		FromSubAccount: fromSubAccount,
+		ToSubAccount:   toSubAccount,
+		Status:         "ok",
+		Timestamp:      self.Milliseconds(),
+		Info:           response,
+	}
+	return
+}
+
+func (self *Kucoin) FetchDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.depositAddress("privateGetDepositAddresses", code, network, params), nil
+}
+
+func (self *Kucoin) CreateDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.depositAddress("privatePostDepositAddresses", code, network, params), nil
+}
+
+func (self *Kucoin) depositAddress(method string, code string, network string, params map[string]interface{}) *DepositAddress {
+	request := map[string]interface{}{
+		"currency": code,
+	}
+	if network != "" {
+		request["chain"] = self.NetworkId(network)
+	}
+	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	return &DepositAddress{
+		Currency: code,
+		Address:  self.SafeString(data, "address", ""),
+		Tag:      self.SafeString(data, "memo", ""),
+		Network:  self.NetworkCode(self.SafeString(data, "chain", network)),
+		Status:   "ok",
+		Info:     response,
+	}
+}
+
+func (self *Kucoin) Withdraw(code string, amount float64, address string, tag string, network string, params map[string]interface{}) (result *Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"currency": code,
+		"address":  address,
+		"amount":   self.Float64ToString(amount),
+	}
+	if tag != "" {
+		request["memo"] = tag
+	}
+	if network != "" {
+		request["chain"] = self.NetworkId(network)
+	}
+	response := self.ApiFunc("privatePostWithdrawals", self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	result = &Transaction{
+		Id:        self.SafeString(data, "withdrawalId", ""),
+		Type:      "withdrawal",
+		Currency:  code,
+		Network:   self.NetworkCode(self.NetworkId(network)),
+		Amount:    amount,
+		Address:   address,
+		Tag:       tag,
+		Status:    "pending",
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+	return
+}
+
+// FetchDeposits 充值记录没有 id, Id 为空
+func (self *Kucoin) FetchDeposits(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateGetDeposits", "deposit", code, since, limit, params), nil
+}
+
+func (self *Kucoin) FetchWithdrawals(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateGetWithdrawals", "withdrawal", code, since, limit, params), nil
+}
+
+func (self *Kucoin) fetchTransactions(method string, typ string, code string, since int64, limit int64, params map[string]interface{}) []*Transaction {
+	request := map[string]interface{}{}
+	if code != "" {
+		request["currency"] = code
+	}
+	if since > 0 {
+		request["startAt"] = since
+	}
+	if limit > 0 {
+		request["pageSize"] = limit
+	}
+	response := self.ApiFunc(method, self.Extend(request, params), nil, nil)
+	items := self.SafeValue(self.SafeValue(response, "data", map[string]interface{}{}), "items", []interface{}{})
+	result := []*Transaction{}
+	for i := 0; i < self.Length(items); i++ {
+		item := self.Member(items, i)
+		result = append(result, &Transaction{
+			Id:        self.SafeString(item, "id", ""),
+			Txid:      self.SafeString(item, "walletTxId", ""),
+			Type:      typ,
+			Currency:  self.SafeCurrencyCode(self.SafeString(item, "currency", "")),
+			Network:   self.NetworkCode(self.SafeString(item, "chain", "")),
+			Amount:    self.SafeFloat(item, "amount", 0),
+			Fee:       self.SafeFloat(item, "fee", 0),
+			Address:   self.SafeString(item, "address", ""),
+			Tag:       self.SafeString(item, "memo", ""),
+			Status:    self.parseTransactionStatus(self.SafeString(item, "status", "")),
+			Timestamp: self.SafeInteger(item, "createdAt", 0),
+			Updated:   self.SafeInteger(item, "updatedAt", 0),
+			Info:      item,
+		})
+	}
+	return result
+}
+
+func (self *Kucoin) parseTransactionStatus(status string) string {
+	statuses := map[string]interface{}{
+		"PROCESSING":        "pending",
+		"WALLET_PROCESSING": "pending",
+		"SUCCESS":           "ok",
+		"FAILURE":           "failed",
+	}
+	return self.SafeString(statuses, status, status)
+}
+
 func (self *Kucoin) Sign(path string, api string, method string, params map[string]interface{}, headers interface{}, body interface{}) (ret interface{}) {
 	versions := self.SafeValue(self.Options, "versions", map[string]interface{}{})
 	apiVersions := self.SafeValue(versions, api, nil)
@@ -730,5 +1542,7 @@ func (self *Kucoin) Market(symbol string) *Market {
 		Symbol: symbol,
 		Base:   li[0],
 		Quote:  li[1],
+		Type:   "spot",
+		Spot:   true,
 	}
 }
@@ -62,11 +62,14 @@ func TestAll(t *testing.T) {
 	testFetchOrderBook(t)
 	//testFetchTrades(t)
 	//testFetchTicker(t)
+	//testFetchTickers(t)
 	//testFetchOHLCV(t)
 	//testFetchBalance(t)
 	//order := testCreateOrder(t); _ = order
+	//testCreateOrders(t)
 	//testFetchOrder(t, "63a42a5f77056300018c3e48")
 	//testFetchOpenOrders(t)
+	//testEditOrder(t, "63a42a5f77056300018c3e48")
 	//testCancelOrder(t, "63a42a5f77056300018c3e48")
 
 	// 余额划转
@@ -134,6 +137,14 @@ func testFetchTicker(t *testing.T) {
 	log.Println("##### FetchTicker:", symbol, ex.JsonIndent(ticker))
 }
 
+func testFetchTickers(t *testing.T) {
+	tickers, err := ex.FetchTickers([]string{symbol}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTickers:", ex.JsonIndent(tickers))
+}
+
 func testFetchOHLCV(t *testing.T) {
 	klines, err := ex.FetchOHLCV(symbol, "1h", 0, 10, nil)
 	if err != nil {
@@ -163,6 +174,19 @@ func testCreateOrder(t *testing.T) *base.Order {
 	return order
 }
 
+func testCreateOrders(t *testing.T) []*base.OrderResult {
+	// @ CreateOrders
+	results, err := ex.CreateOrders([]*base.OrderRequest{
+		{Symbol: symbol, Type: "limit", Side: "buy", Amount: 0.001, Price: 10000},
+		{Symbol: symbol, Type: "limit", Side: "buy", Amount: 0.001, Price: 10001},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### CreateOrders:", symbol, ex.JsonIndent(results))
+	return results
+}
+
 func testFetchOrder(t *testing.T, orderId string) {
 	// @ FetchOrder
 	o, err := ex.FetchOrder(orderId, symbol, nil)
@@ -172,6 +196,16 @@ func testFetchOrder(t *testing.T, orderId string) {
 	log.Println("##### FetchOrder:", ex.JsonIndent(o))
 }
 
+func testEditOrder(t *testing.T, orderId string) *base.Order {
+	// @ EditOrder
+	order, err := ex.EditOrder(orderId, symbol, "limit", "buy", 0.001 /*amount*/, 10001 /*price*/, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### EditOrder:", symbol, ex.JsonIndent(order))
+	return order
+}
+
 func testFetchOpenOrders(t *testing.T) []*base.Order {
 	// @ FetchOpenOrders
 	openOrders, err := ex.FetchOpenOrders(symbol, 0, 0, nil)
@@ -82,6 +82,7 @@ func (self *MarginBitmax) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "assets",
                 "products",
@@ -149,6 +150,8 @@ func (self *MarginBitmax) Describe() []byte {
     "options": {
         "account-category": "margin",
         "account-group": null,
+        "accountTypes": ["margin", "spot", "swap"],
+        "liquidationLevel": 0,
         "fetchClosedOrders": {
             "method": "accountGroupGetOrderHist"
         }
@@ -223,3 +226,72 @@ func (self *MarginBitmax) Describe() []byte {
     }
 }`)
 }
+
+// Borrow bitmax 杠杆账户下单时自动借币, 没有单独的借币接口
+func (self *MarginBitmax) Borrow(code string, amount float64, params map[string]interface{}) (*MarginLoan, error) {
+	return nil, TypedError("NotSupported", self.Id+" borrows automatically when placing margin orders")
+}
+
+// Repay bitmax 卖出或转入时自动还币, 没有单独的还币接口
+func (self *MarginBitmax) Repay(code string, amount float64, params map[string]interface{}) (*MarginLoan, error) {
+	return nil, TypedError("NotSupported", self.Id+" repays automatically when the balance is sufficient")
+}
+
+// FetchBorrowInterest bitmax 只有全仓杠杆, 不返回利率
+func (self *MarginBitmax) FetchBorrowInterest(code string, params map[string]interface{}) (result []*BorrowInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFunc("accountGroupGetMarginBalance", self.Extend(self.accountGroupRequest(), params), nil, nil)
+	balances := self.SafeValue(response, "data", []interface{}{})
+	result = []*BorrowInterest{}
+	for i := 0; i < self.Length(balances); i++ {
+		balance := self.Member(balances, i)
+		currency := self.SafeCurrencyCode(self.SafeString(balance, "asset", ""))
+		borrowed := self.SafeFloat(balance, "borrowed", 0)
+		interest := self.SafeFloat(balance, "interest", 0)
+		if (code != "" && currency != code) || (code == "" && borrowed == 0 && interest == 0) {
+			continue
+		}
+		result = append(result, &BorrowInterest{
+			Currency:  currency,
+			Borrowed:  borrowed,
+			Interest:  interest,
+			Timestamp: self.Milliseconds(),
+			Info:      balance,
+		})
+	}
+	return
+}
+
+// FetchMarginRisk 资产和负债按 USDT 计价, LiquidationLevel 取 Options["liquidationLevel"]
+func (self *MarginBitmax) FetchMarginRisk(params map[string]interface{}) (result *MarginRisk, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFunc("accountGroupGetMarginRisk", self.Extend(self.accountGroupRequest(), params), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	result = &MarginRisk{
+		TotalAsset:       self.SafeFloat(data, "totalBalanceInUSDT", 0),
+		TotalDebt:        self.SafeFloat(data, "totalBorrowedInUSDT", 0) + self.SafeFloat(data, "totalInterestInUSDT", 0),
+		LiquidationLevel: self.SafeFloat(self.Options, "liquidationLevel", 0),
+		Currency:         "USDT",
+		Info:             response,
+	}
+	if result.TotalDebt > 0 {
+		result.MarginLevel = result.TotalAsset / result.TotalDebt
+	}
+	return
+}
+
+func (self *MarginBitmax) accountGroupRequest() map[string]interface{} {
+	self.LoadAccounts()
+	account := self.SafeValue(self.Accounts, 0, map[string]interface{}{})
+	return map[string]interface{}{
+		"account-group": self.SafeString(account, "id", ""),
+	}
+}
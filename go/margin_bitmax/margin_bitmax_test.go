@@ -5,7 +5,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	. "github.com/epheien/ccxt/go/base"
 )
 
 func init() {
@@ -55,6 +60,7 @@ func TestFetchOrderBook(t *testing.T) {
 		t.Fatal(err)
 	}
 	log.Println("##### FetchBalance:", ex.Json(balance))
+	//testMargin(t, ex)
 	return
 
 	// @ CreateOrder
@@ -88,3 +94,60 @@ func TestFetchOrderBook(t *testing.T) {
 	}
 
 }
+
+func testMargin(t *testing.T, ex *MarginBitmax) {
+	// @ FetchBorrowInterest
+	interests, err := ex.FetchBorrowInterest("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchBorrowInterest:", ex.Json(interests))
+
+	// @ FetchMarginRisk
+	risk, err := ex.FetchMarginRisk(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchMarginRisk:", ex.Json(risk))
+}
+
+// 杠杆账户的 Total 为总额, Portfolio 扣除借款和利息后估值
+func TestPortfolioMargin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/margin/balance") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"code":0,"data":[{"asset":"BTC","totalBalance":"1.5","availableBalance":"1.2","borrowed":"0.5","interest":"0.01"}]}`))
+	}))
+	defer server.Close()
+
+	ex, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex.ApiKey = "key"
+	ex.Secret = "secret"
+	ex.Urls["api"] = server.URL
+	ex.Accounts = []interface{}{map[string]interface{}{"id": "group"}}
+	ex.SetMarkets([]*Market{{Id: "BTC/USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT", BaseId: "BTC", QuoteId: "USDT"}}, nil)
+
+	balance, err := ex.FetchBalance(map[string]interface{}{"type": AccountTypeMargin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if btc := balance.Account["BTC"]; btc.Total != 1.5 || btc.Borrowed != 0.5 || btc.Interest != 0.01 || btc.NetEquity != 0.99 {
+		t.Fatalf("unexpected balance: %+v", btc)
+	}
+
+	p := NewPortfolio("USDT")
+	p.AddVenue("margin", ex, AccountTypeMargin)
+	p.FixedRates["BTC/USDT"] = 20000
+	valuation, err := p.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valuation.Assets["BTC"].Amount != 0.99 || valuation.Total != 19800 {
+		t.Fatalf("unexpected valuation: %s", ex.Json(valuation))
+	}
+}
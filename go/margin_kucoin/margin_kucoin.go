@@ -1,6 +1,8 @@
 package margin_kucoin
 
 import (
+	"strings"
+
 	. "github.com/epheien/ccxt/go/base"
 	"github.com/epheien/ccxt/go/kucoin"
 )
@@ -85,6 +87,7 @@ func (self *MarginKucoin) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "timestamp",
                 "status",
@@ -101,6 +104,7 @@ func (self *MarginKucoin) Describe() []byte {
                 "market/stats",
                 "currencies",
                 "currencies/{currency}",
+                "v3/currencies",
                 "prices",
                 "mark-price/{symbol}/current",
                 "margin/config"
@@ -111,8 +115,12 @@ func (self *MarginKucoin) Describe() []byte {
         },
         "private": {
             "get": [
+                "base-fee",
+                "trade-fees",
+                "user-info",
                 "accounts",
                 "accounts/{accountId}",
+                "accounts/ledgers",
                 "accounts/{accountId}/ledgers",
                 "accounts/{accountId}/holds",
                 "accounts/transferable",
@@ -141,7 +149,12 @@ func (self *MarginKucoin) Describe() []byte {
                 "margin/lend/trade/settled",
                 "margin/lend/assets",
                 "margin/market",
-                "margin/margin/trade/last"
+                "margin/margin/trade/last",
+                "isolated/symbols",
+                "isolated/accounts",
+                "isolated/account/{symbol}",
+                "isolated/borrow/outstanding",
+                "isolated/borrow/repaid"
             ],
             "post": [
                 "accounts",
@@ -157,7 +170,10 @@ func (self *MarginKucoin) Describe() []byte {
                 "margin/lend",
                 "margin/toggle-auto-lend",
                 "bullet-private",
-                "margin/order"
+                "margin/order",
+                "isolated/borrow",
+                "isolated/repay/all",
+                "isolated/repay/single"
             ],
             "delete": [
                 "withdrawals/{withdrawalId}",
@@ -244,9 +260,37 @@ func (self *MarginKucoin) Describe() []byte {
         "version": "v1",
         "symbolSeparator": "-",
         "tradeType": "MARGIN_TRADE",
+        "borrowStrategy": "FOK",
+        "repaySequence": "RECENTLY_EXPIRE_FIRST",
         "fetchMyTradesMethod": "private_get_fills",
-        "fetchBalance": {
-            "type": "trade"
+        "fetchTradesSince": false,
+        "accountTypes": ["margin", "isolated", "spot", "funding"],
+        "accountsByType": {
+            "spot": "trade",
+            "margin": "margin",
+            "isolated": "isolated",
+            "funding": "main"
+        },
+        "kcsPayFees": false,
+        "kcsDiscount": 0.8,
+        "fetchLedgerWindow": 86400000,
+        "ledgerTypes": {
+            "Exchange": "trade",
+            "Trade_Exchange": "trade",
+            "TRADE_EXCHANGE": "trade",
+            "KCS Pay Fees": "fee",
+            "Refunded Fees": "rebate",
+            "Rebate": "rebate",
+            "Deposit": "deposit",
+            "Withdrawal": "withdrawal",
+            "Transfer": "transfer",
+            "TRANSFER": "transfer",
+            "Sub-account transfer": "transfer"
+        },
+        "networks": {
+            "ERC20": "eth",
+            "TRC20": "trx",
+            "BEP20": "bsc"
         },
         "versions": {
             "public": {
@@ -259,6 +303,9 @@ func (self *MarginKucoin) Describe() []byte {
                 }
             },
             "private": {
+                "GET": {
+                    "user-info": "v2"
+                },
                 "POST": {
                     "accounts/inner-transfer": "v2",
                     "accounts/sub-transfer": "v2"
@@ -268,3 +315,252 @@ func (self *MarginKucoin) Describe() []byte {
     }
 }`)
 }
+
+// Borrow 全仓借币, params 中给出 symbol 时为逐仓借币
+func (self *MarginKucoin) Borrow(code string, amount float64, params map[string]interface{}) (result *MarginLoan, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	symbol, query := self.MarginSymbol(params)
+	request := map[string]interface{}{
+		"currency": self.CurrencyId(code),
+		"size":     self.Float64ToString(amount),
+	}
+	strategy := self.SafeString(self.Options, "borrowStrategy", "FOK")
+	method := "privatePostMarginBorrow"
+	if symbol != "" {
+		request["symbol"] = self.Market(symbol).Id
+		request["borrowStrategy"] = strategy
+		method = "privatePostIsolatedBorrow"
+	} else {
+		request["type"] = strategy
+	}
+	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	result = &MarginLoan{
+		Id:        self.SafeString(data, "orderId", ""),
+		Currency:  code,
+		Amount:    self.SafeFloat(data, "actualSize", amount),
+		Symbol:    symbol,
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+	return
+}
+
+// Repay 按 repaySequence 的顺序还币, 默认先还最早到期的
+func (self *MarginKucoin) Repay(code string, amount float64, params map[string]interface{}) (result *MarginLoan, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	symbol, query := self.MarginSymbol(params)
+	request := map[string]interface{}{
+		"currency": self.CurrencyId(code),
+		"size":     self.Float64ToString(amount),
+	}
+	sequence := self.SafeString(self.Options, "repaySequence", "RECENTLY_EXPIRE_FIRST")
+	method := "privatePostMarginRepayAll"
+	if symbol != "" {
+		request["symbol"] = self.Market(symbol).Id
+		request["seqStrategy"] = sequence
+		method = "privatePostIsolatedRepayAll"
+	} else {
+		request["sequence"] = sequence
+	}
+	response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
+	result = &MarginLoan{
+		Currency:  code,
+		Amount:    amount,
+		Symbol:    symbol,
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+	return
+}
+
+// FetchBorrowInterest 按币种汇总未还的借款, kucoin 每笔借款单独计息, InterestRate 取最后一笔的日利率.
+// 每页最多 50 笔, 依次查询所有页
+func (self *MarginKucoin) FetchBorrowInterest(code string, params map[string]interface{}) (result []*BorrowInterest, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	symbol, query := self.MarginSymbol(params)
+	request := map[string]interface{}{
+		"pageSize": 50,
+	}
+	if code != "" {
+		request["currency"] = self.CurrencyId(code)
+	}
+	method := "privateGetMarginBorrowOutstanding"
+	liabilityKey, interestKey, rateKey := "liability", "accruedInterest", "dailyIntRate"
+	if symbol != "" {
+		request["symbol"] = self.Market(symbol).Id
+		method = "privateGetIsolatedBorrowOutstanding"
+		liabilityKey, interestKey, rateKey = "liabilityBalance", "interestBalance", "dailyInterestRate"
+	}
+	items := []interface{}{}
+	for page := int64(1); ; page++ {
+		request["currentPage"] = page
+		response := self.ApiFunc(method, self.Extend(request, query), nil, nil)
+		data := self.SafeValue(response, "data", map[string]interface{}{})
+		pageItems, _ := self.SafeValue(data, "items", nil).([]interface{})
+		items = append(items, pageItems...)
+		if len(pageItems) == 0 || page >= self.SafeInteger(data, "totalPage", 0) {
+			break
+		}
+	}
+	result = []*BorrowInterest{}
+	byCurrency := map[string]*BorrowInterest{}
+	for _, item := range items {
+		currency := self.SafeCurrencyCode(self.SafeString(item, "currency", ""))
+		interest := byCurrency[currency]
+		if interest == nil {
+			interest = &BorrowInterest{
+				Currency: currency,
+				Symbol:   symbol,
+				Info:     []interface{}{},
+			}
+			byCurrency[currency] = interest
+			result = append(result, interest)
+		}
+		accrued := self.SafeFloat(item, interestKey, 0)
+		// liability 为剩余本金加利息
+		interest.Borrowed += self.SafeFloat(item, liabilityKey, 0) - accrued
+		interest.Interest += accrued
+		interest.InterestRate = self.SafeFloat(item, rateKey, interest.InterestRate)
+		timestamp := self.SafeInteger(item, "createdAt", 0)
+		if timestamp > interest.Timestamp {
+			interest.Timestamp = timestamp
+		}
+		interest.Info = append(interest.Info.([]interface{}), item)
+	}
+	return
+}
+
+// FetchMaxBorrowable 逐仓时 code 需为交易对的 base 或 quote
+func (self *MarginKucoin) FetchMaxBorrowable(code string, params map[string]interface{}) (result *MaxBorrowable, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	self.LoadMarkets()
+	symbol, query := self.MarginSymbol(params)
+	result = &MaxBorrowable{
+		Currency: code,
+		Symbol:   symbol,
+	}
+	if symbol != "" {
+		response := self.fetchIsolatedAccount(symbol, query)
+		data := self.SafeValue(response, "data", map[string]interface{}{})
+		for _, key := range []string{"baseAsset", "quoteAsset"} {
+			asset := self.SafeValue(data, key, map[string]interface{}{})
+			if self.SafeCurrencyCode(self.SafeString(asset, "currency", "")) == code {
+				result.Amount = self.SafeFloat(asset, "borrowableAmount", 0)
+				result.Info = asset
+				return
+			}
+		}
+		self.RaiseException("BadRequest", self.Id+" "+code+" is not a currency of "+symbol)
+	}
+	response := self.ApiFunc("privateGetMarginAccount", query, nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	accounts := self.SafeValue(data, "accounts", []interface{}{})
+	for i := 0; i < self.Length(accounts); i++ {
+		account := self.Member(accounts, i)
+		if self.SafeCurrencyCode(self.SafeString(account, "currency", "")) == code {
+			result.Amount = self.SafeFloat(account, "maxBorrowSize", 0)
+			result.Info = account
+			return
+		}
+	}
+	self.RaiseException("BadRequest", self.Id+" "+code+" is not a margin currency")
+	return
+}
+
+// FetchMarginRisk kucoin 用债务率表示风险, 转换为 MarginLevel.
+// 接口不返回折算后的总资产和总负债, TotalAsset 和 TotalDebt 按各币种的余额和负债以 USD 价格折算
+func (self *MarginKucoin) FetchMarginRisk(params map[string]interface{}) (result *MarginRisk, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbol, query := self.MarginSymbol(params)
+	if symbol != "" {
+		marketId := self.Market(symbol).Id
+		response := self.fetchIsolatedAccount(symbol, query)
+		data := self.SafeValue(response, "data", map[string]interface{}{})
+		result = &MarginRisk{
+			Symbol:      symbol,
+			MarginLevel: self.DebtRatioToMarginLevel(self.SafeFloat(data, "debtRatio", 0)),
+			Currency:    "USD",
+			Info:        response,
+		}
+		assets := []interface{}{self.SafeValue(data, "baseAsset", nil), self.SafeValue(data, "quoteAsset", nil)}
+		result.TotalAsset, result.TotalDebt = self.valueAssets(assets)
+		configs := self.ApiFunc("privateGetIsolatedSymbols", nil, nil, nil)
+		items := self.SafeValue(configs, "data", []interface{}{})
+		for i := 0; i < self.Length(items); i++ {
+			item := self.Member(items, i)
+			if self.SafeString(item, "symbol", "") == marketId {
+				result.LiquidationLevel = self.DebtRatioToMarginLevel(self.SafeFloat(item, "liquidationDebtRatio", 0))
+				break
+			}
+		}
+		return
+	}
+	response := self.ApiFunc("privateGetMarginAccount", query, nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	config := self.ApiFunc("publicGetMarginConfig", nil, nil, nil)
+	liqDebtRatio := self.SafeFloat(self.SafeValue(config, "data", map[string]interface{}{}), "liqDebtRatio", 0)
+	result = &MarginRisk{
+		MarginLevel:      self.DebtRatioToMarginLevel(self.SafeFloat(data, "debtRatio", 0)),
+		LiquidationLevel: self.DebtRatioToMarginLevel(liqDebtRatio),
+		Currency:         "USD",
+		Info:             response,
+	}
+	accounts, _ := self.SafeValue(data, "accounts", []interface{}{}).([]interface{})
+	result.TotalAsset, result.TotalDebt = self.valueAssets(accounts)
+	return
+}
+
+// valueAssets 按 USD 价格折算各币种的 totalBalance 和 liability 之和
+func (self *MarginKucoin) valueAssets(assets []interface{}) (totalAsset, totalDebt float64) {
+	ids := []string{}
+	for _, asset := range assets {
+		if self.SafeFloat(asset, "totalBalance", 0) != 0 || self.SafeFloat(asset, "liability", 0) != 0 {
+			ids = append(ids, self.SafeString(asset, "currency", ""))
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	request := map[string]interface{}{
+		"base":       "USD",
+		"currencies": strings.Join(ids, ","),
+	}
+	prices := self.SafeValue(self.ApiFunc("publicGetPrices", request, nil, nil), "data", map[string]interface{}{})
+	for _, asset := range assets {
+		price := self.SafeFloat(prices, self.SafeString(asset, "currency", ""), 0)
+		totalAsset += self.SafeFloat(asset, "totalBalance", 0) * price
+		totalDebt += self.SafeFloat(asset, "liability", 0) * price
+	}
+	return
+}
+
+func (self *MarginKucoin) fetchIsolatedAccount(symbol string, params map[string]interface{}) interface{} {
+	request := map[string]interface{}{
+		"symbol": self.Market(symbol).Id,
+	}
+	return self.ApiFunc("privateGetIsolatedAccountSymbol", self.Extend(request, params), nil, nil)
+}
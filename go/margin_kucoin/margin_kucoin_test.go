@@ -4,7 +4,12 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	. "github.com/epheien/ccxt/go/base"
 )
 
 func init() {
@@ -55,6 +60,7 @@ func TestFetchOrderBook(t *testing.T) {
 		t.Fatal(err)
 	}
 	log.Println("##### FetchBalance:", ex.Json(balance))
+	//testMargin(t, ex)
 	return
 
 	// @ CreateOrder
@@ -85,3 +91,83 @@ func TestFetchOrderBook(t *testing.T) {
 	}
 	log.Println("##### CancelOrder:", resp)
 }
+
+func testMargin(t *testing.T, ex *MarginKucoin) {
+	// @ Borrow, 逐仓时传参数 "symbol": "BTC/USDT"
+	loan, err := ex.Borrow("USDT", 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### Borrow:", ex.Json(loan))
+
+	// @ FetchBorrowInterest
+	interests, err := ex.FetchBorrowInterest("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchBorrowInterest:", ex.Json(interests))
+
+	// @ FetchMaxBorrowable
+	maxBorrowable, err := ex.FetchMaxBorrowable("USDT", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchMaxBorrowable:", ex.Json(maxBorrowable))
+
+	// @ FetchMarginRisk
+	risk, err := ex.FetchMarginRisk(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchMarginRisk:", ex.Json(risk))
+
+	// @ Repay
+	loan, err = ex.Repay("USDT", 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### Repay:", ex.Json(loan))
+}
+
+// 杠杆账户的 Total 为总额, liability 含利息, Portfolio 扣除后估值
+func TestPortfolioMargin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/margin/account") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"code":"200000","data":{"accounts":[{"currency":"BTC","totalBalance":"1.5","availableBalance":"1.2","holdBalance":"0.3","liability":"0.51"}]}}`))
+	}))
+	defer server.Close()
+
+	ex, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex.ApiKey = "key"
+	ex.Secret = "secret"
+	ex.Password = "password"
+	for api := range ex.Urls["api"].(map[string]interface{}) {
+		ex.Urls["api"].(map[string]interface{})[api] = server.URL
+	}
+	ex.SetMarkets([]*Market{{Id: "BTC-USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT", BaseId: "BTC", QuoteId: "USDT"}}, nil)
+
+	balance, err := ex.FetchBalance(map[string]interface{}{"type": AccountTypeMargin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if btc := balance.Account["BTC"]; btc.Total != 1.5 || btc.Borrowed != 0.51 || btc.NetEquity != 0.99 {
+		t.Fatalf("unexpected balance: %+v", btc)
+	}
+
+	p := NewPortfolio("USDT")
+	p.AddVenue("margin", ex, AccountTypeMargin)
+	p.FixedRates["BTC/USDT"] = 20000
+	valuation, err := p.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valuation.Assets["BTC"].Amount != 0.99 || valuation.Total != 19800 {
+		t.Fatalf("unexpected valuation: %s", ex.Json(valuation))
+	}
+}
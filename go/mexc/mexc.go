@@ -42,6 +42,7 @@ func (self *Mexc) Describe() []byte {
         "CORS": false,
         "createMarketOrder": false,
         "fetchCurrencies": true,
+        "fetchTicker": true,
         "fetchTickers": true,
         "withdraw": true,
         "fetchDeposits": true,
@@ -59,18 +60,15 @@ func (self *Mexc) Describe() []byte {
     },
     // 值只支持字符串形式
     "timeframes": {
-    //     "1m": 60,
-    //     "5m": 300,
-    //     "10m": 600,
-    //     "15m": 900,
-    //     "30m": 1800,
-    //     "1h": 3600,
-    //     "2h": 7200,
-    //     "4h": 14400,
-    //     "6h": 21600,
-    //     "12h": 43200,
-    //     "1d": 86400,
-    //     "1w": 604800
+        "1m": "1m",
+        "5m": "5m",
+        "15m": "15m",
+        "30m": "30m",
+        "1h": "60m",
+        "4h": "4h",
+        "1d": "1d",
+        "1w": "1W",
+        "1M": "1M"
     },
     "urls": {
         "logo": "https://www.mexc.com/images/full-logo-light-ko.svg",
@@ -83,12 +81,16 @@ func (self *Mexc) Describe() []byte {
     },
     "api": {
         "public": {
+            "cacheable": true,
             "get": [
                 "ping",
                 "time",
                 "defaultSymbols",
                 "exchangeInfo",
                 "depth",
+                "klines",
+                "ticker/24hr",
+                "ticker/bookTicker",
             ]
         },
         "private": {
@@ -99,11 +101,18 @@ func (self *Mexc) Describe() []byte {
                 "allOrders",
                 "myTrades",
                 "mxDeduct/enable",
+                "capital/deposit/address",
+                "capital/deposit/hisrec",
+                "capital/withdraw/history",
+                "capital/config/getall",
+                "tradeFee",
             ],
             "post": [
                 "order",
                 "batchOrders",
                 "mxDeduct/enable",
+                "capital/deposit/address",
+                "capital/withdraw/apply",
             ],
             "delete": [
                 "order",
@@ -129,6 +138,8 @@ func (self *Mexc) Describe() []byte {
     },
     "options": {
         "fetchTradesMethod": "public_get_tradehistory_id",
+        "fetchOHLCVLimit": 1000,
+        "fetchTradesLimit": 1000,
         "limits": {
             "cost": {
                 "min": {
@@ -138,7 +149,9 @@ func (self *Mexc) Describe() []byte {
                 }
             }
         },
-        "account": "spot"
+        "account": "spot",
+        "accountTypes": ["spot"],
+        "mxDiscount": 0.8
     },
 }
 `)
@@ -151,6 +164,8 @@ func (self *Mexc) Market(symbol string) *Market {
 		Symbol: symbol,
 		Base:   li[0],
 		Quote:  li[1],
+		Type:   "spot",
+		Spot:   true,
 	}
 }
 
@@ -246,18 +261,135 @@ func (self *Mexc) FetchOrderBook(symbol string, limit int64, params map[string]i
 	}
 	response := self.ApiFunc("publicGetDepth", self.Extend(request, params), nil, nil)
 	orderbook := self.ParseOrderBook(response, 0, "bids", "asks", 0, 1)
+	orderbook.Nonce = self.SafeInteger(response, "lastUpdateId", 0)
 	return orderbook, nil
 }
 
+func (self *Mexc) ParseTicker(ticker interface{}) *Ticker {
+	timestamp := self.SafeInteger(ticker, "closeTime", 0)
+	last := self.SafeFloat(ticker, "lastPrice", 0)
+	return &Ticker{
+		Timestamp:   timestamp,
+		Datetime:    self.Iso8601(timestamp),
+		Last:        last,
+		Bid:         self.SafeFloat(ticker, "bidPrice", 0),
+		BidQty:      self.SafeFloat(ticker, "bidQty", 0),
+		Ask:         self.SafeFloat(ticker, "askPrice", 0),
+		AskQty:      self.SafeFloat(ticker, "askQty", 0),
+		Open:        self.SafeFloat(ticker, "openPrice", 0),
+		High:        self.SafeFloat(ticker, "highPrice", 0),
+		Low:         self.SafeFloat(ticker, "lowPrice", 0),
+		Close:       last,
+		BaseVolume:  self.SafeFloat(ticker, "volume", 0),
+		QuoteVolume: self.SafeFloat(ticker, "quoteVolume", 0),
+		Change:      self.SafeFloat(ticker, "priceChange", 0),
+		// NOTE: priceChangePercent 是小数形式, 如 0.0123
+		Percentage: self.SafeFloat(ticker, "priceChangePercent", 0) * 100,
+		Info:       ticker,
+	}
+}
+
+func (self *Mexc) FetchTicker(symbol string, params map[string]interface{}) (ticker *Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"symbol": self.MarketId(symbol),
+	}
+	response := self.ApiFunc("publicGetTicker24hr", self.Extend(request, params), nil, nil)
+	ticker = self.ParseTicker(response)
+	ticker.Symbol = symbol
+	return ticker, nil
+}
+
+// NOTE: 交易对 id 没有分隔符, 未指定 symbols 时需要加载市场信息来转换
+func (self *Mexc) FetchTickers(symbols []string, params map[string]interface{}) (tickers []*Ticker, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	marketsById := map[string]string{}
+	for _, symbol := range symbols {
+		marketsById[self.MarketId(symbol)] = symbol
+	}
+	if len(symbols) == 0 {
+		for id, market := range self.Exchange.LoadMarkets() {
+			marketsById[market.Id] = id
+		}
+	}
+	response := self.ApiFuncReturnList("publicGetTicker24hr", params, nil, nil)
+	tickers = []*Ticker{}
+	for _, item := range response {
+		symbol, ok := marketsById[self.SafeString(item, "symbol", "")]
+		if !ok {
+			continue
+		}
+		ticker := self.ParseTicker(item)
+		ticker.Symbol = symbol
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
+}
+
+// [openTime, open, high, low, close, volume, closeTime, quoteVolume]
+func (self *Mexc) ParseOHLCV(ohlcv interface{}) *OHLCV {
+	data := ohlcv.([]interface{})
+	return &OHLCV{
+		Timestamp: ToInteger(data[0]),
+		Open:      ToFloat(data[1]),
+		High:      ToFloat(data[2]),
+		Low:       ToFloat(data[3]),
+		Close:     ToFloat(data[4]),
+		Volume:    ToFloat(data[5]),
+		Info:      ohlcv,
+	}
+}
+
+func (self *Mexc) FetchOHLCV(symbol, timeframe string, since int64, limit int64, params map[string]interface{}) (klines []*OHLCV, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	interval, ok := self.Timeframes[timeframe]
+	if !ok {
+		self.RaiseException("BadRequest", self.Id+" fetchOHLCV does not support timeframe "+timeframe)
+	}
+	request := map[string]interface{}{
+		"symbol":   self.MarketId(symbol),
+		"interval": interval,
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList("publicGetKlines", self.Extend(request, params), nil, nil)
+	klines = []*OHLCV{}
+	for _, item := range response {
+		klines = append(klines, self.ParseOHLCV(item))
+	}
+	return klines, nil
+}
+
 func (self *Mexc) FetchBalance(params map[string]interface{}) (balanceResult *Account, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = self.PanicToError(e)
 		}
 	}()
-	response := self.ApiFunc("privateGetAccount", params, nil, nil)
+	typ, query := self.AccountType(params, AccountTypeSpot)
+	if typ != AccountTypeSpot {
+		self.UnsupportedAccountType(typ)
+	}
+	response := self.ApiFunc("privateGetAccount", query, nil, nil)
 	result := map[string]interface{}{
 		"info": response,
+		"type": typ,
 	}
 	for _, one := range response["balances"].([]interface{}) {
 		account := self.Account()
@@ -282,31 +414,34 @@ func (self *Mexc) CreateOrder(symbol string, _type string, side string, amount f
 		self.RaiseException("ExchangeError", self.Id+" allows limit orders only")
 	}
 	marketId := self.MarketId(symbol)
+	clientOrderId, query := self.ParseClientOrderId(params, "newClientOrderId")
 	request := map[string]interface{}{
-		"type":     strings.ToUpper(_type),
-		"symbol":   marketId,
-		"side":     strings.ToUpper(side),
-		"price":    self.Float64ToString(price),
-		"quantity": self.Float64ToString(amount),
+		"type":             strings.ToUpper(_type),
+		"symbol":           marketId,
+		"side":             strings.ToUpper(side),
+		"price":            self.Float64ToString(price),
+		"quantity":         self.Float64ToString(amount),
+		"newClientOrderId": clientOrderId,
 	}
-	response := self.ApiFunc("privatePostOrder", self.Extend(request, params), nil, nil)
+	response := self.ApiFunc("privatePostOrder", self.Extend(request, query), nil, nil)
 	data := response
 	timestamp := self.SafeInteger(response, "transactTime")
 	order := map[string]interface{}{
-		"id":        self.SafeString(data, "id"),
-		"symbol":    symbol,
-		"type":      _type,
-		"side":      side,
-		"price":     price,
-		"amount":    amount,
-		"cost":      nil,
-		"filled":    nil,
-		"remaining": nil,
-		"timestamp": timestamp,
-		"datetime":  self.Iso8601(timestamp),
-		"fee":       nil,
-		"status":    "open",
-		"info":      data,
+		"id":            self.SafeString2(data, "orderId", "id", ""),
+		"clientOrderId": clientOrderId,
+		"symbol":        symbol,
+		"type":          _type,
+		"side":          side,
+		"price":         price,
+		"amount":        amount,
+		"cost":          nil,
+		"filled":        nil,
+		"remaining":     nil,
+		"timestamp":     timestamp,
+		"datetime":      self.Iso8601(timestamp),
+		"fee":           nil,
+		"status":        "open",
+		"info":          data,
 	}
 	return self.ToOrder(order), nil
 }
@@ -314,9 +449,14 @@ func (self *Mexc) CreateOrder(symbol string, _type string, side string, amount f
 func (self *Mexc) ParseOrderStatus(status string) string {
 	// NOTE: 类型必须为 map[string]interface{}, 否则无法使用 SafeString
 	statuses := map[string]interface{}{
-		"open":      "open",
-		"closed":    "closed",
-		"cancelled": "canceled",
+		"open":               "open",
+		"closed":             "closed",
+		"cancelled":          "canceled",
+		"NEW":                "open",
+		"PARTIALLY_FILLED":   "open",
+		"FILLED":             "closed",
+		"CANCELED":           "canceled",
+		"PARTIALLY_CANCELED": "canceled",
 	}
 	return self.SafeString(statuses, status, status)
 }
@@ -326,17 +466,23 @@ func (self *Mexc) ParseOrder(order interface{}, market interface{}) (result map[
 	if market != nil {
 		symbol = market.(*Market).Symbol
 	}
-	orderId := self.SafeString(order, "id")
-	timestamp := self.SafeInteger(order, "create_time_ms")
+	// NOTE: 同时兼容 v3 接口的字段
+	orderId := self.SafeString2(order, "orderId", "id", "")
+	timestamp := self.SafeInteger2(order, "time", "create_time_ms", 0)
 	status := self.ParseOrderStatus(self.SafeString(order, "status"))
-	side := self.SafeString(order, "side")
+	side := self.SafeStringLower(order, "side", "")
 	price := self.SafeFloat(order, "price")
-	amount := self.SafeFloat(order, "amount")
+	amount := self.SafeFloat2(order, "origQty", "amount", 0)
 	remaining := self.SafeFloat(order, "left")
+	if executedQty, ok := self.SafeValue(order, "executedQty", nil).(string); ok {
+		remaining = amount - ToFloat(executedQty)
+	}
 	filled := amount - remaining
+	clientOrderId := self.SafeString(order, "clientOrderId", "")
 
 	return map[string]interface{}{
 		"id":                 orderId,
+		"clientOrderId":      clientOrderId,
 		"symbol":             symbol,
 		"side":               side,
 		"amount":             amount,
@@ -429,11 +575,8 @@ func (self *Mexc) FetchOrder(id string, symbol string, params map[string]interfa
 		self.RaiseException("ArgumentsRequired", "symbol")
 	}
 	market := self.Market(symbol)
-	request := map[string]interface{}{
-		"order_id":      id,
-		"currency_pair": market.Id,
-	}
-	response := self.ApiFunc("privateGetSpotOrdersOrderId", self.Extend(request, params), nil, nil)
+	request, query := self.orderIdRequest(market, id, params)
+	response := self.ApiFunc("privateGetOrder", self.Extend(request, query), nil, nil)
 	return self.ToOrder(self.ParseOrder(response, market)), nil
 }
 
@@ -448,12 +591,300 @@ func (self *Mexc) CancelOrder(id string, symbol string, params map[string]interf
 	}
 
 	market := self.Market(symbol)
+	request, query := self.orderIdRequest(market, id, params)
+	response = self.ApiFunc("privateDeleteOrder", self.Extend(request, query), nil, nil)
+	return response, nil
+}
+
+// params 中有 clientOrderId 时按 origClientOrderId 查询或撤单, 否则按 orderId
+func (self *Mexc) orderIdRequest(market *Market, id string, params map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
 	request := map[string]interface{}{
-		"order_id":      id,
-		"currency_pair": market.Id,
+		"symbol": market.Id,
 	}
-	response = self.ApiFunc("privateDeleteSpotOrdersOrderId", self.Extend(request, params), nil, nil)
-	return response, nil
+	query := self.Extend(params).(map[string]interface{})
+	if clientOrderId := self.SafeString(query, "clientOrderId", ""); clientOrderId != "" {
+		request["origClientOrderId"] = clientOrderId
+	} else {
+		request["orderId"] = id
+	}
+	delete(query, "clientOrderId")
+	return request, query
+}
+
+// FetchTradingFee 开启 MX 抵扣时 DiscountCurrency 为 MX
+func (self *Mexc) FetchTradingFee(symbol string, params map[string]interface{}) (result *TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	result = self.fetchTradingFee(symbol, params)
+	self.setFeeDiscount(result)
+	return
+}
+
+// FetchTradingFees 交易所只有单个交易对的费率接口, 逐个查询, 交易对较多时很慢.
+// params["symbols"] ([]string) 指定要查询的交易对, 默认为所有可交易的交易对
+func (self *Mexc) FetchTradingFees(params map[string]interface{}) (result map[string]*TradingFee, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	symbols, _ := self.SafeValue(params, "symbols", nil).([]string)
+	if len(symbols) == 0 {
+		markets, err := self.FetchMarkets(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, market := range markets {
+			if market.Active {
+				symbols = append(symbols, market.Symbol)
+			}
+		}
+	}
+	query := self.Omit(params, "symbols")
+	result = map[string]*TradingFee{}
+	for _, symbol := range symbols {
+		result[symbol] = self.fetchTradingFee(symbol, query)
+	}
+	if len(result) > 0 {
+		discount := &TradingFee{}
+		self.setFeeDiscount(discount)
+		for _, fee := range result {
+			fee.DiscountCurrency = discount.DiscountCurrency
+			fee.Discount = discount.Discount
+		}
+	}
+	return result, nil
+}
+
+func (self *Mexc) fetchTradingFee(symbol string, params map[string]interface{}) *TradingFee {
+	request := map[string]interface{}{
+		"symbol": self.Market(symbol).Id,
+	}
+	response := self.ApiFunc("privateGetTradeFee", self.Extend(request, params), nil, nil)
+	data := self.SafeValue(response, "data", map[string]interface{}{})
+	return &TradingFee{
+		Symbol: symbol,
+		Maker:  self.SafeFloat(data, "makerCommission", 0),
+		Taker:  self.SafeFloat(data, "takerCommission", 0),
+		Info:   response,
+	}
+}
+
+// setFeeDiscount 开启 MX 抵扣时设置 DiscountCurrency 和 Discount
+func (self *Mexc) setFeeDiscount(fee *TradingFee) {
+	deduct := self.ApiFunc("privateGetMxDeductEnable", nil, nil, nil)
+	if self.ToBool(self.SafeValue(self.SafeValue(deduct, "data", nil), "mxDeductEnable", false)) {
+		fee.DiscountCurrency = "MX"
+		fee.Discount = self.SafeFloat(self.Options, "mxDiscount", 0.8)
+	}
+}
+
+// FetchCurrencies 需要 apiKey
+func (self *Mexc) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	response := self.ApiFuncReturnList("privateGetCapitalConfigGetall", params, nil, nil)
+	result = map[string]*Currency{}
+	for _, entry := range response {
+		id := self.SafeString(entry, "coin", "")
+		code := self.SafeCurrencyCode(id)
+		currency := &Currency{
+			Id:   id,
+			Code: code,
+			Name: self.SafeString(entry, "name", ""),
+			Info: entry,
+		}
+		networkList, _ := self.SafeValue(entry, "networkList", nil).([]interface{})
+		for _, item := range networkList {
+			self.AddCurrencyNetwork(currency, &CurrencyNetwork{
+				Id:            self.SafeString(item, "network", ""),
+				Name:          self.SafeString(item, "name", ""),
+				Deposit:       self.ToBool(self.SafeValue(item, "depositEnable", false)),
+				Withdraw:      self.ToBool(self.SafeValue(item, "withdrawEnable", false)),
+				Fee:           self.SafeFloat(item, "withdrawFee", 0),
+				FeeKnown:      self.SafeValue(item, "withdrawFee", nil) != nil,
+				WithdrawMin:   self.SafeFloat(item, "withdrawMin", 0),
+				WithdrawMax:   self.SafeFloat(item, "withdrawMax", 0),
+				Confirmations: self.SafeInteger(item, "minConfirm", 0),
+				Info:          item,
+			})
+		}
+		result[code] = currency
+	}
+	return result, nil
+}
+
+// FetchDepositAddress 交易所返回该币种所有链的地址, network 为空时取第一个
+func (self *Mexc) FetchDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"coin": code,
+	}
+	if network != "" {
+		request["network"] = self.NetworkId(network)
+	}
+	response := self.ApiFuncReturnList("privateGetCapitalDepositAddress", self.Extend(request, params), nil, nil)
+	for _, item := range response {
+		if network == "" || self.SafeString(item, "network", "") == self.NetworkId(network) {
+			return self.parseDepositAddress(item, code), nil
+		}
+	}
+	self.RaiseException("InvalidAddress", self.Id+" fetchDepositAddress no "+network+" address for "+code+", use createDepositAddress")
+	return
+}
+
+func (self *Mexc) CreateDepositAddress(code string, network string, params map[string]interface{}) (result *DepositAddress, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	if network == "" {
+		self.RaiseException("ArgumentsRequired", self.Id+" createDepositAddress requires a network argument")
+	}
+	request := map[string]interface{}{
+		"coin":    code,
+		"network": self.NetworkId(network),
+	}
+	response := self.ApiFunc("privatePostCapitalDepositAddress", self.Extend(request, params), nil, nil)
+	return self.parseDepositAddress(response, code), nil
+}
+
+func (self *Mexc) parseDepositAddress(item interface{}, code string) *DepositAddress {
+	return &DepositAddress{
+		Currency: code,
+		Address:  self.SafeString(item, "address", ""),
+		Tag:      self.SafeString(item, "memo", ""),
+		Network:  self.NetworkCode(self.SafeString(item, "network", "")),
+		Status:   "ok",
+		Info:     item,
+	}
+}
+
+func (self *Mexc) Withdraw(code string, amount float64, address string, tag string, network string, params map[string]interface{}) (result *Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	request := map[string]interface{}{
+		"coin":    code,
+		"address": address,
+		"amount":  self.Float64ToString(amount),
+	}
+	if tag != "" {
+		request["memo"] = tag
+	}
+	if network != "" {
+		request["network"] = self.NetworkId(network)
+	}
+	response := self.ApiFunc("privatePostCapitalWithdrawApply", self.Extend(request, params), nil, nil)
+	result = &Transaction{
+		Id:        self.SafeString(response, "id", ""),
+		Type:      "withdrawal",
+		Currency:  code,
+		Network:   self.NetworkCode(self.NetworkId(network)),
+		Amount:    amount,
+		Address:   address,
+		Tag:       tag,
+		Status:    "pending",
+		Timestamp: self.Milliseconds(),
+		Info:      response,
+	}
+	return
+}
+
+func (self *Mexc) FetchDeposits(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateGetCapitalDepositHisrec", "deposit", code, since, limit, params), nil
+}
+
+func (self *Mexc) FetchWithdrawals(code string, since int64, limit int64, params map[string]interface{}) (result []*Transaction, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
+	return self.fetchTransactions("privateGetCapitalWithdrawHistory", "withdrawal", code, since, limit, params), nil
+}
+
+func (self *Mexc) fetchTransactions(method string, typ string, code string, since int64, limit int64, params map[string]interface{}) []*Transaction {
+	request := map[string]interface{}{}
+	if code != "" {
+		request["coin"] = code
+	}
+	if since > 0 {
+		request["startTime"] = since
+	}
+	if limit > 0 {
+		request["limit"] = limit
+	}
+	response := self.ApiFuncReturnList(method, self.Extend(request, params), nil, nil)
+	result := []*Transaction{}
+	for _, item := range response {
+		transaction := &Transaction{
+			Id:       self.SafeString(item, "id", ""),
+			Txid:     self.SafeString(item, "txId", ""),
+			Type:     typ,
+			Currency: self.SafeCurrencyCode(self.SafeString(item, "coin", "")),
+			Network:  self.NetworkCode(self.SafeString(item, "network", "")),
+			Amount:   self.SafeFloat(item, "amount", 0),
+			Fee:      self.SafeFloat(item, "transactionFee", 0),
+			Address:  self.SafeString(item, "address", ""),
+			Tag:      self.SafeString(item, "memo", ""),
+			Status:   self.parseTransactionStatus(typ, self.SafeString(item, "status", "")),
+			Info:     item,
+		}
+		if typ == "deposit" {
+			transaction.Timestamp = self.SafeInteger(item, "insertTime", 0)
+		} else {
+			transaction.Timestamp = self.SafeInteger(item, "applyTime", 0)
+			transaction.Updated = self.SafeInteger(item, "updateTime", 0)
+		}
+		result = append(result, transaction)
+	}
+	return result
+}
+
+func (self *Mexc) parseTransactionStatus(typ string, status string) string {
+	statuses := map[string]interface{}{
+		"1": "failed",
+		"2": "pending",
+		"3": "pending",
+		"4": "pending",
+		"5": "ok",
+		"6": "pending",
+		"7": "failed",
+	}
+	if typ == "withdrawal" {
+		statuses = map[string]interface{}{
+			"1":  "pending",
+			"2":  "pending",
+			"3":  "pending",
+			"4":  "pending",
+			"5":  "pending",
+			"6":  "pending",
+			"7":  "ok",
+			"8":  "failed",
+			"9":  "canceled",
+			"10": "pending",
+		}
+	}
+	return self.SafeString(statuses, status, status)
 }
 
 func (self *Mexc) genSign(query string, timestamp int64) (string, string) {
@@ -62,6 +62,7 @@ func TestAll(t *testing.T) {
 	//testFetchOrderBook(t)
 	//testFetchTrades(t)
 	//testFetchTicker(t)
+	//testFetchTickers(t)
 	//testFetchOHLCV(t)
 	//testFetchBalance(t)
 	//order := testCreateOrder(t); _ = order
@@ -126,6 +127,14 @@ func testFetchTicker(t *testing.T) {
 	log.Println("##### FetchTicker:", symbol, ex.JsonIndent(ticker))
 }
 
+func testFetchTickers(t *testing.T) {
+	tickers, err := ex.FetchTickers([]string{symbol}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Println("##### FetchTickers:", ex.JsonIndent(tickers))
+}
+
 func testFetchOHLCV(t *testing.T) {
 	klines, err := ex.FetchOHLCV(symbol, "1h", 0, 10, nil)
 	if err != nil {
@@ -53,7 +53,7 @@ func (self *Okex) Describe() []byte {
         "fetchDepositAddress": true,
         "fetchOrderTrades": true,
         "fetchTickers": true,
-        "fetchLedger": true,
+        "fetchLedger": false,
         "withdraw": true,
         "futures": true
     },
@@ -641,6 +641,7 @@ func (self *Okex) Describe() []byte {
             "option"
         ],
         "defaultType": "spot",
+        "accountTypes": ["funding", "spot", "futures", "swap"],
         "auth": {
             "time": "public",
             "currencies": "private",
@@ -789,51 +790,35 @@ func (self *Okex) FetchMarketsByType(typ string, params map[string]interface{})
 	return nil
 }
 
-func (self *Okex) FetchCurrencies(params map[string]interface{}) map[string]interface{} {
+func (self *Okex) FetchCurrencies(params map[string]interface{}) (result map[string]*Currency, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = self.PanicToError(e)
+		}
+	}()
 	response := self.ApiFunc("accountGetCurrencies", params, nil, nil)
-	result := map[string]interface{}{}
+	result = map[string]*Currency{}
 	for i := 0; i < self.Length(response); i++ {
 		currency := self.Member(response, i)
 		id := self.SafeString(currency, "currency", "")
 		code := self.SafeCurrencyCode(id)
-		precision := 8
-		name := self.SafeString(currency, "name", "")
-		canDeposit := self.SafeInteger(currency, "can_deposit", 0)
-		canWithdraw := self.SafeInteger(currency, "can_withdraw", 0)
-		active := false
-		if canDeposit != 0 && canWithdraw != 0 {
-			active = true
+		result[code] = &Currency{
+			Id:        id,
+			Code:      code,
+			Name:      self.SafeString(currency, "name", ""),
+			Precision: 8,
+			Info:      currency,
 		}
-		self.SetValue(result, code, map[string]interface{}{
-			"id":        id,
-			"code":      code,
-			"info":      currency,
-			"type":      nil,
-			"name":      name,
-			"active":    active,
-			"fee":       nil,
-			"precision": precision,
-			"limits": map[string]interface{}{
-				"amount": map[string]interface{}{
-					"min": nil,
-					"max": nil,
-				},
-				"price": map[string]interface{}{
-					"min": nil,
-					"max": nil,
-				},
-				"cost": map[string]interface{}{
-					"min": nil,
-					"max": nil,
-				},
-				"withdraw": map[string]interface{}{
-					"min": self.SafeFloat(currency, "min_withdrawal", 0),
-					"max": nil,
-				},
-			},
+		self.AddCurrencyNetwork(result[code], &CurrencyNetwork{
+			Id:          id,
+			Deposit:     self.SafeInteger(currency, "can_deposit", 0) != 0,
+			Withdraw:    self.SafeInteger(currency, "can_withdraw", 0) != 0,
+			WithdrawMin: self.SafeFloat(currency, "min_withdrawal", 0),
+			Precision:   8,
+			Info:        currency,
 		})
 	}
-	return result
+	return result, nil
 }
 
 func (self *Okex) FetchOrderBook(symbol string, limit int64, params map[string]interface{}) (orderBook *OrderBook, err error) {
@@ -981,19 +966,25 @@ func (self *Okex) FetchBalance(params map[string]interface{}) (balanceResult *Ac
 		}
 	}()
 	defaultType := self.SafeString2(self.Options, "fetchBalance", "defaultType", "")
-	typ := self.SafeString(params, "type", defaultType)
-	if self.ToBool(self.TestNil(typ)) {
-		self.RaiseException("ArgumentsRequired", self.Id+" fetchBalance requires a type parameter (one of account, spot, margin, futures, swap)")
+	if defaultType == "" && self.SafeString(params, "type", "") == "" {
+		self.RaiseException("ArgumentsRequired", self.Id+" fetchBalance requires a type parameter (one of funding, spot, margin, futures, swap)")
 	}
+	typ, query := self.AccountType(params, defaultType)
 	self.LoadMarkets()
+	// okex 的资金账户为 account
+	okexType := typ
 	suffix := "Accounts"
-	if typ == "account" {
+	if typ == AccountTypeFunding {
+		okexType = "account"
 		suffix = "Wallet"
+	} else if typ == AccountTypeIsolated {
+		self.UnsupportedAccountType(typ)
 	}
-	method := typ + "Get" + suffix
-	query := self.Omit(params, "type")
+	method := okexType + "Get" + suffix
 	response := self.ApiFuncReturnList(method, query, nil, nil)
-	return self.ParseBalanceByType(typ, response), nil
+	balanceResult = self.ParseBalanceByType(okexType, response)
+	balanceResult.Type = typ
+	return balanceResult, nil
 }
 
 func (self *Okex) CreateOrder(symbol string, typ string, side string, amount float64, price float64, params map[string]interface{}) (result *Order, err error) {